@@ -6,18 +6,23 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/project"
 	"github.com/VI-IM/im_backend_go/ent/schema"
 	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/internal/auth"
 	s3client "github.com/VI-IM/im_backend_go/internal/client"
 	"github.com/VI-IM/im_backend_go/internal/config"
 	"github.com/VI-IM/im_backend_go/internal/database"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/internal/domain/enums"
+	"github.com/VI-IM/im_backend_go/internal/jobs"
 	"github.com/VI-IM/im_backend_go/internal/repository"
 	"github.com/VI-IM/im_backend_go/internal/router"
 	"github.com/VI-IM/im_backend_go/internal/static"
@@ -70,6 +75,13 @@ func main() {
 		logger.Get().Fatal().Err(err).Msg("Failed to load configuration")
 	}
 
+	if err := auth.LoadSigningKeys(); err != nil {
+		logger.Get().Fatal().Err(err).Msg("Failed to load auth keys")
+	}
+	if !auth.SigningKeysConfigured() {
+		logger.Get().Warn().Msg("JWT_SIGNING_KEYS is not set, signing access tokens with AUTH_JWT_SECRET")
+	}
+
 	// Log configuration values
 	cfg := config.GetConfig()
 
@@ -83,9 +95,18 @@ func main() {
 
 	smsClient := s3client.NewSMSClient()
 	crmClient := s3client.NewCRMClient(cfg.CRM)
+	mailer := s3client.NewMailer(cfg.Mail)
+
+	stampDuty, err := domain.LoadStampDutyTable(cfg.Finance.StampDutyTableFile)
+	if err != nil {
+		logger.Get().Fatal().Err(err).Msg("Failed to load stamp duty table")
+	}
 
 	repo := repository.NewRepository(client)
-	app := application.NewApplication(repo, s3Client, smsClient, crmClient)
+	app := application.NewApplication(repo, s3Client, smsClient, crmClient, mailer, stampDuty)
+	if err := app.EnsureDefaultRoles(ctx); err != nil {
+		logger.Get().Fatal().Err(err).Msg("Failed to seed role permissions")
+	}
 
 	// Initialize static assets loader
 	if cfg.StaticAssetsURL != "" {
@@ -99,14 +120,57 @@ func main() {
 		logger.Get().Info().Msg("No static assets URL configured, will serve from build directory")
 	}
 
+	// Start background jobs; they stop when the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	scheduler := jobs.NewScheduler()
+	scheduler.Register(jobs.NewReraExpiryJob(app, cfg.Rera.CheckInterval))
+	scheduler.Register(jobs.NewDeletionPurgeJob(app, cfg.Deletion.PurgeInterval))
+	scheduler.Register(jobs.NewSlugSyncJob(app, cfg.Slug.SyncInterval))
+	scheduler.Register(jobs.NewPropertyAttributesSyncJob(app, cfg.PropertySearch.AttributesSyncInterval))
+	scheduler.Register(jobs.NewEventFlushJob(app, cfg.Events.FlushInterval))
+	scheduler.Register(jobs.NewEventRollupJob(app, cfg.Events.RollupInterval))
+	scheduler.Register(jobs.NewTrendingRecomputeJob(app, cfg.Trending.RecomputeInterval))
+	scheduler.Register(jobs.NewSavedSearchAlertJob(app, cfg.SavedSearch.AlertInterval))
+	scheduler.Start(jobsCtx)
+
 	// Initialize router
 	router.Init(app)
 
 	// Start server
-	logger.Get().Info().Msgf("Server starting on port %d", cfg.Port)
-	if err := http.ListenAndServe(":"+strconv.Itoa(cfg.Port), router.Router); err != nil {
+	server := &http.Server{
+		Addr:    ":" + strconv.Itoa(cfg.Port),
+		Handler: router.Router,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Get().Info().Msgf("Server starting on port %d", cfg.Port)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-serverErr:
 		logger.Get().Fatal().Err(err).Msg("Failed to start server")
+	case sig := <-signals:
+		logger.Get().Info().Str("signal", sig.String()).Msg("Shutting down server")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to shut down server gracefully")
+	}
+
+	stopJobs()
+	scheduler.Wait()
+
+	// Write the events buffered since the last flush
+	if _, customErr := app.FlushListingEvents(ctx); customErr != nil {
+		logger.Get().Error().Str("error", customErr.Message).Msg("Failed to flush listing events on shutdown")
 	}
+	logger.Get().Info().Msg("Server stopped")
 }
 
 func runMigration(ctx context.Context) {
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// APIKey is a credential for server-to-server integrations. It grants its own set of
+// permissions and is not tied to a user. Only the SHA-256 hash of the key is stored; the
+// prefix is kept in clear so a key can be recognised in listings and logs.
+type APIKey struct {
+	ent.Schema
+}
+
+func (APIKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("name"),
+		field.String("prefix"),
+		field.String("key_hash").Unique().Sensitive(),
+		field.JSON("permissions", []string{}),
+		// allowed_ips holds addresses and CIDR ranges; empty allows any address
+		field.JSON("allowed_ips", []string{}).Optional(),
+		field.String("created_by_user_id"),
+		field.Time("expires_at").Optional().Nillable(),
+		field.Time("last_used_at").Optional().Nillable(),
+		field.String("last_used_ip").Optional(),
+		field.Time("revoked_at").Optional().Nillable(),
+		field.String("revoked_by_user_id").Optional(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
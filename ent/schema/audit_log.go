@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditLog records who changed what. Entries are only ever inserted; nothing updates or
+// deletes them.
+type AuditLog struct {
+	ent.Schema
+}
+
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique().Immutable(),
+		field.String("actor_user_id").Immutable(),
+		field.String("actor_role").Optional().Immutable(),
+		field.String("api_key_id").Optional().Immutable(),
+		field.String("ip_address").Optional().Immutable(),
+		field.String("action").Immutable(),
+		field.String("entity_type").Immutable(),
+		field.String("entity_id").Immutable(),
+		field.JSON("before", map[string]interface{}{}).Optional().Immutable(),
+		field.JSON("after", map[string]interface{}{}).Optional().Immutable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity_type", "entity_id"),
+		index.Fields("actor_user_id"),
+		index.Fields("created_at"),
+		index.Fields("action"),
+	}
+}
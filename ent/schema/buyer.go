@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Buyer is a public buyer account, signed in with a one-time code sent to the phone.
+// Buyers are kept apart from the internal User table and get their own tokens.
+type Buyer struct {
+	ent.Schema
+}
+
+func (Buyer) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("phone").Unique(),
+		field.String("name").Optional(),
+		field.String("email").Optional(),
+		field.String("otp_hash").Optional().Sensitive(),
+		field.Time("otp_expires_at").Optional().Nillable(),
+		field.Int("otp_attempts").Default(0),
+		field.Time("otp_sent_at").Optional().Nillable(),
+		field.Time("phone_verified_at").Optional().Nillable(),
+		field.Time("last_login_at").Optional().Nillable(),
+		field.Bool("is_active").Default(true),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+func (Buyer) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("email"),
+	}
+}
@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// BuyerComparison is a named set of projects a buyer compares side by side.
+type BuyerComparison struct {
+	ent.Schema
+}
+
+func (BuyerComparison) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("buyer_id"),
+		field.String("name").Optional(),
+		field.JSON("project_ids", []string{}),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+func (BuyerComparison) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("buyer_id"),
+	}
+}
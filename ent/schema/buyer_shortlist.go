@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// BuyerShortlist is a project or property a buyer has shortlisted.
+type BuyerShortlist struct {
+	ent.Schema
+}
+
+func (BuyerShortlist) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("buyer_id"),
+		field.Enum("entity_type").Values("project", "property"),
+		field.String("entity_id"),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (BuyerShortlist) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("buyer_id", "entity_type", "entity_id").Unique(),
+	}
+}
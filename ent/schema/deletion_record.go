@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// DeletionRecord tracks a soft-deleted project or property until it is restored or purged.
+type DeletionRecord struct {
+	ent.Schema
+}
+
+func (DeletionRecord) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.Enum("entity_type").Values("project", "property"),
+		field.String("entity_id"),
+		field.String("slug").Optional(),
+		field.String("redirect_to").Optional(),
+		field.JSON("cascaded_property_ids", []string{}).Optional(),
+		field.JSON("report", DeletionReport{}).Optional(),
+		field.String("deleted_by").Optional(),
+		field.Time("deleted_at").Default(time.Now).Immutable(),
+		field.Time("restore_until"),
+		field.Time("restored_at").Optional().Nillable(),
+		field.Time("purged_at").Optional().Nillable(),
+	}
+}
+
+func (DeletionRecord) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity_type", "entity_id"),
+		index.Fields("restore_until"),
+	}
+}
+
+// DeletionReport lists the records that depend on a project or property.
+type DeletionReport struct {
+	EntityType        string            `json:"entity_type"`
+	EntityID          string            `json:"entity_id"`
+	Name              string            `json:"name"`
+	Slug              string            `json:"slug"`
+	Properties        []DependentRecord `json:"properties"`
+	CustomSearchPages []DependentRecord `json:"custom_search_pages"`
+	LeadCount         int               `json:"lead_count"`
+	ReraRegistrations int               `json:"rera_registrations"`
+}
+
+type DependentRecord struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
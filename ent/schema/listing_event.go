@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ListingEvent is one visitor interaction with a project or property page. Rows are
+// written in batches and summarised into ListingEventRollup.
+type ListingEvent struct {
+	ent.Schema
+}
+
+func (ListingEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.Enum("entity_type").Values("project", "property"),
+		field.String("entity_id"),
+		field.Enum("event_type").Values("page_view", "gallery_open", "brochure_download", "call_click", "whatsapp_click"),
+		field.String("session_id").Optional(),
+		field.String("source").Optional(),
+		field.Time("occurred_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (ListingEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity_type", "entity_id", "occurred_at"),
+		index.Fields("occurred_at"),
+	}
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ListingEventRollup is the number of events of one type on one listing during one IST
+// calendar day, stored as YYYY-MM-DD so it compares and groups without time zones.
+type ListingEventRollup struct {
+	ent.Schema
+}
+
+func (ListingEventRollup) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.Enum("entity_type").Values("project", "property"),
+		field.String("entity_id"),
+		field.Enum("event_type").Values("page_view", "gallery_open", "brochure_download", "call_click", "whatsapp_click"),
+		field.String("date"),
+		field.Int("count").Default(0),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+func (ListingEventRollup) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity_type", "entity_id", "event_type", "date").Unique(),
+		index.Fields("date"),
+	}
+}
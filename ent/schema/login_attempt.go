@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// LoginAttempt is one password sign-in or 2FA step, successful or not. It is the login
+// history of users and the source of per-address throttling.
+type LoginAttempt struct {
+	ent.Schema
+}
+
+func (LoginAttempt) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("user_id").Optional(), // empty when the email matched no user
+		field.String("email"),
+		field.String("ip_address").Optional(),
+		field.String("ip_network").Optional(), // what per-address throttling counts against
+		field.String("user_agent").Optional(),
+		field.Bool("success"),
+		field.String("failure_reason").Optional(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (LoginAttempt) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+		index.Fields("ip_network", "created_at"),
+	}
+}
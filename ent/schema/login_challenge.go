@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// LoginChallenge is the short-lived second step of a password sign-in for a user with
+// 2FA. A "verify" challenge is answered with a TOTP or recovery code; an "enroll"
+// challenge is issued to users whose role requires 2FA before they have set it up.
+// Only the SHA-256 hash of the challenge token is stored.
+type LoginChallenge struct {
+	ent.Schema
+}
+
+func (LoginChallenge) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("user_id"),
+		field.Enum("purpose").Values("verify", "enroll"),
+		field.String("token_hash").Unique().Sensitive(),
+		field.Int("attempts").Default(0),
+		field.Time("expires_at"),
+		field.Time("used_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (LoginChallenge) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+	}
+}
@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Notification is an in-app message to a user, such as the outcome of a moderation review.
+type Notification struct {
+	ent.Schema
+}
+
+func (Notification) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("user_id"),
+		field.String("kind"),
+		field.String("title"),
+		field.Text("message").Optional(),
+		field.String("entity_type").Optional(),
+		field.String("entity_id").Optional(),
+		field.Time("read_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (Notification) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "read_at"),
+	}
+}
@@ -34,6 +34,7 @@ func (Project) Fields() []ent.Field {
 		field.Bool("is_priority").Default(false).Optional(),
 		field.Bool("is_deleted").Default(false).Optional(),
 		field.JSON("search_context", []string{}).Optional(),
+		field.Time("price_changed_at").Optional().Nillable(), // last change of min_price or max_price
 		field.Time("deleted_at").Optional().Nillable(),
 		field.Time("created_at").Default(time.Now).Immutable(),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
@@ -45,6 +46,7 @@ func (Project) Edges() []ent.Edge {
 		edge.To("properties", Property.Type),
 		edge.From("location", Location.Type).Ref("projects").Unique(),
 		edge.From("developer", Developer.Type).Ref("projects").Unique(),
+		edge.To("rera_registrations", ReraRegistration.Type),
 	}
 }
 
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ProjectPopularity caches the computed trending score of a project. The table is
+// rebuilt periodically from recent leads and view events.
+type ProjectPopularity struct {
+	ent.Schema
+}
+
+func (ProjectPopularity) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("project_id"),
+		field.String("city").Optional(),
+		field.String("locality").Optional(),
+		field.Float("score").Default(0),
+		field.Int("leads").Default(0),
+		field.Int("verified_leads").Default(0),
+		field.Int("views").Default(0),
+		field.Time("computed_at").Default(time.Now),
+	}
+}
+
+func (ProjectPopularity) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("project", Project.Type).
+			Unique().
+			Required().
+			Field("project_id"),
+	}
+}
+
+func (ProjectPopularity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("project_id").Unique(),
+		index.Fields("city", "score"),
+		index.Fields("locality", "score"),
+		index.Fields("score"),
+	}
+}
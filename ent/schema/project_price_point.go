@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ProjectPricePoint is one entry in a project's price history, recorded whenever its
+// price range or a price list configuration changes. An empty configuration is the
+// project-wide min/max range; otherwise it names a price list entry such as "3 BHK".
+type ProjectPricePoint struct {
+	ent.Schema
+}
+
+func (ProjectPricePoint) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("project_id"),
+		field.String("configuration").Default(""),
+		field.String("size").Optional(),
+		field.Float("min_price"), // in rupees
+		field.Float("max_price"), // in rupees
+		field.Time("recorded_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (ProjectPricePoint) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("project_id", "configuration", "recorded_at"),
+	}
+}
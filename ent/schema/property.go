@@ -6,6 +6,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 type Property struct {
@@ -27,11 +28,29 @@ func (Property) Fields() []ent.Field {
 		field.JSON("search_context", []string{}).Optional(),
 		field.Bool("is_deleted").Default(false),
 		field.Bool("is_featured").Default(false),
+		field.Int("view_count").Default(0),
+		field.Enum("listing_type").Values("primary_sale", "resale", "rent", "lease").Default("primary_sale"),
+		field.Time("available_from").Optional().Nillable(),
+		// Partner submissions stay pending until a superadmin approves them
+		field.Enum("moderation_status").Values("pending", "approved", "rejected").Default("approved"),
 		field.String("project_id").Optional(),
 		field.String("developer_id").Optional(),
 		field.String("location_id").Optional(),
 		field.String("created_by_user_id").Optional(),
 		field.Time("deleted_at").Optional().Nillable(),
+		// Normalized from web_cards and pricing_info for filtering and sorting
+		field.Float("price").Optional().Nillable(), // in rupees
+		field.Float("built_up_area_sqft").Optional().Nillable(),
+		field.Float("price_per_sqft").Optional().Nillable(),
+		field.Float("monthly_rent").Optional().Nillable(), // rent and lease listings, in rupees
+		field.Int("bedrooms").Optional().Nillable(),
+		field.Int("bathrooms").Optional().Nillable(),
+		field.String("furnishing").Optional().Nillable(),
+		field.String("facing").Optional().Nillable(),
+		field.Int("floor_number").Optional().Nillable(), // 0 is the ground floor
+		field.String("possession").Optional().Nillable(),
+		field.Int("age_years").Optional().Nillable(),
+		field.Time("attributes_synced_at").Optional().Nillable(),
 		field.Time("created_at").Default(time.Now).Immutable(),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
@@ -57,6 +76,17 @@ func (Property) Edges() []ent.Edge {
 	}
 }
 
+func (Property) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("price"),
+		index.Fields("bedrooms"),
+		index.Fields("price_per_sqft"),
+		index.Fields("listing_type"),
+		index.Fields("monthly_rent"),
+		index.Fields("moderation_status"),
+	}
+}
+
 type PropertyReraInfo struct {
 	ReraNumber string `json:"rera_number,omitempty"`
 }
@@ -90,7 +120,11 @@ type PropertyFloorPlan struct {
 
 // pricing information
 type PropertyPricingInfo struct {
-	Price string `json:"price,omitempty"` // selling price
+	Price           string `json:"price,omitempty"`            // selling price, for primary sale and resale
+	MonthlyRent     string `json:"monthly_rent,omitempty"`     // rent and lease
+	SecurityDeposit string `json:"security_deposit,omitempty"` // rent and lease
+	Maintenance     string `json:"maintenance,omitempty"`      // monthly maintenance charges
+	IsNegotiable    bool   `json:"is_negotiable,omitempty"`
 }
 
 // property details
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// PropertyRevision is a business partner's property submission awaiting moderation. A
+// create revision approves a new property; an update revision holds edits to a live one.
+type PropertyRevision struct {
+	ent.Schema
+}
+
+func (PropertyRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("property_id"),
+		field.Enum("action").Values("create", "update"),
+		field.Enum("status").Values("pending", "approved", "rejected", "superseded").Default("pending"),
+		field.JSON("snapshot", PropertySnapshot{}),
+		field.String("submitted_by_user_id"),
+		field.String("reviewed_by_user_id").Optional(),
+		field.String("review_reason").Optional(),
+		field.Time("reviewed_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+func (PropertyRevision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("property_id", "status"),
+		index.Fields("status"),
+	}
+}
+
+// PropertySnapshot is the moderated content of a property. Slug is set only when the
+// submission asks for an explicit slug.
+type PropertySnapshot struct {
+	Name             string              `json:"name"`
+	Slug             string              `json:"slug,omitempty"`
+	PropertyType     string              `json:"property_type,omitempty"`
+	PropertyImages   []string            `json:"property_images,omitempty"`
+	WebCards         WebCards            `json:"web_cards"`
+	PricingInfo      PropertyPricingInfo `json:"pricing_info"`
+	PropertyReraInfo PropertyReraInfo    `json:"property_rera_info"`
+	MetaInfo         PropertyMetaInfo    `json:"meta_info"`
+	ListingType      string              `json:"listing_type,omitempty"`
+	AvailableFrom    *time.Time          `json:"available_from,omitempty"`
+}
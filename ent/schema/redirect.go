@@ -0,0 +1,25 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Redirect maps a path that no longer exists to the path that replaces it.
+type Redirect struct {
+	ent.Schema
+}
+
+func (Redirect) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("from_path").Unique(),
+		field.String("to_path"),
+		field.Int("status_code").Default(301),
+		field.String("reason").Optional(), // e.g. purge
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// RefreshToken is one issued refresh token. Only the SHA-256 hash of the token is stored.
+// Every login starts a family; each refresh marks the presented token used and issues the
+// next token in the same family, so presenting a used token again reveals a stolen copy.
+type RefreshToken struct {
+	ent.Schema
+}
+
+func (RefreshToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("user_id"),
+		field.String("family_id"),
+		field.String("token_hash").Unique().Sensitive(),
+		field.String("user_agent").Optional(),
+		field.String("ip_address").Optional(),
+		field.Time("session_started_at"),
+		field.Time("expires_at"),
+		field.Time("used_at").Optional().Nillable(),
+		field.String("replaced_by_id").Optional(),
+		field.Time("revoked_at").Optional().Nillable(),
+		field.String("revoked_reason").Optional(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (RefreshToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+		index.Fields("family_id"),
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ReraRegistration holds the schema definition for a RERA registration of a project phase.
+type ReraRegistration struct {
+	ent.Schema
+}
+
+func (ReraRegistration) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("project_id"),
+		field.String("phase").Optional(),
+		field.String("state"),     // state code of the issuing authority, e.g. UP, MH, HR
+		field.String("authority"), // e.g. UP RERA, MahaRERA
+		field.String("registration_number"),
+		field.Time("valid_from").Optional().Nillable(),
+		field.Time("valid_until").Optional().Nillable(),
+		field.Enum("status").
+			Values("pending", "active", "expiring", "expired", "revoked").
+			Default("pending"),
+		field.String("qr_image").Optional(),
+		field.String("website_link").Optional(),
+		field.Time("last_checked_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+func (ReraRegistration) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("project", Project.Type).
+			Ref("rera_registrations").
+			Unique().
+			Required().
+			Field("project_id"),
+	}
+}
+
+func (ReraRegistration) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("state", "registration_number").Unique(),
+		index.Fields("status"),
+		index.Fields("valid_until"),
+	}
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Role is the permission set of a user role. The ID is the role name stored on users.
+type Role struct {
+	ent.Schema
+}
+
+func (Role) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique().Immutable(),
+		field.String("description").Optional(),
+		field.JSON("permissions", []string{}),
+		// two_factor_required makes users of the role enroll in 2FA before they can sign in.
+		field.Bool("two_factor_required").Default(false),
+		field.String("updated_by_user_id").Optional(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
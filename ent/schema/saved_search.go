@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// SavedSearch is a buyer's project search, stored as the ListProjects filter map, that is
+// matched against new and re-priced projects and sent as a digest. Alerts start once the
+// phone or email it was saved against has been verified.
+type SavedSearch struct {
+	ent.Schema
+}
+
+func (SavedSearch) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("name").Optional(),
+		field.Enum("channel").Values("sms", "email"),
+		field.String("phone").Optional(),
+		field.String("email").Optional(),
+		field.JSON("filters", map[string]interface{}{}).Optional(),
+		field.Enum("frequency").Values("daily", "weekly").Default("daily"),
+		field.String("verification_code_hash").Optional().Sensitive(),
+		field.Time("verification_expires_at").Optional().Nillable(),
+		field.Int("verification_attempts").Default(0),
+		field.Time("verified_at").Optional().Nillable(),
+		field.String("unsubscribe_token").Unique().Sensitive(),
+		field.Time("unsubscribed_at").Optional().Nillable(),
+		field.Time("last_checked_at").Optional().Nillable(), // end of the last matched window
+		field.Time("last_notified_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+func (SavedSearch) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("phone"),
+		index.Fields("email"),
+		index.Fields("verified_at", "unsubscribed_at"),
+	}
+}
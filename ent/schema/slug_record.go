@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// SlugRecord reserves a slug for one entity. Slugs are unique across all entity types,
+// and retired slugs stay reserved so their redirects keep pointing at the right page.
+type SlugRecord struct {
+	ent.Schema
+}
+
+func (SlugRecord) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("slug").Unique(),
+		field.Enum("entity_type").Values("project", "property", "blog", "custom_search_page"),
+		field.String("entity_id"),
+		field.Bool("is_current").Default(true),
+		field.Time("retired_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+func (SlugRecord) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity_type", "entity_id"),
+	}
+}
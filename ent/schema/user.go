@@ -27,11 +27,15 @@ func (User) Fields() []ent.Field {
 		field.String("phone_number").Optional(),
 		field.String("current_address").Optional(),
 		field.String("permanent_address").Optional(),
-		field.Enum("role").Values("business_partner", "superadmin", "dm").Default("business_partner"),
+		field.Enum("role").Values("business_partner", "superadmin", "dm", "partner_member").Default("business_partner"), // partner_member: read-only sub-user of a business partner
 		field.Bool("is_active").Default(true),
 		field.Bool("is_email_verified").Default(false),
 		field.Bool("is_verified").Default(false),
 		field.Time("last_login_time").Optional(),
+		// Failed sign-ins since the last successful one, for login throttling
+		field.Int("failed_login_count").Default(0),
+		field.Time("last_failed_login_at").Optional().Nillable(),
+		field.Time("locked_until").Optional().Nillable(),
 		field.Int("parent_id").Optional(),
 		field.Time("deleted_at").Optional().Nillable(),
 		field.Time("created_at").Default(time.Now).Immutable(),
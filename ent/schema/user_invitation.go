@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// UserInvitation is a pending invite for a partner team member to set a password. Only
+// the SHA-256 hash of the invite token is stored.
+type UserInvitation struct {
+	ent.Schema
+}
+
+func (UserInvitation) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("user_id"),
+		field.String("invited_by_user_id"),
+		field.String("token_hash").Unique().Sensitive(),
+		field.Time("expires_at"),
+		field.Time("accepted_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (UserInvitation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+	}
+}
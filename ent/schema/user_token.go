@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// UserToken is a single-use token emailed to a user, to verify their email address or
+// reset their password. Only the SHA-256 hash of the token is stored.
+type UserToken struct {
+	ent.Schema
+}
+
+func (UserToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique(),
+		field.String("user_id"),
+		field.Enum("purpose").Values("email_verification", "password_reset"),
+		field.String("token_hash").Unique().Sensitive(),
+		field.String("email"), // the address the token was sent to
+		field.Time("expires_at"),
+		field.Time("used_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+func (UserToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "purpose"),
+	}
+}
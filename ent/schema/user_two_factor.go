@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// UserTwoFactor is a user's TOTP enrollment. The ID is the user ID. The secret is stored
+// encrypted, and enabled_at stays empty until the user confirms a first code.
+type UserTwoFactor struct {
+	ent.Schema
+}
+
+func (UserTwoFactor) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").Unique().Immutable(),
+		field.String("secret_encrypted").Sensitive(),
+		field.Time("enabled_at").Optional().Nillable(),
+		// SHA-256 hashes of the unused recovery codes
+		field.JSON("recovery_code_hashes", []string{}).Optional(),
+		// last_used_step is the TOTP time step of the last accepted code, so it cannot be replayed
+		field.Int64("last_used_step").Default(0),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
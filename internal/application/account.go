@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// validatePassword applies the password policy before a password is hashed.
+func validatePassword(password string) *imhttp.CustomError {
+	if err := utils.ValidatePasswordStrength(password, config.GetConfig().Account.PasswordMinLength); err != nil {
+		return imhttp.NewCustomErr(http.StatusBadRequest, err.Error(), "weak password")
+	}
+	return nil
+}
+
+// RequestPasswordReset emails a reset link. It succeeds for unknown addresses too, so the
+// endpoint cannot be used to find out who has an account.
+func (c *application) RequestPasswordReset(ctx context.Context, email string) *imhttp.CustomError {
+	u, err := c.repo.GetUserDetailsByEmail(ctx, email)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil
+		}
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to request password reset", err.Error())
+	}
+	if !u.IsActive || u.DeletedAt != nil {
+		return nil
+	}
+
+	cfg := config.GetConfig().Account
+	cerr := c.sendUserToken(ctx, u, domain.UserTokenPasswordReset, cfg.PasswordResetTTL, cfg.PasswordResetPath,
+		"Reset your password",
+		"We received a request to reset your password. Open this link to choose a new one:\n\n%s\n\nThe link is valid for %s. If you did not ask for this, you can ignore this email.")
+	if cerr != nil && cerr.StatusCode == http.StatusTooManyRequests {
+		return nil
+	}
+	return cerr
+}
+
+// ResetPassword sets a new password with a reset token and ends every session of the user.
+func (c *application) ResetPassword(ctx context.Context, req *request.ResetPasswordRequest) *imhttp.CustomError {
+	if cerr := validatePassword(req.Password); cerr != nil {
+		return cerr
+	}
+
+	t, err := c.repo.ConsumeUserToken(ctx, utils.HashToken(req.Token), domain.UserTokenPasswordReset)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to reset password", err.Error())
+	}
+	if t == nil {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Reset link is invalid or has expired", "invalid reset token")
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to hash password", err.Error())
+	}
+	if err := c.repo.SetUserPassword(ctx, t.UserID, hash); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to reset password", err.Error())
+	}
+	c.endUserSessions(ctx, t.UserID, domain.SessionRevokedPasswordReset)
+
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      domain.Actor{UserID: t.UserID},
+		Action:     domain.AuditActionPasswordReset,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   t.UserID,
+	})
+	return nil
+}
+
+// RequestEmailVerification emails a verification link to the signed-in user.
+func (c *application) RequestEmailVerification(ctx context.Context, userID string) *imhttp.CustomError {
+	u, err := c.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	if u.IsEmailVerified {
+		return imhttp.NewCustomErr(http.StatusConflict, "Email is already verified", "email already verified")
+	}
+	return c.sendEmailVerification(ctx, u)
+}
+
+func (c *application) sendEmailVerification(ctx context.Context, u *ent.User) *imhttp.CustomError {
+	cfg := config.GetConfig().Account
+	return c.sendUserToken(ctx, u, domain.UserTokenEmailVerification, cfg.EmailVerificationTTL, cfg.EmailVerificationPath,
+		"Verify your email address",
+		"Open this link to verify your email address:\n\n%s\n\nThe link is valid for %s.")
+}
+
+// VerifyEmail marks the email a verification token was sent to as verified. Tokens sent to
+// an address the user has since changed are rejected.
+func (c *application) VerifyEmail(ctx context.Context, token string) *imhttp.CustomError {
+	t, err := c.repo.ConsumeUserToken(ctx, utils.HashToken(token), domain.UserTokenEmailVerification)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify email", err.Error())
+	}
+	if t == nil {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Verification link is invalid or has expired", "invalid verification token")
+	}
+
+	verified, err := c.repo.MarkUserEmailVerified(ctx, t.UserID, t.Email)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify email", err.Error())
+	}
+	if !verified {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Verification link is for a previous email address", "email changed")
+	}
+	return nil
+}
+
+// sendUserToken stores a new single-use token and emails a link carrying it. bodyFormat
+// receives the link and how long it is valid.
+func (c *application) sendUserToken(ctx context.Context, u *ent.User, purpose string, ttl time.Duration, path, subject, bodyFormat string) *imhttp.CustomError {
+	cfg := config.GetConfig()
+
+	last, err := c.repo.LastUserTokenAt(ctx, u.ID, purpose)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to send email", err.Error())
+	}
+	if last != nil && time.Since(*last) < cfg.Account.TokenResendInterval {
+		return imhttp.NewCustomErr(http.StatusTooManyRequests, "Please wait before requesting another email", "resend interval not elapsed")
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate token", err.Error())
+	}
+	if err := c.repo.CreateUserToken(ctx, u.ID, purpose, utils.HashToken(token), u.Email, time.Now().Add(ttl)); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to store token", err.Error())
+	}
+
+	link := fmt.Sprintf("%s%s?token=%s", cfg.Server.BaseURL, path, url.QueryEscape(token))
+	if err := c.mailer.Send(u.Email, subject, fmt.Sprintf(bodyFormat, link, ttl)); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", u.ID).Str("purpose", purpose).Msg("Failed to send account email")
+		return imhttp.NewCustomErr(http.StatusBadGateway, "Failed to send email", err.Error())
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package application
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
@@ -34,7 +37,7 @@ func (c *application) GetAllCategoriesWithAmenities() (*response.AmenityResponse
 	}, nil
 }
 
-func (c *application) AddCategoryWithAmenities(req *request.CreateAmenityRequest) *imhttp.CustomError {
+func (c *application) AddCategoryWithAmenities(ctx context.Context, req *request.CreateAmenityRequest, actor domain.Actor) *imhttp.CustomError {
 
 	// Check if the amenity already exists
 	var categoryName string
@@ -82,11 +85,14 @@ func (c *application) AddCategoryWithAmenities(req *request.CreateAmenityRequest
 		logger.Get().Error().Err(err).Msg("Failed to update static site data")
 		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create amenity", err.Error())
 	}
+	for category := range req.Category {
+		c.auditAmenityCategory(ctx, domain.AuditActionCreate, category, nil, actor)
+	}
 
 	return nil
 }
 
-func (c *application) UpdateStaticSiteData(req *request.UpdateStaticSiteDataRequest) (*response.StaticSiteDataResponse, *imhttp.CustomError) {
+func (c *application) UpdateStaticSiteData(ctx context.Context, req *request.UpdateStaticSiteDataRequest, actor domain.Actor) (*response.StaticSiteDataResponse, *imhttp.CustomError) {
 	// Get current static site data
 	staticData, err := c.repo.GetStaticSiteData()
 	if err != nil {
@@ -98,6 +104,7 @@ func (c *application) UpdateStaticSiteData(req *request.UpdateStaticSiteDataRequ
 	if !staticData.IsActive {
 		return nil, imhttp.NewCustomErr(http.StatusForbidden, "Cannot update inactive static site data", "Static site data must be active to update")
 	}
+	before := response.GetStaticSiteDataFromEnt(staticData)
 
 	// Update fields if provided
 	if req.PropertyTypes != nil {
@@ -122,12 +129,21 @@ func (c *application) UpdateStaticSiteData(req *request.UpdateStaticSiteDataRequ
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get updated static site data", err.Error())
 	}
 
-	return response.GetStaticSiteDataFromEnt(updatedData), nil
+	result := response.GetStaticSiteDataFromEnt(updatedData)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: domain.AuditEntityStaticSiteData,
+		EntityID:   updatedData.ID,
+		Before:     before,
+		After:      result,
+	})
+	return result, nil
 }
 
 // patch update static site data which is active
 
-func (c *application) AddCategory(categoryName string) *imhttp.CustomError {
+func (c *application) AddCategory(ctx context.Context, categoryName string, actor domain.Actor) *imhttp.CustomError {
 
 	// Check if the category already exists
 	exist, err := c.repo.CheckCategoryExists(categoryName)
@@ -143,11 +159,12 @@ func (c *application) AddCategory(categoryName string) *imhttp.CustomError {
 		logger.Get().Error().Err(err).Msg("Failed to add category")
 		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add category", err.Error())
 	}
+	c.auditAmenityCategory(ctx, domain.AuditActionCreate, categoryName, nil, actor)
 
 	return nil
 }
 
-func (c *application) AddAmenityToCategory(req *request.AddAmenityToCategoryRequest) *imhttp.CustomError {
+func (c *application) AddAmenityToCategory(ctx context.Context, req *request.AddAmenityToCategoryRequest, actor domain.Actor) *imhttp.CustomError {
 
 	// Check if the category already exists
 	exist, err := c.repo.CheckCategoryExists(req.CategoryName)
@@ -159,16 +176,18 @@ func (c *application) AddAmenityToCategory(req *request.AddAmenityToCategoryRequ
 	if !exist {
 		return imhttp.NewCustomErr(http.StatusNotFound, "Category not found", "Category not found")
 	}
+	before := c.amenityCategorySnapshot(req.CategoryName)
 
 	if err := c.repo.AddAmenityToCategory(req); err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to add amenity to category")
 		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add amenity to category", err.Error())
 	}
+	c.auditAmenityCategory(ctx, domain.AuditActionUpdate, req.CategoryName, before, actor)
 
 	return nil
 }
 
-func (c *application) DeleteAmenityFromCategory(req *request.DeleteAmenityFromCategoryRequest) *imhttp.CustomError {
+func (c *application) DeleteAmenityFromCategory(ctx context.Context, req *request.DeleteAmenityFromCategoryRequest, actor domain.Actor) *imhttp.CustomError {
 
 	// Check if the category already exists
 	exist, err := c.repo.CheckCategoryExists(req.CategoryName)
@@ -200,16 +219,18 @@ func (c *application) DeleteAmenityFromCategory(req *request.DeleteAmenityFromCa
 	if !amenityExists {
 		return imhttp.NewCustomErr(http.StatusNotFound, "Amenity not found", "Amenity not found")
 	}
+	before := amenityCategory(staticData, req.CategoryName)
 
 	if err := c.repo.DeleteAmenityFromCategory(req); err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to delete amenity from category")
 		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete amenity from category", err.Error())
 	}
+	c.auditAmenityCategory(ctx, domain.AuditActionUpdate, req.CategoryName, before, actor)
 
 	return nil
 }
 
-func (c *application) DeleteCategoryWithAmenities(categoryName string) *imhttp.CustomError {
+func (c *application) DeleteCategoryWithAmenities(ctx context.Context, categoryName string, actor domain.Actor) *imhttp.CustomError {
 
 	// Check if the category already exists
 	exist, err := c.repo.CheckCategoryExists(categoryName)
@@ -221,11 +242,55 @@ func (c *application) DeleteCategoryWithAmenities(categoryName string) *imhttp.C
 	if !exist {
 		return imhttp.NewCustomErr(http.StatusNotFound, "Category not found", "Category not found")
 	}
+	before := c.amenityCategorySnapshot(categoryName)
 
 	if err := c.repo.DeleteCategoryWithAmenities(categoryName); err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to delete category with amenities")
 		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete category with amenities", err.Error())
 	}
+	c.auditAmenityCategory(ctx, domain.AuditActionDelete, categoryName, before, actor)
 
 	return nil
 }
+
+// auditedAmenityCategory is one amenity category as recorded in the audit log.
+type auditedAmenityCategory struct {
+	Category  string             `json:"category"`
+	Amenities []response.Amenity `json:"amenities"`
+}
+
+// auditAmenityCategory records a change to an amenity category. The category after the
+// change is read back, and is nil once the category is gone.
+func (c *application) auditAmenityCategory(ctx context.Context, action, categoryName string, before interface{}, actor domain.Actor) {
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		EntityType: domain.AuditEntityAmenityCategory,
+		EntityID:   categoryName,
+		Before:     before,
+		After:      c.amenityCategorySnapshot(categoryName),
+	})
+}
+
+// amenityCategorySnapshot reads an amenity category for the audit log, or nil when it
+// does not exist or cannot be read.
+func (c *application) amenityCategorySnapshot(categoryName string) interface{} {
+	staticData, err := c.repo.GetStaticSiteData()
+	if err != nil {
+		logger.Get().Error().Err(err).Str("category", categoryName).Msg("Failed to snapshot amenity category for audit")
+		return nil
+	}
+	return amenityCategory(staticData, categoryName)
+}
+
+func amenityCategory(staticData *ent.StaticSiteData, categoryName string) interface{} {
+	amenities, ok := staticData.CategoriesWithAmenities.Categories[categoryName]
+	if !ok {
+		return nil
+	}
+	snapshot := &auditedAmenityCategory{Category: categoryName, Amenities: make([]response.Amenity, 0, len(amenities))}
+	for _, amenity := range amenities {
+		snapshot.Amenities = append(snapshot.Amenities, response.Amenity{Icon: amenity.Icon, Value: amenity.Value})
+	}
+	return snapshot
+}
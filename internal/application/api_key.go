@@ -0,0 +1,193 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// CreateAPIKey generates a key with its own permissions. The key is returned once; only
+// its hash and prefix are stored.
+func (c *application) CreateAPIKey(ctx context.Context, req *request.CreateAPIKeyRequest, actor domain.Actor) (*response.CreatedAPIKey, *imhttp.CustomError) {
+	permissions, cerr := normalizePermissions(req.Permissions, actor.Permissions)
+	if cerr != nil {
+		return nil, cerr
+	}
+	allowedIPs, cerr := normalizeAllowedIPs(req.AllowedIPs)
+	if cerr != nil {
+		return nil, cerr
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Expiry must be in the future", "expires_at in the past")
+	}
+
+	prefix, err := utils.GenerateSecureToken(6)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate API key", err.Error())
+	}
+	secret, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate API key", err.Error())
+	}
+	prefix = domain.APIKeyPrefix + prefix
+	key := prefix + "." + secret
+
+	k, err := c.repo.CreateAPIKey(ctx, domain.NewAPIKey{
+		Name:            req.Name,
+		Prefix:          prefix,
+		KeyHash:         utils.HashToken(key),
+		Permissions:     permissions,
+		AllowedIPs:      allowedIPs,
+		ExpiresAt:       req.ExpiresAt,
+		CreatedByUserID: actor.UserID,
+	})
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create API key", err.Error())
+	}
+
+	created := response.GetAPIKeyFromEnt(k)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityAPIKey,
+		EntityID:   k.ID,
+		After:      created,
+	})
+	return &response.CreatedAPIKey{APIKey: created, Key: key}, nil
+}
+
+func (c *application) ListAPIKeys(ctx context.Context) ([]*response.APIKey, *imhttp.CustomError) {
+	keys, err := c.repo.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list API keys", err.Error())
+	}
+	items := make([]*response.APIKey, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, response.GetAPIKeyFromEnt(k))
+	}
+	return items, nil
+}
+
+// RevokeAPIKey stops a key from authenticating. Revoked keys stay listed.
+func (c *application) RevokeAPIKey(ctx context.Context, id string, actor domain.Actor) (*response.APIKey, *imhttp.CustomError) {
+	k, err := c.repo.RevokeAPIKey(ctx, id, actor.UserID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "API key not found", err.Error())
+	}
+
+	revoked := response.GetAPIKeyFromEnt(k)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionRevoke,
+		EntityType: domain.AuditEntityAPIKey,
+		EntityID:   k.ID,
+		After:      revoked,
+	})
+	return revoked, nil
+}
+
+// AuthenticateAPIKey resolves a key presented by a client into claims and the key's
+// permissions. The claims carry no user, so ownership-scoped data needs the matching
+// ".all" permission.
+func (c *application) AuthenticateAPIKey(ctx context.Context, key, ipAddress string) (*auth.Claims, map[string]bool, *imhttp.CustomError) {
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid API key", "malformed api key")
+	}
+	k, err := c.repo.GetAPIKeyByHash(ctx, utils.HashToken(key))
+	if err != nil {
+		return nil, nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify API key", err.Error())
+	}
+	if k == nil || k.RevokedAt != nil {
+		return nil, nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid API key", "api key not found or revoked")
+	}
+	if k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt) {
+		return nil, nil, imhttp.NewCustomErr(http.StatusUnauthorized, "API key has expired", "api key expired")
+	}
+	if !ipAllowed(k, ipAddress) {
+		logger.Get().Warn().Str("api_key_id", k.ID).Str("ip_address", ipAddress).Msg("API key used from an address outside its allowlist")
+		return nil, nil, imhttp.NewCustomErr(http.StatusForbidden, "API key is not allowed from this address", "ip address not allowed")
+	}
+
+	if err := c.repo.TouchAPIKey(ctx, k.ID, ipAddress); err != nil {
+		logger.Get().Error().Err(err).Str("api_key_id", k.ID).Msg("Failed to record API key use")
+	}
+
+	permissions := make(map[string]bool, len(k.Permissions))
+	for _, p := range k.Permissions {
+		permissions[p] = true
+	}
+	return &auth.Claims{Role: domain.RoleAPIKey, APIKeyID: k.ID}, permissions, nil
+}
+
+// ipAllowed reports whether an address is in a key's allowlist. An empty list allows any.
+func ipAllowed(k *ent.APIKey, ipAddress string) bool {
+	if len(k.AllowedIps) == 0 {
+		return true
+	}
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return false
+	}
+	for _, allowed := range k.AllowedIps {
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if allowedIP := net.ParseIP(allowed); allowedIP != nil && allowedIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePermissions checks a list of permissions and returns it sorted without duplicates.
+// Only permissions the actor holds can be granted, so nobody can hand out more than they have.
+func normalizePermissions(requested []string, granted map[string]bool) ([]string, *imhttp.CustomError) {
+	seen := make(map[string]bool, len(requested))
+	permissions := make([]string, 0, len(requested))
+	for _, p := range requested {
+		if !domain.IsValidPermission(p) {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, fmt.Sprintf("Unknown permission %q", p), "unknown permission")
+		}
+		if !granted[p] {
+			return nil, imhttp.NewCustomErr(http.StatusForbidden, fmt.Sprintf("You cannot grant the %q permission", p), "permission not held by actor")
+		}
+		if !seen[p] {
+			seen[p] = true
+			permissions = append(permissions, p)
+		}
+	}
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// normalizeAllowedIPs checks an allowlist of addresses and CIDR ranges.
+func normalizeAllowedIPs(requested []string) ([]string, *imhttp.CustomError) {
+	allowed := make([]string, 0, len(requested))
+	for _, entry := range requested {
+		entry = strings.TrimSpace(entry)
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			allowed = append(allowed, network.String())
+			continue
+		}
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, fmt.Sprintf("Invalid IP address or range %q", entry), "invalid allowed ip")
+		}
+		allowed = append(allowed, ip.String())
+	}
+	return allowed, nil
+}
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+const (
+	// maxAuditExportRows bounds a CSV export; a larger one needs a narrower filter.
+	maxAuditExportRows = 50000
+	auditExportBatch   = 1000
+)
+
+// audit records a change. Failures are logged and do not fail the caller.
+func (c *application) audit(ctx context.Context, entry domain.AuditEntry) {
+	if err := c.repo.CreateAuditLog(ctx, entry); err != nil {
+		logger.Get().Error().Err(err).
+			Str("actor_user_id", entry.Actor.UserID).
+			Str("action", entry.Action).
+			Str("entity_type", entry.EntityType).
+			Str("entity_id", entry.EntityID).
+			Msg("Failed to audit change")
+	}
+}
+
+// ListAuditLogs returns a page of the audit log, newest first.
+func (c *application) ListAuditLogs(ctx context.Context, req *request.ListAuditLogsRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	filter, customErr := auditLogFilter(req)
+	if customErr != nil {
+		return nil, customErr
+	}
+	req.Validate()
+
+	logs, total, err := c.repo.ListAuditLogs(ctx, filter, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list audit logs", err.Error())
+	}
+	items := make([]*response.AuditLog, 0, len(logs))
+	for _, l := range logs {
+		items = append(items, response.GetAuditLogFromEnt(l))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+// ExportAuditLogs writes every audit log entry matching the filter as CSV, newest first.
+// Snapshots are written as JSON.
+func (c *application) ExportAuditLogs(ctx context.Context, req *request.ListAuditLogsRequest) (*response.AuditLogExport, *imhttp.CustomError) {
+	filter, customErr := auditLogFilter(req)
+	if customErr != nil {
+		return nil, customErr
+	}
+	if filter.To == nil {
+		// Entries written while exporting would shift the pages
+		now := time.Now()
+		filter.To = &now
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"created_at", "id", "actor_user_id", "actor_role", "api_key_id", "ip_address", "action", "entity_type", "entity_id", "before", "after"})
+	for offset := 0; ; offset += auditExportBatch {
+		logs, total, err := c.repo.ListAuditLogs(ctx, filter, offset, auditExportBatch)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to export audit logs", err.Error())
+		}
+		if total > maxAuditExportRows {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, fmt.Sprintf("Export is limited to %d entries, narrow the filter", maxAuditExportRows),
+				fmt.Sprintf("%d entries match", total))
+		}
+		for _, l := range logs {
+			row, err := auditLogRow(l)
+			if err != nil {
+				return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to export audit logs", err.Error())
+			}
+			w.Write(row)
+		}
+		if len(logs) < auditExportBatch {
+			break
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to export audit logs", err.Error())
+	}
+
+	return &response.AuditLogExport{
+		FileName:    fmt.Sprintf("audit-log-%s.csv", time.Now().Format("20060102-150405")),
+		ContentType: "text/csv",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func auditLogRow(l *ent.AuditLog) ([]string, error) {
+	before, err := auditSnapshotJSON(l.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := auditSnapshotJSON(l.After)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		l.CreatedAt.UTC().Format(time.RFC3339),
+		l.ID,
+		l.ActorUserID,
+		l.ActorRole,
+		l.APIKeyID,
+		l.IPAddress,
+		l.Action,
+		l.EntityType,
+		l.EntityID,
+		before,
+		after,
+	}, nil
+}
+
+func auditSnapshotJSON(snapshot map[string]interface{}) (string, error) {
+	if snapshot == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// auditLogFilter validates the filters of an audit log request.
+func auditLogFilter(req *request.ListAuditLogsRequest) (domain.AuditLogFilter, *imhttp.CustomError) {
+	filter := domain.AuditLogFilter{
+		ActorUserID: req.ActorUserID,
+		EntityType:  req.EntityType,
+		EntityID:    req.EntityID,
+		Action:      req.Action,
+	}
+	if req.From != "" {
+		from, err := parseAuditTime(req.From, false)
+		if err != nil {
+			return filter, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid from, expected YYYY-MM-DD or an RFC 3339 time", err.Error())
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := parseAuditTime(req.To, true)
+		if err != nil {
+			return filter, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid to, expected YYYY-MM-DD or an RFC 3339 time", err.Error())
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, imhttp.NewCustomErr(http.StatusBadRequest, "from must not be after to", "from must not be after to")
+	}
+	return filter, nil
+}
+
+// parseAuditTime parses a date or an RFC 3339 time. A date is the start of that day, or
+// its last instant when endOfDay is set.
+func parseAuditTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		return date.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return date, nil
+}
+
+// projectSnapshot is a project as recorded in the audit log, or nil when it cannot be read.
+func (c *application) projectSnapshot(id string) interface{} {
+	project, err := c.repo.GetProjectByID(id)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("project_id", id).Msg("Failed to snapshot project for audit")
+		return nil
+	}
+	return response.GetProjectFromEnt(project)
+}
+
+// propertySnapshot is a property as recorded in the audit log, or nil when it cannot be read.
+func (c *application) propertySnapshot(id string) interface{} {
+	property, err := c.repo.GetPropertyByID(id)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("property_id", id).Msg("Failed to snapshot property for audit")
+		return nil
+	}
+	return response.GetPropertyFromEnt(property)
+}
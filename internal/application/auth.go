@@ -3,28 +3,36 @@ package application
 import (
 	"context"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/internal/utils"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
 )
 
-func (c *application) GetAccessToken(email string, password string) (*response.GenerateTokenResponse, *imhttp.CustomError) {
-	ctx := context.Background()
+// GetAccessToken signs a user in with a password. Failed attempts are throttled per
+// address and per account, and enough of them lock the account for a while.
+func (c *application) GetAccessToken(ctx context.Context, email string, password string, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError) {
 	if email == "" || password == "" {
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "No credentials provided", "No credentials provided")
 	}
 
+	if cerr := c.checkLoginAddress(ctx, email, meta); cerr != nil {
+		return nil, cerr
+	}
+
 	exist, err := c.repo.CheckIfUserExistsByEmail(ctx, email)
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check email", err.Error())
 	}
 	if !exist {
+		c.recordLogin(ctx, domain.LoginAttempt{Email: email, Meta: meta, FailureReason: domain.LoginFailureUnknownUser})
 		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", "User not found")
 	}
 
@@ -37,71 +45,93 @@ func (c *application) GetAccessToken(email string, password string) (*response.G
 		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", "User not found")
 	}
 
+	if cerr := c.checkAccountLock(ctx, user, meta); cerr != nil {
+		return nil, cerr
+	}
+
 	if !utils.ComparePassword(user.Password, password) {
+		c.loginFailed(ctx, user, meta, domain.LoginFailureInvalidPassword)
 		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid password", "Invalid password")
 	}
 
-	// Generate access token with role
-	accessToken, err := auth.GenerateToken(user.ID, false, user.Role.String(), user.PhoneNumber)
-	if err != nil {
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate access token", err.Error())
+	if !user.IsActive {
+		c.recordLogin(ctx, domain.LoginAttempt{UserID: user.ID, Email: user.Email, Meta: meta, FailureReason: domain.LoginFailureInactive})
+		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "User is not active", "User is not active")
 	}
 
-	// Generate refresh token
-	refreshToken, err := utils.GenerateRefreshToken(user.ID, time.Now().Add(time.Hour*24*7))
-	if err != nil {
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate refresh token", err.Error())
+	// Users with 2FA, or whose role requires it, finish signing in with a challenge
+	challenge, cerr := c.twoFactorChallenge(ctx, user)
+	if cerr != nil || challenge != nil {
+		return challenge, cerr
 	}
 
-	return &response.GenerateTokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		Role:         user.Role.String(),
-		Name:         user.Name,
-	}, nil
+	c.loginSucceeded(ctx, user, meta)
+	return c.startSession(ctx, user, meta)
 }
 
-func (c *application) RefreshToken(refreshToken string) (*response.GenerateTokenResponse, *imhttp.CustomError) {
-	ctx := context.Background()
+// RefreshToken exchanges a refresh token for a new access token and the next refresh
+// token of its session. Presenting a token that was already exchanged revokes the session,
+// since either the client or an attacker holds a stolen copy.
+func (c *application) RefreshToken(ctx context.Context, refreshToken string, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError) {
 	if refreshToken == "" {
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Refresh token is empty", "Refresh token is empty")
 	}
 
-	userID, err := utils.VerifyRefreshToken(refreshToken)
+	current, err := c.repo.GetRefreshTokenByHash(ctx, utils.HashToken(refreshToken))
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify refresh token", err.Error())
 	}
-
-	user, err := c.repo.GetUserDetailsByEmail(ctx, strconv.Itoa(userID))
-	if err != nil {
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get user details", err.Error())
+	if current == nil {
+		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid refresh token", "refresh token not found")
 	}
-
-	if user == nil {
-		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", "User not found")
+	if current.RevokedAt != nil {
+		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Session has ended", "refresh token revoked")
+	}
+	if current.UsedAt != nil {
+		return nil, c.revokeReusedSession(ctx, current)
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Session has expired", "refresh token expired")
 	}
 
-	// Note: DeletedAt field will be available after regenerating entities
-	// if user.DeletedAt != nil {
-	// 	return nil, imhttp.NewCustomErr(http.StatusNotFound, "User is deleted", "User is deleted")
-	// }
-
-	if !user.IsActive {
+	user, err := c.repo.GetUserByID(ctx, current.UserID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "User not found", err.Error())
+	}
+	if !user.IsActive || user.DeletedAt != nil {
+		if err := c.repo.RevokeRefreshTokenFamily(ctx, current.FamilyID, domain.SessionRevokedUserInactive); err != nil {
+			logger.Get().Error().Err(err).Str("family_id", current.FamilyID).Msg("Failed to end session of inactive user")
+		}
 		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "User is not active", "User is not active")
 	}
 
-	// Generate access token with role
-	accessToken, err := auth.GenerateToken(strconv.Itoa(userID), false, user.Role.String(), user.PhoneNumber)
+	accessToken, err := auth.GenerateToken(user.ID, false, user.Role.String(), user.PhoneNumber)
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate access token", err.Error())
 	}
 
+	nextToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate refresh token", err.Error())
+	}
+	next, err := c.repo.RotateRefreshToken(ctx, current, utils.HashToken(nextToken), time.Now().Add(config.GetConfig().RefreshTokenTTL), meta)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to rotate refresh token", err.Error())
+	}
+	if next == nil {
+		// Another request exchanged the same token first
+		return nil, c.revokeReusedSession(ctx, current)
+	}
+
 	return &response.GenerateTokenResponse{
-		AccessToken: accessToken,
+		AccessToken:  accessToken,
+		RefreshToken: nextToken,
+		Role:         user.Role.String(),
+		Name:         user.Name,
 	}, nil
 }
 
-func (c *application) Signup(ctx context.Context, req *request.SignupRequest) (*response.GenerateTokenResponse, *imhttp.CustomError) {
+func (c *application) Signup(ctx context.Context, req *request.SignupRequest, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError) {
 	// Check if username already exists
 	exist, err := c.repo.CheckIfUserExistsByEmail(ctx, req.Email)
 	if err != nil {
@@ -111,6 +141,10 @@ func (c *application) Signup(ctx context.Context, req *request.SignupRequest) (*
 		return nil, imhttp.NewCustomErr(http.StatusConflict, "Email already exists", "Email already exists")
 	}
 
+	if cerr := validatePassword(req.Password); cerr != nil {
+		return nil, cerr
+	}
+
 	// Hash password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
@@ -133,19 +167,10 @@ func (c *application) Signup(ctx context.Context, req *request.SignupRequest) (*
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create user", err.Error())
 	}
 
-	// Generate tokens with role
-	accessToken, err := auth.GenerateToken(createdUser.ID, false, createdUser.Role.String(), createdUser.PhoneNumber)
-	if err != nil {
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate access token", err.Error())
-	}
-
-	refreshToken, err := utils.GenerateRefreshToken(createdUser.ID, time.Now().Add(time.Hour*24*7))
-	if err != nil {
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate refresh token", err.Error())
+	// The account works before the address is verified, so a failed email does not fail signup
+	if cerr := c.sendEmailVerification(ctx, createdUser); cerr != nil {
+		logger.Get().Warn().Str("user_id", createdUser.ID).Str("error", cerr.ErrorMessage).Msg("Failed to send verification email after signup")
 	}
 
-	return &response.GenerateTokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
+	return c.startSession(ctx, createdUser, meta)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
@@ -84,18 +85,29 @@ func (c *application) GetBlogBySlug(slug string) (*response.BlogResponse, *imhtt
 	return response.GetBlogFromEnt(blog), nil
 }
 
-func (c *application) CreateBlog(ctx context.Context, req *request.CreateBlogRequest) (*response.BlogResponse, *imhttp.CustomError) {
+func (c *application) CreateBlog(ctx context.Context, req *request.CreateBlogRequest, actor domain.Actor) (*response.BlogResponse, *imhttp.CustomError) {
+	if customErr := c.ensureSlugAvailable(ctx, req.Slug); customErr != nil {
+		return nil, customErr
+	}
 
 	blog, err := c.repo.CreateBlog(ctx, req.Slug, req.BlogContent, req.SEOMetaInfo, req.IsPriority, req.IsPublished)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to create blog")
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create blog", err.Error())
-	}
-
-	return response.GetBlogFromEnt(blog), nil
+		return nil, slugError(err, "Failed to create blog")
+	}
+
+	result := response.GetBlogFromEnt(blog)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityBlog,
+		EntityID:   blog.ID,
+		After:      result,
+	})
+	return result, nil
 }
 
-func (c *application) DeleteBlog(ctx context.Context, id string) *imhttp.CustomError {
+func (c *application) DeleteBlog(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError {
 	// Check if blog exists
 	blog, err := c.repo.GetBlogByID(id)
 	if err != nil {
@@ -112,21 +124,49 @@ func (c *application) DeleteBlog(ctx context.Context, id string) *imhttp.CustomE
 		logger.Get().Error().Err(err).Msg("Failed to delete blog")
 		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete blog", err.Error())
 	}
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionDelete,
+		EntityType: domain.AuditEntityBlog,
+		EntityID:   id,
+		Before:     response.GetBlogFromEnt(blog),
+		After:      c.blogSnapshot(id),
+	})
 
 	return nil
 }
 
-func (c *application) UpdateBlog(ctx context.Context, id string, req *request.UpdateBlogRequest) (*response.BlogResponse, *imhttp.CustomError) {
+func (c *application) UpdateBlog(ctx context.Context, id string, req *request.UpdateBlogRequest, actor domain.Actor) (*response.BlogResponse, *imhttp.CustomError) {
+	before := c.blogSnapshot(id)
+
 	// Update blog in repository
 	blog, err := c.repo.UpdateBlog(ctx, id, req.BlogURL, req.BlogContent, req.SEOMetaInfo, req.IsPriority)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to update blog")
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update blog", err.Error())
+		return nil, slugError(err, "Failed to update blog")
 	}
 
 	if blog == nil {
 		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Blog not found", "Blog not found")
 	}
 
-	return response.GetBlogFromEnt(blog), nil
+	result := response.GetBlogFromEnt(blog)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: domain.AuditEntityBlog,
+		EntityID:   blog.ID,
+		Before:     before,
+		After:      result,
+	})
+	return result, nil
+}
+
+// blogSnapshot is a blog as recorded in the audit log, or nil when it cannot be read.
+func (c *application) blogSnapshot(id string) interface{} {
+	blog, err := c.repo.GetBlogByID(id)
+	if err != nil || blog == nil {
+		return nil
+	}
+	return response.GetBlogFromEnt(blog)
 }
@@ -0,0 +1,301 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// RequestBuyerOTP sends a login code to a phone, creating the buyer account on first use.
+// The code is sent through the same SMS flow as lead verification.
+func (c *application) RequestBuyerOTP(ctx context.Context, req *request.BuyerOTPRequest) *imhttp.CustomError {
+	cfg := config.GetConfig().Buyer
+
+	buyer, err := c.repo.GetBuyerByPhone(ctx, req.Phone)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get buyer", err.Error())
+	}
+	if buyer == nil {
+		buyer, err = c.repo.CreateBuyer(ctx, req.Phone)
+		if err != nil {
+			return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create buyer", err.Error())
+		}
+	}
+	if !buyer.IsActive {
+		return imhttp.NewCustomErr(http.StatusForbidden, "Account is disabled", "Account is disabled")
+	}
+	if buyer.OtpSentAt != nil && time.Since(*buyer.OtpSentAt) < cfg.OTPResendInterval {
+		return imhttp.NewCustomErr(http.StatusTooManyRequests, "Please wait before requesting another code", "Please wait before requesting another code")
+	}
+
+	code, err := utils.GenerateSecureOTP(6)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate code", err.Error())
+	}
+	if err := c.repo.SetBuyerOTP(ctx, buyer.ID, utils.HashToken(code), time.Now().Add(cfg.OTPTTL)); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to save code", err.Error())
+	}
+	if err := c.smsClient.SendOTP(req.Phone, code); err != nil {
+		logger.Get().Error().Err(err).Str("buyer_id", buyer.ID).Msg("Failed to send buyer login OTP")
+		return imhttp.NewCustomErr(http.StatusBadGateway, "Failed to send code", err.Error())
+	}
+	return nil
+}
+
+// VerifyBuyerOTP exchanges a login code for a buyer token. A code is locked after
+// OTPMaxAttempts wrong guesses and must be requested again.
+func (c *application) VerifyBuyerOTP(ctx context.Context, req *request.VerifyBuyerOTPRequest) (*response.BuyerLoginResponse, *imhttp.CustomError) {
+	cfg := config.GetConfig().Buyer
+
+	buyer, err := c.repo.GetBuyerByPhone(ctx, req.Phone)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get buyer", err.Error())
+	}
+	if buyer == nil || !buyer.IsActive {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid or expired code", "Invalid or expired code")
+	}
+
+	// The code is compared and consumed in the same update, so it cannot be used twice
+	signedIn, err := c.repo.CompleteBuyerLogin(ctx, buyer.ID, utils.HashToken(req.Code), cfg.OTPMaxAttempts)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to sign in", err.Error())
+	}
+	if signedIn == nil {
+		if buyer.OtpHash != "" {
+			if err := c.repo.IncrementBuyerOTPAttempts(ctx, buyer.ID, cfg.OTPMaxAttempts); err != nil {
+				logger.Get().Error().Err(err).Str("buyer_id", buyer.ID).Msg("Failed to record buyer OTP attempt")
+			}
+		}
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid or expired code", "Invalid or expired code")
+	}
+	buyer = signedIn
+
+	token, expiresAt, err := auth.GenerateBuyerToken(buyer.ID, buyer.Phone)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate token", err.Error())
+	}
+
+	return &response.BuyerLoginResponse{
+		AccessToken: token,
+		ExpiresAt:   expiresAt,
+		Buyer:       response.GetBuyerFromEnt(buyer),
+	}, nil
+}
+
+func (c *application) GetBuyerProfile(ctx context.Context, buyerID string) (*response.Buyer, *imhttp.CustomError) {
+	buyer, err := c.repo.GetBuyerByID(ctx, buyerID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Buyer not found", err.Error())
+	}
+	return response.GetBuyerFromEnt(buyer), nil
+}
+
+func (c *application) UpdateBuyerProfile(ctx context.Context, buyerID string, req *request.UpdateBuyerProfileRequest) (*response.Buyer, *imhttp.CustomError) {
+	name, email := req.Name, req.Email
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		name = &trimmed
+	}
+	if email != nil {
+		normalized := strings.ToLower(strings.TrimSpace(*email))
+		email = &normalized
+	}
+
+	buyer, err := c.repo.UpdateBuyerProfile(ctx, buyerID, name, email)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update profile", err.Error())
+	}
+	return response.GetBuyerFromEnt(buyer), nil
+}
+
+func (c *application) GetBuyerShortlist(ctx context.Context, buyerID string) ([]*response.ShortlistItem, *imhttp.CustomError) {
+	items, err := c.repo.GetBuyerShortlist(ctx, buyerID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get shortlist", err.Error())
+	}
+
+	result := make([]*response.ShortlistItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, response.GetShortlistItem(item))
+	}
+	return result, nil
+}
+
+func (c *application) AddToShortlist(ctx context.Context, buyerID string, req *request.ShortlistRequest) *imhttp.CustomError {
+	if !domain.IsValidShortlistEntity(req.EntityType) {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Invalid entity type", "entity_type must be project or property")
+	}
+
+	if req.EntityType == domain.ShortlistProject {
+		project, err := c.repo.GetProjectByID(req.EntityID)
+		if err != nil || project.IsDeleted {
+			return imhttp.NewCustomErr(http.StatusNotFound, "Project not found", "Project not found")
+		}
+	} else {
+		property, err := c.repo.GetPropertyByID(req.EntityID)
+		if err != nil || property.IsDeleted {
+			return imhttp.NewCustomErr(http.StatusNotFound, "Property not found", "Property not found")
+		}
+	}
+
+	if err := c.repo.AddBuyerShortlist(ctx, buyerID, req.EntityType, req.EntityID); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update shortlist", err.Error())
+	}
+	return nil
+}
+
+func (c *application) RemoveFromShortlist(ctx context.Context, buyerID, entityType, entityID string) *imhttp.CustomError {
+	if !domain.IsValidShortlistEntity(entityType) {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Invalid entity type", "entity_type must be project or property")
+	}
+
+	removed, err := c.repo.RemoveBuyerShortlist(ctx, buyerID, entityType, entityID)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update shortlist", err.Error())
+	}
+	if !removed {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Not in shortlist", "Not in shortlist")
+	}
+	return nil
+}
+
+// ListBuyerEnquiries returns the leads submitted with the buyer's verified phone.
+func (c *application) ListBuyerEnquiries(ctx context.Context, buyerID string, req *request.GetAllAPIRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	req.Validate()
+	buyer, err := c.repo.GetBuyerByID(ctx, buyerID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Buyer not found", err.Error())
+	}
+
+	leadsData, total, err := c.repo.GetBuyerEnquiries(ctx, buyer.Phone, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get enquiries", err.Error())
+	}
+
+	items := make([]*response.BuyerEnquiry, 0, len(leadsData))
+	for _, lead := range leadsData {
+		items = append(items, response.GetBuyerEnquiryFromEnt(lead))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+// validateComparisonProjects de-duplicates the project IDs of a comparison and checks
+// that each project exists.
+func (c *application) validateComparisonProjects(projectIDs []string) ([]string, *imhttp.CustomError) {
+	maxSize := config.GetConfig().Buyer.MaxComparisonSize
+
+	unique := make([]string, 0, len(projectIDs))
+	seen := make(map[string]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) < 2 {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "At least 2 projects are required for comparison", "At least 2 projects are required for comparison")
+	}
+	if len(unique) > maxSize {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Too many projects", "A comparison can hold at most "+strconv.Itoa(maxSize)+" projects")
+	}
+
+	for _, id := range unique {
+		project, err := c.repo.GetProjectByID(id)
+		if err != nil || project.IsDeleted {
+			return nil, imhttp.NewCustomErr(http.StatusNotFound, "Project not found", "Project not found: "+id)
+		}
+	}
+	return unique, nil
+}
+
+func (c *application) CreateBuyerComparison(ctx context.Context, buyerID string, req *request.BuyerComparisonRequest) (*response.BuyerComparison, *imhttp.CustomError) {
+	projectIDs, cerr := c.validateComparisonProjects(req.ProjectIDs)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	comparison, err := c.repo.CreateBuyerComparison(ctx, buyerID, strings.TrimSpace(req.Name), projectIDs)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to save comparison", err.Error())
+	}
+	return response.GetBuyerComparisonFromEnt(comparison), nil
+}
+
+func (c *application) ListBuyerComparisons(ctx context.Context, buyerID string) ([]*response.BuyerComparison, *imhttp.CustomError) {
+	comparisons, err := c.repo.ListBuyerComparisons(ctx, buyerID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list comparisons", err.Error())
+	}
+
+	result := make([]*response.BuyerComparison, 0, len(comparisons))
+	for _, comparison := range comparisons {
+		result = append(result, response.GetBuyerComparisonFromEnt(comparison))
+	}
+	return result, nil
+}
+
+// GetBuyerComparison returns a saved comparison with the side-by-side project details.
+func (c *application) GetBuyerComparison(ctx context.Context, buyerID, id string) (*response.BuyerComparisonDetail, *imhttp.CustomError) {
+	comparison, err := c.repo.GetBuyerComparison(ctx, buyerID, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Comparison not found", err.Error())
+	}
+
+	detail, cerr := c.CompareProjects(comparison.ProjectIds)
+	if cerr != nil {
+		return nil, cerr
+	}
+	return &response.BuyerComparisonDetail{
+		BuyerComparison: response.GetBuyerComparisonFromEnt(comparison),
+		Comparison:      detail,
+	}, nil
+}
+
+func (c *application) UpdateBuyerComparison(ctx context.Context, buyerID, id string, req *request.UpdateBuyerComparisonRequest) (*response.BuyerComparison, *imhttp.CustomError) {
+	if _, err := c.repo.GetBuyerComparison(ctx, buyerID, id); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Comparison not found", err.Error())
+	}
+
+	var projectIDs []string
+	if req.ProjectIDs != nil {
+		var cerr *imhttp.CustomError
+		if projectIDs, cerr = c.validateComparisonProjects(req.ProjectIDs); cerr != nil {
+			return nil, cerr
+		}
+	}
+	name := req.Name
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		name = &trimmed
+	}
+
+	comparison, err := c.repo.UpdateBuyerComparison(ctx, id, name, projectIDs)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update comparison", err.Error())
+	}
+	return response.GetBuyerComparisonFromEnt(comparison), nil
+}
+
+func (c *application) DeleteBuyerComparison(ctx context.Context, buyerID, id string) *imhttp.CustomError {
+	deleted, err := c.repo.DeleteBuyerComparison(ctx, buyerID, id)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete comparison", err.Error())
+	}
+	if !deleted {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Comparison not found", "Comparison not found")
+	}
+	return nil
+}
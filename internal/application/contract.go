@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/auth"
 	"github.com/VI-IM/im_backend_go/internal/client"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/internal/repository"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
@@ -16,24 +18,52 @@ type application struct {
 	s3Client  client.S3ClientInterface
 	smsClient client.SMSClientInterface
 	crmClient client.CRMClientInterface
+	mailer    client.MailerInterface
+	events    *eventBuffer
+	similar   *similarCache
+	stampDuty map[string]domain.StampDutyRate
+	roles     *roleCache
+	access    *userAccessCache
 }
 
 type ApplicationInterface interface {
 	// Auth
-	GetAccessToken(username, password string) (*response.GenerateTokenResponse, *imhttp.CustomError)
-	RefreshToken(refreshToken string) (*response.GenerateTokenResponse, *imhttp.CustomError)
-	Signup(ctx context.Context, req *request.SignupRequest) (*response.GenerateTokenResponse, *imhttp.CustomError)
+	GetAccessToken(ctx context.Context, username, password string, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError)
+	RefreshToken(ctx context.Context, refreshToken string, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError)
+	Signup(ctx context.Context, req *request.SignupRequest, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError)
+	Logout(ctx context.Context, refreshToken string) *imhttp.CustomError
+	LogoutAll(ctx context.Context, userID string) *imhttp.CustomError
+	ListUserSessions(ctx context.Context, userID string) ([]*response.Session, *imhttp.CustomError)
+	RevokeUserSession(ctx context.Context, userID, sessionID string) *imhttp.CustomError
+	RequestPasswordReset(ctx context.Context, email string) *imhttp.CustomError
+	ResetPassword(ctx context.Context, req *request.ResetPasswordRequest) *imhttp.CustomError
+	RequestEmailVerification(ctx context.Context, userID string) *imhttp.CustomError
+	VerifyEmail(ctx context.Context, token string) *imhttp.CustomError
+
+	// Two-factor authentication
+	GetTwoFactorStatus(ctx context.Context, userID string) (*response.TwoFactorStatus, *imhttp.CustomError)
+	SetupTwoFactor(ctx context.Context, userID string) (*response.TwoFactorSetup, *imhttp.CustomError)
+	EnableTwoFactor(ctx context.Context, userID, code string, actor domain.Actor) (*response.RecoveryCodes, *imhttp.CustomError)
+	DisableTwoFactor(ctx context.Context, userID string, req *request.DisableTwoFactorRequest, actor domain.Actor) *imhttp.CustomError
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*response.RecoveryCodes, *imhttp.CustomError)
+	ResetUserTwoFactor(ctx context.Context, userID string, actor domain.Actor) *imhttp.CustomError
+	SetupTwoFactorChallenge(ctx context.Context, challengeToken string) (*response.TwoFactorSetup, *imhttp.CustomError)
+	VerifyTwoFactorChallenge(ctx context.Context, req *request.VerifyTwoFactorChallengeRequest, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError)
 
 	// Project
 	GetProjectByID(id string) (*response.Project, *imhttp.CustomError)
-	AddProject(input request.AddProjectRequest) (*response.AddProjectResponse, *imhttp.CustomError)
-	UpdateProject(input request.UpdateProjectRequest) (*response.Project, *imhttp.CustomError)
-	DeleteProject(id string) *imhttp.CustomError
+	AddProject(ctx context.Context, input request.AddProjectRequest, actor domain.Actor) (*response.AddProjectResponse, *imhttp.CustomError)
+	UpdateProject(ctx context.Context, input request.UpdateProjectRequest, actor domain.Actor) (*response.Project, *imhttp.CustomError)
+	DeleteProject(ctx context.Context, id string, req *request.DeleteRequest, actor domain.Actor) (*response.DeletionResult, *imhttp.CustomError)
+	GetProjectDeletionPlan(ctx context.Context, id string) (*response.DeletionPlan, *imhttp.CustomError)
+	RestoreProject(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError
 	ListProjects(request *request.GetAllAPIRequest) ([]*response.ProjectListResponse, *imhttp.CustomError)
 	CompareProjects(projectIDs []string) (*response.ProjectComparisonResponse, *imhttp.CustomError)
 	GetProjectByURL(url string) (*ent.Project, *imhttp.CustomError)
 	GetProjectFilters() (map[string]interface{}, *imhttp.CustomError)
 	GetProjectNamesOnly() ([]*response.ProjectNameResponse, *imhttp.CustomError)
+	ImportProjects(ctx context.Context, req *request.ImportProjectsRequest, actor domain.Actor) (*response.ProjectImportReport, *imhttp.CustomError)
+	ExportProjects(ctx context.Context, req *request.ExportProjectsRequest) (*response.ProjectExport, *imhttp.CustomError)
 
 	// Developer
 	ListDevelopers(pagination *request.GetAllAPIRequest) ([]*response.Developer, *imhttp.CustomError)
@@ -48,21 +78,25 @@ type ApplicationInterface interface {
 
 	// Property
 	GetPropertyByID(id string) (*response.Property, *imhttp.CustomError)
+	GetPublishedPropertyByID(ctx context.Context, id string) (*response.Property, *imhttp.CustomError)
 	GetPropertyBySlug(ctx context.Context, slug string) (*response.Property, *imhttp.CustomError)
-	UpdateProperty(input request.UpdatePropertyRequest) (*response.Property, *imhttp.CustomError)
+	UpdateProperty(ctx context.Context, input request.UpdatePropertyRequest, actor domain.Actor) (*response.Property, *imhttp.CustomError)
 	GetPropertiesOfProject(projectID string) ([]*response.Property, *imhttp.CustomError)
-	AddProperty(input request.AddPropertyRequest) (*response.AddPropertyResponse, *imhttp.CustomError)
-	ListProperties(pagination *request.GetAllAPIRequest) ([]*response.PropertyListResponse, int, *imhttp.CustomError)
-	DeleteProperty(id string) *imhttp.CustomError
+	AddProperty(ctx context.Context, input request.AddPropertyRequest, actor domain.Actor) (*response.AddPropertyResponse, *imhttp.CustomError)
+	ListProperties(ctx context.Context, req *request.ListPropertiesRequest) (*response.PropertyListPage, *imhttp.CustomError)
+	SyncPropertyAttributes(ctx context.Context) (int, *imhttp.CustomError)
+	DeleteProperty(ctx context.Context, id string, req *request.DeleteRequest, actor domain.Actor) (*response.DeletionResult, *imhttp.CustomError)
+	GetPropertyDeletionPlan(ctx context.Context, id string) (*response.DeletionPlan, *imhttp.CustomError)
+	RestoreProperty(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError
 	
 	// Amenity
 	GetAllCategoriesWithAmenities() (*response.AmenityResponse, *imhttp.CustomError)
-	AddCategoryWithAmenities(req *request.CreateAmenityRequest) *imhttp.CustomError
-	UpdateStaticSiteData(req *request.UpdateStaticSiteDataRequest) (*response.StaticSiteDataResponse, *imhttp.CustomError)
-	AddCategory(categoryName string) *imhttp.CustomError
-	AddAmenityToCategory(req *request.AddAmenityToCategoryRequest) *imhttp.CustomError
-	DeleteAmenityFromCategory(req *request.DeleteAmenityFromCategoryRequest) *imhttp.CustomError
-	DeleteCategoryWithAmenities(categoryName string) *imhttp.CustomError
+	AddCategoryWithAmenities(ctx context.Context, req *request.CreateAmenityRequest, actor domain.Actor) *imhttp.CustomError
+	UpdateStaticSiteData(ctx context.Context, req *request.UpdateStaticSiteDataRequest, actor domain.Actor) (*response.StaticSiteDataResponse, *imhttp.CustomError)
+	AddCategory(ctx context.Context, categoryName string, actor domain.Actor) *imhttp.CustomError
+	AddAmenityToCategory(ctx context.Context, req *request.AddAmenityToCategoryRequest, actor domain.Actor) *imhttp.CustomError
+	DeleteAmenityFromCategory(ctx context.Context, req *request.DeleteAmenityFromCategoryRequest, actor domain.Actor) *imhttp.CustomError
+	DeleteCategoryWithAmenities(ctx context.Context, categoryName string, actor domain.Actor) *imhttp.CustomError
 
 	// Upload File
 	UploadFile(request request.UploadFileRequest) (string, string, *imhttp.CustomError)
@@ -72,9 +106,9 @@ type ApplicationInterface interface {
 	ListBlogsWithFilter(isPublished *bool) (*response.BlogListResponse, *imhttp.CustomError)
 	GetBlogByID(id string) (*response.BlogResponse, *imhttp.CustomError)
 	GetBlogBySlug(slug string) (*response.BlogResponse, *imhttp.CustomError)
-	CreateBlog(ctx context.Context, req *request.CreateBlogRequest) (*response.BlogResponse, *imhttp.CustomError)
-	DeleteBlog(ctx context.Context, id string) *imhttp.CustomError
-	UpdateBlog(ctx context.Context, id string, req *request.UpdateBlogRequest) (*response.BlogResponse, *imhttp.CustomError)
+	CreateBlog(ctx context.Context, req *request.CreateBlogRequest, actor domain.Actor) (*response.BlogResponse, *imhttp.CustomError)
+	DeleteBlog(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError
+	UpdateBlog(ctx context.Context, id string, req *request.UpdateBlogRequest, actor domain.Actor) (*response.BlogResponse, *imhttp.CustomError)
 
 	// Content
 	GetProjectByCanonicalURL(ctx context.Context, url string) (*ent.Project, *imhttp.CustomError)
@@ -89,19 +123,137 @@ type ApplicationInterface interface {
 	GetCustomSearchPage(ctx context.Context, slug string) (*response.CustomSearchPage, *imhttp.CustomError)
 	GetLinks(ctx context.Context) ([]*response.Link, *imhttp.CustomError)
 	GetAllCustomSearchPages(ctx context.Context) ([]*response.CustomSearchPage, *imhttp.CustomError)
-	AddCustomSearchPage(ctx context.Context, customSearchPage *request.CustomSearchPage) (*response.CustomSearchPage, *imhttp.CustomError)
-	UpdateCustomSearchPage(ctx context.Context, id string, customSearchPage *request.CustomSearchPage) (*response.CustomSearchPage, *imhttp.CustomError)
-	DeleteCustomSearchPage(ctx context.Context, id string) *imhttp.CustomError
+	AddCustomSearchPage(ctx context.Context, customSearchPage *request.CustomSearchPage, actor domain.Actor) (*response.CustomSearchPage, *imhttp.CustomError)
+	UpdateCustomSearchPage(ctx context.Context, id string, customSearchPage *request.CustomSearchPage, actor domain.Actor) (*response.CustomSearchPage, *imhttp.CustomError)
+	DeleteCustomSearchPage(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError
 
 	// Leads
-	CreateLeadWithOTP(ctx context.Context, req *request.CreateLeadRequest) (*response.CreateLeadResponse, *imhttp.CustomError)
-	CreateLead(ctx context.Context, req *request.CreateLeadRequest) (*response.CreateLeadResponse, *imhttp.CustomError)
+	CreateLeadWithOTP(ctx context.Context, req *request.CreateLeadRequest, actor domain.Actor) (*response.CreateLeadResponse, *imhttp.CustomError)
+	CreateLead(ctx context.Context, req *request.CreateLeadRequest, actor domain.Actor) (*response.CreateLeadResponse, *imhttp.CustomError)
 	GetLeadByID(ctx context.Context, id int) (*response.Lead, *imhttp.CustomError)
 	GetAllLeads(ctx context.Context, req *request.GetLeadsRequest) (*response.DateLeadsData, *imhttp.CustomError)
-	ValidateOTP(ctx context.Context, req *request.ValidateOTPRequest) (*response.ValidateOTPResponse, *imhttp.CustomError)
-	ResendOTP(ctx context.Context, req *request.ResendOTPRequest) (*response.ResendOTPResponse, *imhttp.CustomError)
+	ValidateOTP(ctx context.Context, req *request.ValidateOTPRequest, actor domain.Actor) (*response.ValidateOTPResponse, *imhttp.CustomError)
+	ResendOTP(ctx context.Context, req *request.ResendOTPRequest, actor domain.Actor) (*response.ResendOTPResponse, *imhttp.CustomError)
+
+	// Deletion
+	PurgeExpiredDeletions(ctx context.Context) (*response.PurgeResult, *imhttp.CustomError)
+
+	// Slugs and redirects
+	GetRedirect(ctx context.Context, path string) (*ent.Redirect, *imhttp.CustomError)
+	ListRedirects(ctx context.Context, req *request.ListRedirectsRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+	CreateRedirect(ctx context.Context, req *request.RedirectRequest) (*response.Redirect, *imhttp.CustomError)
+	UpdateRedirect(ctx context.Context, id string, req *request.RedirectRequest) (*response.Redirect, *imhttp.CustomError)
+	DeleteRedirect(ctx context.Context, id string) *imhttp.CustomError
+	GetSlugHistory(ctx context.Context, entityType, entityID string) ([]*response.SlugRecord, *imhttp.CustomError)
+	ReleaseSlug(ctx context.Context, slug string) *imhttp.CustomError
+	SyncSlugRegistry(ctx context.Context) (*response.SlugSyncResult, *imhttp.CustomError)
+
+	// Moderation
+	ListPropertyRevisions(ctx context.Context, req *request.ListPropertyRevisionsRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+	GetPropertyRevision(ctx context.Context, id string) (*response.PropertyRevision, *imhttp.CustomError)
+	ApprovePropertyRevision(ctx context.Context, id string, actor domain.Actor) (*response.PropertyRevision, *imhttp.CustomError)
+	RejectPropertyRevision(ctx context.Context, id string, actor domain.Actor, req *request.ReviewPropertyRevisionRequest) (*response.PropertyRevision, *imhttp.CustomError)
+
+	// Notifications
+	ListNotifications(ctx context.Context, userID string, req *request.ListNotificationsRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+	MarkNotificationRead(ctx context.Context, id, userID string) *imhttp.CustomError
+
+	// Partner portal
+	GetPartnerProfile(ctx context.Context, userID string) (*response.UserProfile, *imhttp.CustomError)
+	UpdatePartnerProfile(ctx context.Context, req *request.UpdateProfileRequest, actor domain.Actor) (*response.UserProfile, *imhttp.CustomError)
+	GetListingPerformance(ctx context.Context, userID string, req *request.PartnerPerformanceRequest) (*response.ListingPerformance, *imhttp.CustomError)
+	ListPartnerLeads(ctx context.Context, userID string, req *request.ListPartnerLeadsRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+	ListTeamMembers(ctx context.Context, ownerID string) ([]*response.TeamMember, *imhttp.CustomError)
+	InviteTeamMember(ctx context.Context, req *request.InviteTeamMemberRequest, actor domain.Actor) (*response.Invitation, *imhttp.CustomError)
+	RemoveTeamMember(ctx context.Context, memberID string, actor domain.Actor) *imhttp.CustomError
+	AcceptInvitation(ctx context.Context, req *request.AcceptInvitationRequest, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError)
+
+	// Listing events
+	RecordListingEvent(req request.ListingEventRequest)
+	IngestListingEvents(ctx context.Context, req *request.IngestEventsRequest) (*response.IngestEventsResult, *imhttp.CustomError)
+	FlushListingEvents(ctx context.Context) (int, *imhttp.CustomError)
+	RollupListingEvents(ctx context.Context) (int, *imhttp.CustomError)
+	GetListingAnalytics(ctx context.Context, entityType, entityID string, req *request.ListingAnalyticsRequest) (*response.ListingAnalytics, *imhttp.CustomError)
+	GetPartnerPropertyAnalytics(ctx context.Context, userID, propertyID string, req *request.ListingAnalyticsRequest) (*response.ListingAnalytics, *imhttp.CustomError)
+	GetProjectBrochure(ctx context.Context, projectID, sessionID string) (*response.ProjectBrochure, *imhttp.CustomError)
+
+	// Trending
+	RecomputeTrendingProjects(ctx context.Context) (int, *imhttp.CustomError)
+	ListTrendingProjects(ctx context.Context, req *request.TrendingProjectsRequest) ([]*response.TrendingProject, *imhttp.CustomError)
+
+	// Similar projects
+	GetSimilarProjects(ctx context.Context, projectID string, req *request.SimilarProjectsRequest) ([]*response.SimilarProject, *imhttp.CustomError)
+
+	// Saved searches
+	CreateSavedSearch(ctx context.Context, req *request.CreateSavedSearchRequest) (*response.SavedSearch, *imhttp.CustomError)
+	VerifySavedSearch(ctx context.Context, id string, req *request.VerifySavedSearchRequest) (*response.SavedSearch, *imhttp.CustomError)
+	UnsubscribeSavedSearch(ctx context.Context, token string) *imhttp.CustomError
+	SendSavedSearchAlerts(ctx context.Context) (*response.SavedSearchAlertResult, *imhttp.CustomError)
+
+	// Price history
+	GetProjectPriceHistory(ctx context.Context, projectID string) (*response.ProjectPriceHistory, *imhttp.CustomError)
+
+	// Finance
+	CalculateEMI(ctx context.Context, req *request.EMIRequest) (*response.EMIResponse, *imhttp.CustomError)
+	CalculateAffordability(ctx context.Context, req *request.AffordabilityRequest) (*response.AffordabilityResponse, *imhttp.CustomError)
+	CalculatePurchaseCharges(ctx context.Context, req *request.PurchaseChargesRequest) (*response.PurchaseChargesResponse, *imhttp.CustomError)
+	ListStampDutyRates() []*response.StampDutyRate
+
+	// User management
+	ListUsers(ctx context.Context, req *request.ListUsersRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+	GetUser(ctx context.Context, id string) (*response.AdminUser, *imhttp.CustomError)
+	CreateUser(ctx context.Context, req *request.CreateUserRequest, actor domain.Actor) (*response.UserCredentials, *imhttp.CustomError)
+	UpdateUser(ctx context.Context, id string, req *request.UpdateUserRequest, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError)
+	ChangeUserRole(ctx context.Context, id string, req *request.ChangeUserRoleRequest, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError)
+	DeactivateUser(ctx context.Context, id string, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError)
+	ReactivateUser(ctx context.Context, id string, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError)
+	ResetUserPassword(ctx context.Context, id string, req *request.ResetUserPasswordRequest, actor domain.Actor) (*response.UserCredentials, *imhttp.CustomError)
+	UnlockUser(ctx context.Context, id string, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError)
+	ListLoginHistory(ctx context.Context, userID string, req *request.GetAllAPIRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+
+	// Audit log
+	ListAuditLogs(ctx context.Context, req *request.ListAuditLogsRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+	ExportAuditLogs(ctx context.Context, req *request.ListAuditLogsRequest) (*response.AuditLogExport, *imhttp.CustomError)
+
+	// API keys
+	CreateAPIKey(ctx context.Context, req *request.CreateAPIKeyRequest, actor domain.Actor) (*response.CreatedAPIKey, *imhttp.CustomError)
+	ListAPIKeys(ctx context.Context) ([]*response.APIKey, *imhttp.CustomError)
+	RevokeAPIKey(ctx context.Context, id string, actor domain.Actor) (*response.APIKey, *imhttp.CustomError)
+	AuthenticateAPIKey(ctx context.Context, key, ipAddress string) (*auth.Claims, map[string]bool, *imhttp.CustomError)
+	ValidateUserClaims(ctx context.Context, claims *auth.Claims) *imhttp.CustomError
+
+	// Roles and permissions
+	EnsureDefaultRoles(ctx context.Context) error
+	RolePermissions(ctx context.Context, role string) (map[string]bool, *imhttp.CustomError)
+	ListRoles(ctx context.Context) ([]*response.Role, *imhttp.CustomError)
+	ListPermissions() []*response.Permission
+	UpdateRole(ctx context.Context, name string, req *request.UpdateRoleRequest, actor domain.Actor) (*response.Role, *imhttp.CustomError)
+
+	// Buyers
+	RequestBuyerOTP(ctx context.Context, req *request.BuyerOTPRequest) *imhttp.CustomError
+	VerifyBuyerOTP(ctx context.Context, req *request.VerifyBuyerOTPRequest) (*response.BuyerLoginResponse, *imhttp.CustomError)
+	GetBuyerProfile(ctx context.Context, buyerID string) (*response.Buyer, *imhttp.CustomError)
+	UpdateBuyerProfile(ctx context.Context, buyerID string, req *request.UpdateBuyerProfileRequest) (*response.Buyer, *imhttp.CustomError)
+	GetBuyerShortlist(ctx context.Context, buyerID string) ([]*response.ShortlistItem, *imhttp.CustomError)
+	AddToShortlist(ctx context.Context, buyerID string, req *request.ShortlistRequest) *imhttp.CustomError
+	RemoveFromShortlist(ctx context.Context, buyerID, entityType, entityID string) *imhttp.CustomError
+	ListBuyerEnquiries(ctx context.Context, buyerID string, req *request.GetAllAPIRequest) (*response.PaginatedResponse, *imhttp.CustomError)
+	CreateBuyerComparison(ctx context.Context, buyerID string, req *request.BuyerComparisonRequest) (*response.BuyerComparison, *imhttp.CustomError)
+	ListBuyerComparisons(ctx context.Context, buyerID string) ([]*response.BuyerComparison, *imhttp.CustomError)
+	GetBuyerComparison(ctx context.Context, buyerID, id string) (*response.BuyerComparisonDetail, *imhttp.CustomError)
+	UpdateBuyerComparison(ctx context.Context, buyerID, id string, req *request.UpdateBuyerComparisonRequest) (*response.BuyerComparison, *imhttp.CustomError)
+	DeleteBuyerComparison(ctx context.Context, buyerID, id string) *imhttp.CustomError
+
+	// RERA
+	GetProjectReraRegistrations(ctx context.Context, projectID string) ([]*response.ReraRegistration, *imhttp.CustomError)
+	AddReraRegistration(ctx context.Context, projectID string, req *request.ReraRegistrationRequest) (*response.ReraRegistration, *imhttp.CustomError)
+	UpdateReraRegistration(ctx context.Context, id string, req *request.ReraRegistrationRequest) (*response.ReraRegistration, *imhttp.CustomError)
+	DeleteReraRegistration(ctx context.Context, id string) *imhttp.CustomError
+	ListExpiringReraRegistrations(ctx context.Context) ([]*response.ReraRegistration, *imhttp.CustomError)
+	RefreshReraStatuses(ctx context.Context) (*response.ReraStatusRefreshResponse, *imhttp.CustomError)
+	BackfillReraRegistrations(ctx context.Context) (*response.ReraBackfillResult, *imhttp.CustomError)
 }
 
-func NewApplication(repo repository.AppRepository, s3Client client.S3ClientInterface, smsClient client.SMSClientInterface, crmClient client.CRMClientInterface) ApplicationInterface {
-	return &application{repo: repo, s3Client: s3Client, smsClient: smsClient, crmClient: crmClient}
+func NewApplication(repo repository.AppRepository, s3Client client.S3ClientInterface, smsClient client.SMSClientInterface, crmClient client.CRMClientInterface, mailer client.MailerInterface, stampDuty map[string]domain.StampDutyRate) ApplicationInterface {
+	return &application{repo: repo, s3Client: s3Client, smsClient: smsClient, crmClient: crmClient, mailer: mailer, events: &eventBuffer{}, similar: newSimilarCache(), stampDuty: stampDuty, roles: newRoleCache(), access: newUserAccessCache()}
 }
@@ -20,5 +20,6 @@ func (c *application) CheckURLExists(ctx context.Context, url string) (*response
 		Exists:     result.Exists,
 		EntityType: result.EntityType,
 		EntityID:   result.EntityID,
+		Retired:    result.Retired,
 	}, nil
 }
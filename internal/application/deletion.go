@@ -0,0 +1,206 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/deletionrecord"
+	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+func (c *application) GetProjectDeletionPlan(ctx context.Context, id string) (*response.DeletionPlan, *imhttp.CustomError) {
+	report, err := c.repo.GetProjectDeletionReport(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Failed to get project dependents", err.Error())
+	}
+	return buildDeletionPlan(report), nil
+}
+
+func (c *application) GetPropertyDeletionPlan(ctx context.Context, id string) (*response.DeletionPlan, *imhttp.CustomError) {
+	report, err := c.repo.GetPropertyDeletionReport(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Failed to get property dependents", err.Error())
+	}
+	return buildDeletionPlan(report), nil
+}
+
+func (c *application) RestoreProject(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError {
+	if customErr := c.restoreDeletion(ctx, domain.DeletionEntityProject, id); customErr != nil {
+		return customErr
+	}
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionRestore,
+		EntityType: domain.AuditEntityProject,
+		EntityID:   id,
+		After:      c.projectSnapshot(id),
+	})
+	return nil
+}
+
+func (c *application) RestoreProperty(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError {
+	if customErr := c.restoreDeletion(ctx, domain.DeletionEntityProperty, id); customErr != nil {
+		return customErr
+	}
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionRestore,
+		EntityType: domain.AuditEntityProperty,
+		EntityID:   id,
+		After:      c.propertySnapshot(id),
+	})
+	return nil
+}
+
+func (c *application) restoreDeletion(ctx context.Context, entityType, id string) *imhttp.CustomError {
+	record, err := c.repo.GetPendingDeletionRecord(ctx, entityType, id)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get deletion record", err.Error())
+	}
+	if record == nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Nothing to restore", fmt.Sprintf("%s %s has no restorable deletion", entityType, id))
+	}
+	if time.Now().After(record.RestoreUntil) {
+		return imhttp.NewCustomErr(http.StatusGone, "Restore window has closed",
+			fmt.Sprintf("%s %s could only be restored until %s", entityType, id, record.RestoreUntil.Format(time.RFC3339)))
+	}
+
+	if err := c.repo.RestoreDeletion(ctx, record); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to restore deletion")
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to restore", err.Error())
+	}
+	if entityType == domain.DeletionEntityProject {
+		c.similar.invalidate()
+	}
+	return nil
+}
+
+// PurgeExpiredDeletions hard-deletes everything whose restore window has closed and
+// leaves a redirect behind for every removed page.
+func (c *application) PurgeExpiredDeletions(ctx context.Context) (*response.PurgeResult, *imhttp.CustomError) {
+	records, err := c.repo.GetPurgeableDeletionRecords(ctx, time.Now())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get purgeable deletions", err.Error())
+	}
+
+	result := &response.PurgeResult{}
+	for _, record := range records {
+		redirects := purgeRedirects(record)
+		if err := c.repo.PurgeDeletion(ctx, record, redirects); err != nil {
+			logger.Get().Error().Err(err).Str("deletion_id", record.ID).Msg("Failed to purge deletion")
+			result.Failed++
+			continue
+		}
+		logger.Get().Info().
+			Str("entity_type", string(record.EntityType)).
+			Str("entity_id", record.EntityID).
+			Int("redirects", len(redirects)).
+			Msg("Purged deleted entity")
+		result.Purged++
+		result.Redirects += len(redirects)
+	}
+	if result.Purged > 0 {
+		c.similar.invalidate()
+	}
+
+	return result, nil
+}
+
+func purgeRedirects(record *ent.DeletionRecord) []domain.Redirect {
+	target := record.RedirectTo
+	if target == "" {
+		target = "/"
+	}
+
+	var redirects []domain.Redirect
+	add := func(from string) {
+		if from == target {
+			return
+		}
+		redirects = append(redirects, domain.Redirect{FromPath: from, ToPath: target, StatusCode: http.StatusMovedPermanently, Reason: domain.RedirectReasonPurge})
+	}
+
+	switch record.EntityType {
+	case deletionrecord.EntityTypeProject:
+		if record.Slug != "" {
+			add(domain.ProjectPath(record.Slug))
+		}
+		cascaded := make(map[string]bool, len(record.CascadedPropertyIds))
+		for _, id := range record.CascadedPropertyIds {
+			cascaded[id] = true
+		}
+		for _, p := range record.Report.Properties {
+			if cascaded[p.ID] && p.Slug != "" {
+				add(domain.PropertyPath(p.Slug))
+			}
+		}
+	case deletionrecord.EntityTypeProperty:
+		if record.Slug != "" {
+			add(domain.PropertyPath(record.Slug))
+		}
+	}
+	return redirects
+}
+
+func buildDeletionPlan(report *schema.DeletionReport) *response.DeletionPlan {
+	plan := &response.DeletionPlan{
+		DeletionReport:  report,
+		RequiresCascade: report.EntityType == domain.DeletionEntityProject && len(report.Properties) > 0,
+		Warnings:        []string{},
+		RestoreUntil:    time.Now().Add(config.GetConfig().Deletion.RestoreWindow),
+	}
+
+	if plan.RequiresCascade {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%d live properties will be deleted with the project", len(report.Properties)))
+	}
+	if report.LeadCount > 0 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%d leads will be kept but lose their link once the %s is purged", report.LeadCount, report.EntityType))
+	}
+	if len(report.CustomSearchPages) > 0 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%d custom search pages filter on this project and should be updated", len(report.CustomSearchPages)))
+	}
+	if report.ReraRegistrations > 0 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%d RERA registrations will be removed once the project is purged", report.ReraRegistrations))
+	}
+	return plan
+}
+
+// normalizeRedirectTarget accepts a site path or an absolute http(s) URL.
+func normalizeRedirectTarget(target string) (string, *imhttp.CustomError) {
+	target = strings.TrimSpace(target)
+	if target == "" || strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") {
+		return target, nil
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", imhttp.NewCustomErr(http.StatusBadRequest, "Invalid redirect_to", "redirect_to must be a path starting with / or an http(s) URL")
+	}
+	return target, nil
+}
+
+// GetRedirect returns the redirect registered for a path, or nil if there is none.
+func (c *application) GetRedirect(ctx context.Context, path string) (*ent.Redirect, *imhttp.CustomError) {
+	rd, err := c.repo.GetRedirectByPath(ctx, path)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get redirect", err.Error())
+	}
+	// Property redirects are recorded against the sale path; rent paths share them.
+	if slug, ok := domain.SplitPropertyPath(path); rd == nil && ok && path != domain.PropertyPath(slug) {
+		rd, err = c.repo.GetRedirectByPath(ctx, domain.PropertyPath(slug))
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get redirect", err.Error())
+		}
+	}
+	return rd, nil
+}
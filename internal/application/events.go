@@ -0,0 +1,210 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// defaultAnalyticsDays is the period reported when no start date is given.
+const defaultAnalyticsDays = 30
+
+// eventBuffer holds listing events in memory until the next flush writes them as a batch.
+type eventBuffer struct {
+	mu       sync.Mutex
+	pending  []domain.ListingEvent
+	flushing bool
+}
+
+// RecordListingEvent buffers an event. A full batch is flushed in the background; once
+// EVENTS_MAX_BUFFERED events are waiting, further events are dropped.
+func (c *application) RecordListingEvent(req request.ListingEventRequest) {
+	cfg := config.GetConfig().Events
+	event := domain.ListingEvent{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		EventType:  req.EventType,
+		SessionID:  req.SessionID,
+		Source:     req.Source,
+		OccurredAt: time.Now(),
+	}
+
+	c.events.mu.Lock()
+	if len(c.events.pending) >= cfg.MaxBuffered {
+		c.events.mu.Unlock()
+		logger.Get().Warn().Str("event_type", event.EventType).Msg("Listing event buffer full, dropping event")
+		return
+	}
+	c.events.pending = append(c.events.pending, event)
+	full := len(c.events.pending) >= cfg.BatchSize
+	c.events.mu.Unlock()
+
+	if full {
+		go c.FlushListingEvents(context.Background())
+	}
+}
+
+// IngestListingEvents accepts interaction events reported by the frontend. Page views are
+// recorded server-side when a listing is fetched, so they are not accepted here.
+func (c *application) IngestListingEvents(ctx context.Context, req *request.IngestEventsRequest) (*response.IngestEventsResult, *imhttp.CustomError) {
+	result := &response.IngestEventsResult{}
+	for _, e := range req.Events {
+		if !domain.IsValidEventEntity(e.EntityType) || !domain.IsValidEventType(e.EventType) ||
+			e.EventType == domain.EventPageView || e.EntityID == "" {
+			result.Rejected++
+			continue
+		}
+		c.RecordListingEvent(e)
+		result.Accepted++
+	}
+	return result, nil
+}
+
+// FlushListingEvents writes the buffered events and adds property page views to the
+// lifetime view counters. A failed batch is put back to be retried on the next flush.
+func (c *application) FlushListingEvents(ctx context.Context) (int, *imhttp.CustomError) {
+	c.events.mu.Lock()
+	if c.events.flushing || len(c.events.pending) == 0 {
+		c.events.mu.Unlock()
+		return 0, nil
+	}
+	batch := c.events.pending
+	c.events.pending = nil
+	c.events.flushing = true
+	c.events.mu.Unlock()
+
+	defer func() {
+		c.events.mu.Lock()
+		c.events.flushing = false
+		c.events.mu.Unlock()
+	}()
+
+	if err := c.repo.CreateListingEvents(ctx, batch); err != nil {
+		maxBuffered := config.GetConfig().Events.MaxBuffered
+		c.events.mu.Lock()
+		c.events.pending = append(batch, c.events.pending...)
+		if len(c.events.pending) > maxBuffered {
+			c.events.pending = c.events.pending[len(c.events.pending)-maxBuffered:]
+		}
+		c.events.mu.Unlock()
+		return 0, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to write listing events", err.Error())
+	}
+
+	views := make(map[string]int)
+	for _, e := range batch {
+		if e.EntityType == domain.EventEntityProperty && e.EventType == domain.EventPageView {
+			views[e.EntityID]++
+		}
+	}
+	for propertyID, count := range views {
+		if err := c.repo.IncrementPropertyViews(ctx, propertyID, count); err != nil {
+			logger.Get().Error().Err(err).Str("property_id", propertyID).Msg("Failed to update property view count")
+		}
+	}
+	return len(batch), nil
+}
+
+// RollupListingEvents recomputes the daily rollups of yesterday and today. Yesterday is
+// included so events flushed shortly after midnight are counted on the right day.
+func (c *application) RollupListingEvents(ctx context.Context) (int, *imhttp.CustomError) {
+	now := time.Now()
+	total := 0
+	for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+		rows, err := c.repo.RollupListingEvents(ctx, day)
+		if err != nil {
+			return total, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to roll up listing events", err.Error())
+		}
+		total += rows
+	}
+	return total, nil
+}
+
+func (c *application) GetListingAnalytics(ctx context.Context, entityType, entityID string, req *request.ListingAnalyticsRequest) (*response.ListingAnalytics, *imhttp.CustomError) {
+	if !domain.IsValidEventEntity(entityType) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid entity type", "entity type must be project or property")
+	}
+
+	startDate, endDate, cerr := analyticsPeriod(req)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	rollups, err := c.repo.GetListingEventRollups(ctx, entityType, entityID, startDate, endDate)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get listing analytics", err.Error())
+	}
+	return response.GetListingAnalytics(entityType, entityID, startDate, endDate, rollups), nil
+}
+
+// GetPartnerPropertyAnalytics returns the analytics of a property owned by the caller's
+// partner account.
+func (c *application) GetPartnerPropertyAnalytics(ctx context.Context, userID, propertyID string, req *request.ListingAnalyticsRequest) (*response.ListingAnalytics, *imhttp.CustomError) {
+	ownerID, cerr := c.partnerAccountID(ctx, userID)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	property, err := c.repo.GetPropertyByID(propertyID)
+	if err != nil || property.CreatedByUserID != ownerID {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Property not found", "Property not found")
+	}
+	return c.GetListingAnalytics(ctx, domain.EventEntityProperty, propertyID, req)
+}
+
+// GetProjectBrochure returns the brochure link of a project and records the download.
+func (c *application) GetProjectBrochure(ctx context.Context, projectID, sessionID string) (*response.ProjectBrochure, *imhttp.CustomError) {
+	project, err := c.repo.GetProjectByID(projectID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Project not found", err.Error())
+	}
+
+	link := project.WebCards.KnowAbout.DownloadLink
+	if link == "" {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Brochure not available", "Brochure not available")
+	}
+
+	c.RecordListingEvent(request.ListingEventRequest{
+		EntityType: domain.EventEntityProject,
+		EntityID:   project.ID,
+		EventType:  domain.EventBrochureDownload,
+		SessionID:  sessionID,
+		Source:     "server",
+	})
+	return &response.ProjectBrochure{
+		ProjectID:    project.ID,
+		DownloadLink: link,
+	}, nil
+}
+
+// analyticsPeriod validates the requested YYYY-MM-DD period, defaulting to the last
+// defaultAnalyticsDays days.
+func analyticsPeriod(req *request.ListingAnalyticsRequest) (string, string, *imhttp.CustomError) {
+	end := time.Now()
+	if req.EndDate != "" {
+		parsed, err := time.Parse(time.DateOnly, req.EndDate)
+		if err != nil {
+			return "", "", imhttp.NewCustomErr(http.StatusBadRequest, "Invalid end_date, expected YYYY-MM-DD", err.Error())
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if req.StartDate != "" {
+		parsed, err := time.Parse(time.DateOnly, req.StartDate)
+		if err != nil {
+			return "", "", imhttp.NewCustomErr(http.StatusBadRequest, "Invalid start_date, expected YYYY-MM-DD", err.Error())
+		}
+		start = parsed
+	}
+	if start.After(end) {
+		return "", "", imhttp.NewCustomErr(http.StatusBadRequest, "start_date must not be after end_date", "start_date must not be after end_date")
+	}
+	return start.Format(time.DateOnly), end.Format(time.DateOnly), nil
+}
@@ -0,0 +1,185 @@
+package application
+
+import (
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+// listingTerms is what the finance calculators prefill from a project or property.
+type listingTerms struct {
+	Price float64
+	State string
+}
+
+// getListingTerms reads the price and state of a published project or property. A project
+// is priced at its starting (minimum) price.
+func (c *application) getListingTerms(ctx context.Context, projectID, propertyID string) (listingTerms, *imhttp.CustomError) {
+	var terms listingTerms
+	switch {
+	case propertyID != "":
+		property, err := c.repo.GetPublishedPropertyByID(ctx, propertyID)
+		if err != nil {
+			return terms, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get property", err.Error())
+		}
+		if property == nil {
+			return terms, imhttp.NewCustomErr(http.StatusNotFound, "Property not found", "Property not found")
+		}
+		if property.Price != nil {
+			terms.Price = *property.Price
+		} else if price := domain.ParsePrice(property.PricingInfo.Price); price != nil {
+			terms.Price = *price
+		}
+		if property.Edges.Location != nil {
+			terms.State = property.Edges.Location.State
+		}
+	case projectID != "":
+		project, err := c.repo.GetProjectByID(projectID)
+		if err != nil || project.IsDeleted {
+			return terms, imhttp.NewCustomErr(http.StatusNotFound, "Project not found", "Project not found")
+		}
+		if price := domain.ParsePrice(project.MinPrice); price != nil {
+			terms.Price = *price
+		}
+		if project.Edges.Location != nil {
+			terms.State = project.Edges.Location.State
+		}
+	}
+	return terms, nil
+}
+
+// loanTerms applies the configured defaults to a requested rate and tenure.
+func loanTerms(annualRate float64, tenureYears int) (float64, int, *imhttp.CustomError) {
+	cfg := config.GetConfig().Finance
+	if annualRate == 0 {
+		annualRate = cfg.DefaultInterestRate
+	}
+	if tenureYears == 0 {
+		tenureYears = cfg.DefaultTenureYears
+	}
+	if tenureYears > cfg.MaxTenureYears {
+		return 0, 0, imhttp.NewCustomErr(http.StatusBadRequest, "Tenure too long", "tenure_years is above the maximum loan tenure")
+	}
+	return annualRate, tenureYears * 12, nil
+}
+
+// CalculateEMI computes the EMI and repayment schedule of a home loan. The loan is the
+// requested amount, or the price less the down payment. Without a down payment, the
+// share lenders do not finance (1 - MaxLTV) is assumed.
+func (c *application) CalculateEMI(ctx context.Context, req *request.EMIRequest) (*response.EMIResponse, *imhttp.CustomError) {
+	annualRate, months, cerr := loanTerms(req.AnnualRate, req.TenureYears)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	price, downPayment, loan := req.Price, req.DownPayment, req.LoanAmount
+	if loan == 0 {
+		if price == 0 {
+			terms, cerr := c.getListingTerms(ctx, req.ProjectID, req.PropertyID)
+			if cerr != nil {
+				return nil, cerr
+			}
+			price = terms.Price
+		}
+		if price == 0 {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Price is required", "Provide loan_amount, price, or a priced project_id or property_id")
+		}
+		if downPayment == 0 {
+			downPayment = price * (1 - config.GetConfig().Finance.MaxLTV)
+		}
+		if downPayment >= price {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Down payment covers the price", "down_payment must be less than the price")
+		}
+		loan = price - downPayment
+	}
+
+	resp := response.GetEMIResponse(domain.AmortizeLoan(loan, annualRate, months))
+	resp.Price = price
+	resp.DownPayment = downPayment
+	return resp, nil
+}
+
+// CalculateAffordability sizes the largest loan and property price an income supports.
+// When a listing or price is given it also reports whether that price is affordable.
+func (c *application) CalculateAffordability(ctx context.Context, req *request.AffordabilityRequest) (*response.AffordabilityResponse, *imhttp.CustomError) {
+	cfg := config.GetConfig().Finance
+	annualRate, months, cerr := loanTerms(req.AnnualRate, req.TenureYears)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	result := domain.CalculateAffordability(req.MonthlyIncome, req.MonthlyObligations, cfg.FOIR, annualRate, months, req.DownPayment, cfg.MaxLTV)
+	resp := &response.AffordabilityResponse{
+		MaxEMI:          result.MaxEMI,
+		MaxLoan:         result.MaxLoan,
+		MaxPropertyCost: result.MaxPropertyCost,
+		AnnualRate:      annualRate,
+		TenureMonths:    months,
+	}
+
+	price := req.Price
+	if price == 0 && (req.ProjectID != "" || req.PropertyID != "") {
+		terms, cerr := c.getListingTerms(ctx, req.ProjectID, req.PropertyID)
+		if cerr != nil {
+			return nil, cerr
+		}
+		price = terms.Price
+	}
+	if price > 0 {
+		affordable := price <= result.MaxPropertyCost
+		resp.Price = price
+		resp.RequiredEMI = math.Round(domain.CalculateEMI(price*cfg.MaxLTV, annualRate, months))
+		resp.Affordable = &affordable
+	}
+	return resp, nil
+}
+
+// CalculatePurchaseCharges estimates stamp duty and registration. The state and price
+// default to those of the listing.
+func (c *application) CalculatePurchaseCharges(ctx context.Context, req *request.PurchaseChargesRequest) (*response.PurchaseChargesResponse, *imhttp.CustomError) {
+	price, state := req.Price, req.State
+	if (price == 0 || state == "") && (req.ProjectID != "" || req.PropertyID != "") {
+		terms, cerr := c.getListingTerms(ctx, req.ProjectID, req.PropertyID)
+		if cerr != nil {
+			return nil, cerr
+		}
+		if price == 0 {
+			price = terms.Price
+		}
+		if state == "" {
+			state = terms.State
+		}
+	}
+	if price == 0 {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Price is required", "Provide price, or a priced project_id or property_id")
+	}
+	if state == "" {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "State is required", "Provide state, or a project_id or property_id with a known state")
+	}
+
+	state = domain.NormalizeState(state)
+	rate, ok := c.stampDuty[state]
+	if !ok {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "No stamp duty rates for state", "No stamp duty rates for state: "+state)
+	}
+
+	charges := domain.CalculatePurchaseCharges(rate, price, req.Woman)
+	return &response.PurchaseChargesResponse{
+		State:        state,
+		Price:        price,
+		StampDuty:    charges.StampDuty,
+		Registration: charges.Registration,
+		Total:        charges.Total,
+		Rate:         response.GetStampDutyRate(state, rate),
+	}, nil
+}
+
+func (c *application) ListStampDutyRates() []*response.StampDutyRate {
+	return response.GetStampDutyRates(c.stampDuty)
+}
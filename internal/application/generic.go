@@ -7,6 +7,7 @@ import (
 
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
@@ -89,7 +90,7 @@ func (a *application) GetAllCustomSearchPages(ctx context.Context) ([]*response.
 	return customSearchPages, nil
 }
 
-func (a *application) AddCustomSearchPage(ctx context.Context, customSearchPage *request.CustomSearchPage) (*response.CustomSearchPage, *imhttp.CustomError) {
+func (a *application) AddCustomSearchPage(ctx context.Context, customSearchPage *request.CustomSearchPage, actor domain.Actor) (*response.CustomSearchPage, *imhttp.CustomError) {
 
 	if customSearchPage.Title == "" ||
 		customSearchPage.Description == "" ||
@@ -119,6 +120,9 @@ func (a *application) AddCustomSearchPage(ctx context.Context, customSearchPage
 		logger.Get().Info().Msg("Slug already in use")
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Slug already in use", "Slug already in use")
 	}
+	if customErr := a.ensureSlugAvailable(ctx, customSearchPage.Slug); customErr != nil {
+		return nil, customErr
+	}
 
 	customSearchPageEntity := &ent.CustomSearchPage{
 		Title:       customSearchPage.Title,
@@ -135,8 +139,15 @@ func (a *application) AddCustomSearchPage(ctx context.Context, customSearchPage
 
 	customSearchPageEntity, err = a.repo.AddCustomSearchPage(ctx, customSearchPageEntity)
 	if err != nil {
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add custom search page", err.Error())
+		return nil, slugError(err, "Failed to add custom search page")
 	}
+	a.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityCustomSearchPage,
+		EntityID:   customSearchPageEntity.ID,
+		After:      customSearchPageEntity,
+	})
 
 	response := &response.CustomSearchPage{
 		ID:          customSearchPageEntity.ID,
@@ -154,10 +165,18 @@ func (a *application) AddCustomSearchPage(ctx context.Context, customSearchPage
 	return response, nil
 }
 
-func (a *application) UpdateCustomSearchPage(ctx context.Context, id string, customSearchPage *request.CustomSearchPage) (*response.CustomSearchPage, *imhttp.CustomError) {
+func (a *application) UpdateCustomSearchPage(ctx context.Context, id string, customSearchPage *request.CustomSearchPage, actor domain.Actor) (*response.CustomSearchPage, *imhttp.CustomError) {
+	before, err := a.repo.GetCustomSearchPageByID(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, imhttp.NewCustomErr(http.StatusNotFound, "Custom search page not found", err.Error())
+		}
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get custom search page", err.Error())
+	}
 
 	customSearchPageEntity := &ent.CustomSearchPage{
 		ID:          id,
+		Slug:        strings.ReplaceAll(customSearchPage.Slug, " ", "-"),
 		Title:       customSearchPage.Title,
 		Description: customSearchPage.Description,
 		Filters:     customSearchPage.Filters,
@@ -168,10 +187,18 @@ func (a *application) UpdateCustomSearchPage(ctx context.Context, id string, cus
 		},
 	}
 
-	customSearchPageEntity, err := a.repo.UpdateCustomSearchPage(ctx, customSearchPageEntity)
+	customSearchPageEntity, err = a.repo.UpdateCustomSearchPage(ctx, customSearchPageEntity)
 	if err != nil {
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update custom search page", err.Error())
+		return nil, slugError(err, "Failed to update custom search page")
 	}
+	a.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: domain.AuditEntityCustomSearchPage,
+		EntityID:   id,
+		Before:     before,
+		After:      customSearchPageEntity,
+	})
 
 	response := &response.CustomSearchPage{
 
@@ -188,12 +215,26 @@ func (a *application) UpdateCustomSearchPage(ctx context.Context, id string, cus
 	return response, nil
 }
 
-func (a *application) DeleteCustomSearchPage(ctx context.Context, id string) *imhttp.CustomError {
+func (a *application) DeleteCustomSearchPage(ctx context.Context, id string, actor domain.Actor) *imhttp.CustomError {
+	before, err := a.repo.GetCustomSearchPageByID(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return imhttp.NewCustomErr(http.StatusNotFound, "Custom search page not found", err.Error())
+		}
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get custom search page", err.Error())
+	}
 
-	err := a.repo.DeleteCustomSearchPage(ctx, id)
+	err = a.repo.DeleteCustomSearchPage(ctx, id)
 	if err != nil {
 		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete custom search page", err.Error())
 	}
+	a.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionDelete,
+		EntityType: domain.AuditEntityCustomSearchPage,
+		EntityID:   id,
+		Before:     before,
+	})
 
 	return nil
 }
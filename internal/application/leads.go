@@ -11,6 +11,7 @@ import (
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/leads"
 	"github.com/VI-IM/im_backend_go/internal/client"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
@@ -22,7 +23,7 @@ func generateOTP() string {
 	return fmt.Sprintf("%06d", rand.Intn(1000000))
 }
 
-func (a *application) CreateLeadWithOTP(ctx context.Context, req *request.CreateLeadRequest) (*response.CreateLeadResponse, *imhttp.CustomError) {
+func (a *application) CreateLeadWithOTP(ctx context.Context, req *request.CreateLeadRequest, actor domain.Actor) (*response.CreateLeadResponse, *imhttp.CustomError) {
 
 	// Check for existing lead by phone number
 	existingLead, err := a.repo.GetLeadByPhone(ctx, req.Phone)
@@ -73,6 +74,13 @@ func (a *application) CreateLeadWithOTP(ctx context.Context, req *request.Create
 		logger.Get().Error().Err(err).Msg("Failed to create lead")
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create lead", err.Error())
 	}
+	a.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityLead,
+		EntityID:   strconv.Itoa(createdLead.ID),
+		After:      leadSnapshot(createdLead, lead),
+	})
 
 	// Send OTP via SMS
 	if err := a.smsClient.SendOTP(req.Phone, otp); err != nil {
@@ -88,7 +96,7 @@ func (a *application) CreateLeadWithOTP(ctx context.Context, req *request.Create
 	}, nil
 }
 
-func (a *application) CreateLead(ctx context.Context, req *request.CreateLeadRequest) (*response.CreateLeadResponse, *imhttp.CustomError) {
+func (a *application) CreateLead(ctx context.Context, req *request.CreateLeadRequest, actor domain.Actor) (*response.CreateLeadResponse, *imhttp.CustomError) {
 
 	// Check for existing lead by phone number
 	existingLead, err := a.repo.GetLeadByPhone(ctx, req.Phone)
@@ -135,6 +143,13 @@ func (a *application) CreateLead(ctx context.Context, req *request.CreateLeadReq
 		logger.Get().Error().Err(err).Msg("Failed to create lead")
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create lead", err.Error())
 	}
+	a.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityLead,
+		EntityID:   strconv.Itoa(createdLead.ID),
+		After:      leadSnapshot(createdLead, lead),
+	})
 
 	// Send lead to external CRM
 	go a.sendToCRM(ctx, createdLead, req)
@@ -179,6 +194,9 @@ func (a *application) getLeadsByDateGrouped(ctx context.Context, req *request.Ge
 	if req.Source != "" {
 		filters["source"] = req.Source
 	}
+	if req.OwnerUserID != "" {
+		filters["owner_user_id"] = req.OwnerUserID
+	}
 
 	// Handle date filtering - support both single date and date range
 	if req.Date != "" {
@@ -290,7 +308,7 @@ func (a *application) buildDuplicateHistory(ctx context.Context, referenceID str
 	return history, nil
 }
 
-func (a *application) ValidateOTP(ctx context.Context, req *request.ValidateOTPRequest) (*response.ValidateOTPResponse, *imhttp.CustomError) {
+func (a *application) ValidateOTP(ctx context.Context, req *request.ValidateOTPRequest, actor domain.Actor) (*response.ValidateOTPResponse, *imhttp.CustomError) {
 	lead, err := a.repo.GetLeadByPhoneAndOTP(ctx, req.Phone, req.OTP)
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -299,22 +317,32 @@ func (a *application) ValidateOTP(ctx context.Context, req *request.ValidateOTPR
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to validate OTP", err.Error())
 	}
 
+	before := response.ToLeadResponse(lead)
+
 	// Clear OTP and mark as verified
 	lead.Otp = ""
 	lead.OtpVerified = true
 
-	_, err = a.repo.UpdateLead(ctx, lead)
+	updatedLead, err := a.repo.UpdateLead(ctx, lead)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to update lead after OTP validation")
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update lead", err.Error())
 	}
+	a.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionVerify,
+		EntityType: domain.AuditEntityLead,
+		EntityID:   strconv.Itoa(updatedLead.ID),
+		Before:     before,
+		After:      leadSnapshot(updatedLead, lead),
+	})
 
 	return &response.ValidateOTPResponse{
 		Message: "OTP Validated Successfully",
 	}, nil
 }
 
-func (a *application) ResendOTP(ctx context.Context, req *request.ResendOTPRequest) (*response.ResendOTPResponse, *imhttp.CustomError) {
+func (a *application) ResendOTP(ctx context.Context, req *request.ResendOTPRequest, actor domain.Actor) (*response.ResendOTPResponse, *imhttp.CustomError) {
 	lead, err := a.repo.GetLeadByPhone(ctx, req.Phone)
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -323,16 +351,26 @@ func (a *application) ResendOTP(ctx context.Context, req *request.ResendOTPReque
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get lead", err.Error())
 	}
 
+	before := response.ToLeadResponse(lead)
+
 	// Generate new OTP
 	newOTP := generateOTP()
 	lead.Otp = newOTP
 
 	// Update lead with new OTP
-	_, err = a.repo.UpdateLead(ctx, lead)
+	updatedLead, err := a.repo.UpdateLead(ctx, lead)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to update lead with new OTP")
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update lead", err.Error())
 	}
+	a.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionResendOTP,
+		EntityType: domain.AuditEntityLead,
+		EntityID:   strconv.Itoa(updatedLead.ID),
+		Before:     before,
+		After:      leadSnapshot(updatedLead, lead),
+	})
 
 	// Send new OTP via SMS
 	if err := a.smsClient.SendOTP(req.Phone, newOTP); err != nil {
@@ -345,6 +383,14 @@ func (a *application) ResendOTP(ctx context.Context, req *request.ResendOTPReque
 	}, nil
 }
 
+// leadSnapshot is a saved lead as recorded in the audit log. Saving does not load the
+// lead's project and property, so they are taken from the lead that was saved.
+func leadSnapshot(saved, lead *ent.Leads) *response.Lead {
+	snapshot := *saved
+	snapshot.Edges = lead.Edges
+	return response.ToLeadResponse(&snapshot)
+}
+
 // sendToCRM sends lead data to external CRM system asynchronously
 func (a *application) sendToCRM(ctx context.Context, lead *ent.Leads, req *request.CreateLeadRequest) {
 	projectName := ""
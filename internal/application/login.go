@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// ListLoginHistory returns a page of a user's sign-in attempts, newest first.
+func (c *application) ListLoginHistory(ctx context.Context, userID string, req *request.GetAllAPIRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	if _, err := c.repo.GetUserByID(ctx, userID); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	req.Validate()
+
+	attempts, total, err := c.repo.ListLoginAttempts(ctx, userID, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list login history", err.Error())
+	}
+	items := make([]*response.LoginAttempt, 0, len(attempts))
+	for _, a := range attempts {
+		items = append(items, response.GetLoginAttemptFromEnt(a))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+// UnlockUser clears a user's failed sign-ins and lockout.
+func (c *application) UnlockUser(ctx context.Context, id string, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError) {
+	return c.changeUser(ctx, id, domain.AuditActionUnlock, domain.UserUpdate{Unlock: true}, actor)
+}
+
+// checkLoginAddress refuses sign-ins from an address with too many recent failures. The
+// address is the one utils.ClientIP resolved, so a forged X-Forwarded-For cannot spread
+// attempts over many addresses, and IPv6 addresses are counted per /64.
+func (c *application) checkLoginAddress(ctx context.Context, email string, meta domain.SessionMeta) *imhttp.CustomError {
+	if meta.IPAddress == "" {
+		return nil
+	}
+	cfg := config.GetConfig().Login
+	failures, err := c.repo.CountFailedLoginsFromNetwork(ctx, meta.IPAddress, time.Now().Add(-cfg.IPWindow))
+	if err != nil {
+		// Throttling is a safeguard; a lookup failure should not block every sign-in
+		logger.Get().Error().Err(err).Str("ip_address", meta.IPAddress).Msg("Failed to count failed logins")
+		return nil
+	}
+	if failures < cfg.IPMaxFailures {
+		return nil
+	}
+	c.recordLogin(ctx, domain.LoginAttempt{Email: email, Meta: meta, FailureReason: domain.LoginFailureThrottled})
+	return imhttp.NewCustomErr(http.StatusTooManyRequests, "Too many failed sign-ins, please try again later", "ip address throttled")
+}
+
+// checkAccountLock refuses sign-ins to a locked account, and to one with recent failures
+// until its progressive delay has passed.
+func (c *application) checkAccountLock(ctx context.Context, u *ent.User, meta domain.SessionMeta) *imhttp.CustomError {
+	now := time.Now()
+	if u.LockedUntil != nil && now.Before(*u.LockedUntil) {
+		c.recordLogin(ctx, domain.LoginAttempt{UserID: u.ID, Email: u.Email, Meta: meta, FailureReason: domain.LoginFailureLocked})
+		return imhttp.NewCustomErr(http.StatusLocked, "Account is temporarily locked after too many failed sign-ins", "account locked")
+	}
+	if u.LastFailedLoginAt == nil {
+		return nil
+	}
+	if wait := loginDelay(u.FailedLoginCount); wait > 0 && now.Before(u.LastFailedLoginAt.Add(wait)) {
+		c.recordLogin(ctx, domain.LoginAttempt{UserID: u.ID, Email: u.Email, Meta: meta, FailureReason: domain.LoginFailureThrottled})
+		retryIn := u.LastFailedLoginAt.Add(wait).Sub(now).Round(time.Second)
+		return imhttp.NewCustomErr(http.StatusTooManyRequests, fmt.Sprintf("Too many failed sign-ins, try again in %s", retryIn), "login delay not elapsed")
+	}
+	return nil
+}
+
+// loginFailed counts a wrong password or second factor against the user.
+func (c *application) loginFailed(ctx context.Context, u *ent.User, meta domain.SessionMeta, reason string) {
+	cfg := config.GetConfig().Login
+	c.recordLogin(ctx, domain.LoginAttempt{UserID: u.ID, Email: u.Email, Meta: meta, FailureReason: reason})
+
+	updated, err := c.repo.RecordFailedLogin(ctx, u.ID, cfg.MaxFailedAttempts, time.Now().Add(cfg.LockoutDuration))
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", u.ID).Msg("Failed to record failed login")
+		return
+	}
+	if updated.LockedUntil != nil && time.Now().Before(*updated.LockedUntil) {
+		logger.Get().Warn().Str("user_id", u.ID).Str("ip_address", meta.IPAddress).Time("locked_until", *updated.LockedUntil).Msg("Account locked after failed sign-ins")
+	}
+}
+
+// loginSucceeded records a completed sign-in and clears failed ones.
+func (c *application) loginSucceeded(ctx context.Context, u *ent.User, meta domain.SessionMeta) {
+	c.recordLogin(ctx, domain.LoginAttempt{UserID: u.ID, Email: u.Email, Meta: meta})
+	if err := c.repo.RecordSuccessfulLogin(ctx, u.ID); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", u.ID).Msg("Failed to record successful login")
+	}
+}
+
+// recordLogin adds to the login history. Failures are logged and do not fail the sign-in.
+func (c *application) recordLogin(ctx context.Context, attempt domain.LoginAttempt) {
+	if err := c.repo.CreateLoginAttempt(ctx, attempt); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", attempt.UserID).Msg("Failed to record login attempt")
+	}
+}
+
+// loginDelay is how long to wait after the last of a number of failed sign-ins: nothing
+// until DelayAfterFailures, then BaseDelay doubling per failure up to MaxDelay.
+func loginDelay(failures int) time.Duration {
+	cfg := config.GetConfig().Login
+	if failures < cfg.DelayAfterFailures {
+		return 0
+	}
+	delay := cfg.BaseDelay
+	for i := cfg.DelayAfterFailures; i < failures && delay < cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > cfg.MaxDelay {
+		delay = cfg.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,239 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// snapshotOfProperty captures the moderated content of a stored property.
+func snapshotOfProperty(p *ent.Property) schema.PropertySnapshot {
+	return schema.PropertySnapshot{
+		Name:             p.Name,
+		PropertyType:     p.PropertyType,
+		PropertyImages:   p.PropertyImages,
+		WebCards:         p.WebCards,
+		PricingInfo:      p.PricingInfo,
+		PropertyReraInfo: p.PropertyReraInfo,
+		MetaInfo:         p.MetaInfo,
+		ListingType:      p.ListingType.String(),
+		AvailableFrom:    p.AvailableFrom,
+	}
+}
+
+func snapshotOfDomainProperty(p domain.Property) schema.PropertySnapshot {
+	return schema.PropertySnapshot{
+		Name:             p.Name,
+		Slug:             p.Slug,
+		PropertyType:     p.PropertyType,
+		PropertyImages:   p.PropertyImages,
+		WebCards:         p.WebCards,
+		PricingInfo:      p.PricingInfo,
+		PropertyReraInfo: p.PropertyReraInfo,
+		MetaInfo:         p.MetaInfo,
+		ListingType:      p.ListingType,
+		AvailableFrom:    p.AvailableFrom,
+	}
+}
+
+func (c *application) submitPropertyRevision(ctx context.Context, propertyID, action string, snapshot schema.PropertySnapshot, submittedBy string) (*ent.PropertyRevision, *imhttp.CustomError) {
+	revision, err := c.repo.SubmitPropertyRevision(ctx, domain.PropertyRevision{
+		PropertyID:        propertyID,
+		Action:            action,
+		Snapshot:          snapshot,
+		SubmittedByUserID: submittedBy,
+	})
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to submit property for review", err.Error())
+	}
+	return revision, nil
+}
+
+// submitPropertyEdit holds a partner's edit of a live property for review. The snapshot
+// is the property as it will be once the edit is applied.
+func (c *application) submitPropertyEdit(ctx context.Context, existing *ent.Property, edit domain.Property, submittedBy string) (*response.Property, *imhttp.CustomError) {
+	snapshot := snapshotOfProperty(existing)
+	snapshot.Name = edit.Name
+	snapshot.Slug = edit.Slug
+	snapshot.PropertyImages = edit.PropertyImages
+	snapshot.WebCards, _ = domain.MergePropertyWebCards(existing.WebCards, edit.WebCards)
+	snapshot.PricingInfo = edit.PricingInfo
+	snapshot.PropertyReraInfo = edit.PropertyReraInfo
+	snapshot.MetaInfo = edit.MetaInfo
+	if edit.ListingType != "" {
+		snapshot.ListingType = edit.ListingType
+	}
+	if edit.AvailableFrom != nil {
+		snapshot.AvailableFrom = edit.AvailableFrom
+	}
+
+	revision, customErr := c.submitPropertyRevision(ctx, existing.ID, domain.RevisionActionUpdate, snapshot, submittedBy)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	result := response.GetPropertyFromEnt(existing)
+	result.PendingRevisionID = revision.ID
+	return result, nil
+}
+
+func (c *application) ListPropertyRevisions(ctx context.Context, req *request.ListPropertyRevisionsRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	req.Validate()
+	switch req.Status {
+	case "", domain.ModerationStatusPending, domain.ModerationStatusApproved, domain.ModerationStatusRejected, domain.ModerationStatusSuperseded:
+	default:
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid status", fmt.Sprintf("status %q is not supported", req.Status))
+	}
+
+	revisions, total, err := c.repo.ListPropertyRevisions(ctx, req.Status, req.PropertyID, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list property revisions", err.Error())
+	}
+
+	items := make([]*response.PropertyRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		items = append(items, response.GetPropertyRevisionFromEnt(revision))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+// GetPropertyRevision returns a revision with the changes it makes to the live property.
+func (c *application) GetPropertyRevision(ctx context.Context, id string) (*response.PropertyRevision, *imhttp.CustomError) {
+	revision, err := c.repo.GetPropertyRevisionByID(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Property revision not found", err.Error())
+	}
+
+	var before schema.PropertySnapshot
+	if revision.Action.String() == domain.RevisionActionUpdate {
+		live, err := c.repo.GetPropertyByID(revision.PropertyID)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusNotFound, "Property not found", err.Error())
+		}
+		before = snapshotOfProperty(live)
+		// Slugs are only compared when the edit asks for one
+		if revision.Snapshot.Slug != "" {
+			before.Slug = live.Slug
+		}
+	}
+
+	changes, err := domain.DiffPropertySnapshots(before, revision.Snapshot)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to compare property revision", err.Error())
+	}
+
+	result := response.GetPropertyRevisionFromEnt(revision)
+	result.Snapshot = &revision.Snapshot
+	result.Changes = response.GetFieldChanges(changes)
+	return result, nil
+}
+
+// ApprovePropertyRevision publishes a pending revision and notifies the submitter.
+func (c *application) ApprovePropertyRevision(ctx context.Context, id string, actor domain.Actor) (*response.PropertyRevision, *imhttp.CustomError) {
+	revision, customErr := c.getPendingRevision(ctx, id)
+	if customErr != nil {
+		return nil, customErr
+	}
+	before := c.propertySnapshot(revision.PropertyID)
+
+	var apply *domain.Property
+	if revision.Action.String() == domain.RevisionActionUpdate {
+		live, err := c.repo.GetPropertyByID(revision.PropertyID)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusNotFound, "Property not found", err.Error())
+		}
+		snapshot := revision.Snapshot
+		apply = &domain.Property{
+			PropertyID:       live.ID,
+			Name:             snapshot.Name,
+			Slug:             snapshot.Slug,
+			PropertyImages:   snapshot.PropertyImages,
+			WebCards:         snapshot.WebCards,
+			PricingInfo:      snapshot.PricingInfo,
+			PropertyReraInfo: snapshot.PropertyReraInfo,
+			MetaInfo:         snapshot.MetaInfo,
+			ListingType:      snapshot.ListingType,
+			AvailableFrom:    snapshot.AvailableFrom,
+			DeveloperID:      live.DeveloperID,
+			LocationID:       live.LocationID,
+			ProjectID:        live.ProjectID,
+		}
+	}
+
+	reviewed, err := c.repo.ReviewPropertyRevision(ctx, revision, domain.ModerationStatusApproved, actor.UserID, "", apply)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("revision_id", id).Msg("Failed to approve property revision")
+		return nil, slugError(err, "Failed to approve property revision")
+	}
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionApprove,
+		EntityType: domain.AuditEntityProperty,
+		EntityID:   reviewed.PropertyID,
+		Before:     before,
+		After:      c.propertySnapshot(reviewed.PropertyID),
+	})
+
+	c.notify(ctx, domain.Notification{
+		UserID:     reviewed.SubmittedByUserID,
+		Kind:       domain.NotificationPropertyApproved,
+		Title:      fmt.Sprintf("%s is approved", reviewed.Snapshot.Name),
+		Message:    "Your changes are now live.",
+		EntityType: domain.SlugEntityProperty,
+		EntityID:   reviewed.PropertyID,
+	})
+	return response.GetPropertyRevisionFromEnt(reviewed), nil
+}
+
+// RejectPropertyRevision rejects a pending revision with a reason for the submitter.
+func (c *application) RejectPropertyRevision(ctx context.Context, id string, actor domain.Actor, req *request.ReviewPropertyRevisionRequest) (*response.PropertyRevision, *imhttp.CustomError) {
+	if req.Reason == "" {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Reason is required", "A rejection needs a reason for the submitter")
+	}
+	revision, customErr := c.getPendingRevision(ctx, id)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	reviewed, err := c.repo.ReviewPropertyRevision(ctx, revision, domain.ModerationStatusRejected, actor.UserID, req.Reason, nil)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to reject property revision", err.Error())
+	}
+	// The live property is unchanged, so the rejected revision is what gets recorded
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionReject,
+		EntityType: domain.AuditEntityProperty,
+		EntityID:   reviewed.PropertyID,
+		After:      response.GetPropertyRevisionFromEnt(reviewed),
+	})
+
+	c.notify(ctx, domain.Notification{
+		UserID:     reviewed.SubmittedByUserID,
+		Kind:       domain.NotificationPropertyRejected,
+		Title:      fmt.Sprintf("%s was not approved", reviewed.Snapshot.Name),
+		Message:    req.Reason,
+		EntityType: domain.SlugEntityProperty,
+		EntityID:   reviewed.PropertyID,
+	})
+	return response.GetPropertyRevisionFromEnt(reviewed), nil
+}
+
+func (c *application) getPendingRevision(ctx context.Context, id string) (*ent.PropertyRevision, *imhttp.CustomError) {
+	revision, err := c.repo.GetPropertyRevisionByID(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Property revision not found", err.Error())
+	}
+	if revision.Status.String() != domain.ModerationStatusPending {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Property revision is not pending", fmt.Sprintf("revision is %s", revision.Status))
+	}
+	return revision, nil
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// notify records a notification. Failures are logged and do not fail the caller.
+func (c *application) notify(ctx context.Context, n domain.Notification) {
+	if n.UserID == "" {
+		return
+	}
+	if _, err := c.repo.CreateNotification(ctx, n); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", n.UserID).Str("kind", n.Kind).Msg("Failed to notify user")
+	}
+}
+
+func (c *application) ListNotifications(ctx context.Context, userID string, req *request.ListNotificationsRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	req.Validate()
+	notifications, total, err := c.repo.ListNotifications(ctx, userID, req.UnreadOnly, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list notifications", err.Error())
+	}
+
+	items := make([]*response.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		items = append(items, response.GetNotificationFromEnt(n))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+func (c *application) MarkNotificationRead(ctx context.Context, id, userID string) *imhttp.CustomError {
+	if err := c.repo.MarkNotificationRead(ctx, id, userID); err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Failed to mark notification read", err.Error())
+	}
+	return nil
+}
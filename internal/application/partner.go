@@ -0,0 +1,262 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// partnerAccountID returns the business partner whose properties and leads a portal user
+// works on: the partner itself, or the partner that invited a partner_member.
+func (c *application) partnerAccountID(ctx context.Context, userID string) (string, *imhttp.CustomError) {
+	u, err := c.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	if u.Role.String() != domain.RolePartnerMember {
+		return u.ID, nil
+	}
+	if u.Edges.CreatedByUser == nil {
+		return "", imhttp.NewCustomErr(http.StatusForbidden, "Team member has no partner account", "Team member has no partner account")
+	}
+	return u.Edges.CreatedByUser.ID, nil
+}
+
+func (c *application) GetPartnerProfile(ctx context.Context, userID string) (*response.UserProfile, *imhttp.CustomError) {
+	u, err := c.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	return response.GetUserProfileFromEnt(u), nil
+}
+
+func (c *application) UpdatePartnerProfile(ctx context.Context, req *request.UpdateProfileRequest, actor domain.Actor) (*response.UserProfile, *imhttp.CustomError) {
+	profile := domain.UserProfile{
+		Name:             strings.TrimSpace(req.Name),
+		PhoneNumber:      strings.TrimSpace(req.PhoneNumber),
+		Gender:           strings.TrimSpace(req.Gender),
+		CurrentAddress:   strings.TrimSpace(req.CurrentAddress),
+		PermanentAddress: strings.TrimSpace(req.PermanentAddress),
+	}
+	if req.DateOfBirth != "" {
+		dob, err := time.Parse(time.DateOnly, req.DateOfBirth)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid date_of_birth, expected YYYY-MM-DD", err.Error())
+		}
+		profile.DateOfBirth = &dob
+	}
+
+	before, err := c.repo.GetUserByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	u, err := c.repo.UpdateUserProfile(ctx, actor.UserID, profile)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update profile", err.Error())
+	}
+
+	updated := response.GetUserProfileFromEnt(u)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   actor.UserID,
+		Before:     response.GetUserProfileFromEnt(before),
+		After:      updated,
+	})
+	return updated, nil
+}
+
+func (c *application) GetListingPerformance(ctx context.Context, userID string, req *request.PartnerPerformanceRequest) (*response.ListingPerformance, *imhttp.CustomError) {
+	ownerID, cerr := c.partnerAccountID(ctx, userID)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	filters := map[string]interface{}{
+		"start_date": req.StartDate,
+		"end_date":   req.EndDate,
+	}
+	stats, err := c.repo.GetPartnerListingStats(ctx, ownerID, filters)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get listing performance", err.Error())
+	}
+	return response.GetListingPerformance(stats), nil
+}
+
+func (c *application) ListPartnerLeads(ctx context.Context, userID string, req *request.ListPartnerLeadsRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	req.Validate()
+	ownerID, cerr := c.partnerAccountID(ctx, userID)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	filters := map[string]interface{}{
+		"property_id": req.PropertyID,
+		"start_date":  req.StartDate,
+		"end_date":    req.EndDate,
+	}
+	leadsData, total, err := c.repo.GetPartnerLeads(ctx, ownerID, filters, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get leads", err.Error())
+	}
+
+	items := make([]*response.Lead, 0, len(leadsData))
+	for _, lead := range leadsData {
+		items = append(items, response.ToLeadResponse(lead))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+func (c *application) ListTeamMembers(ctx context.Context, ownerID string) ([]*response.TeamMember, *imhttp.CustomError) {
+	members, err := c.repo.ListTeamMembers(ctx, ownerID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list team members", err.Error())
+	}
+
+	result := make([]*response.TeamMember, 0, len(members))
+	for _, m := range members {
+		result = append(result, response.GetTeamMemberFromEnt(m))
+	}
+	return result, nil
+}
+
+func (c *application) InviteTeamMember(ctx context.Context, req *request.InviteTeamMemberRequest, actor domain.Actor) (*response.Invitation, *imhttp.CustomError) {
+	exist, err := c.repo.CheckIfUserExistsByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check email", err.Error())
+	}
+	if exist {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Email already exists", "Email already exists")
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate invitation token", err.Error())
+	}
+	// The member cannot sign in until they choose a password, so the stored hash is of a
+	// value nobody knows.
+	placeholder, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate invitation token", err.Error())
+	}
+	placeholderHash, err := utils.HashPassword(placeholder)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to hash password", err.Error())
+	}
+
+	cfg := config.GetConfig()
+	expiresAt := time.Now().Add(cfg.Partner.InvitationTTL)
+	member, err := c.repo.CreatePartnerInvitation(ctx, domain.PartnerInvitation{
+		Name:            req.Name,
+		Username:        req.Username,
+		Email:           req.Email,
+		PhoneNumber:     req.PhoneNumber,
+		InvitedByUserID: actor.UserID,
+		TokenHash:       utils.HashToken(token),
+		ExpiresAt:       expiresAt,
+	}, placeholderHash)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, imhttp.NewCustomErr(http.StatusConflict, "Username or email already exists", err.Error())
+		}
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to invite team member", err.Error())
+	}
+
+	// The token is only ever sent to the invitee, so the partner cannot sign in as them
+	link := fmt.Sprintf("%s%s?token=%s", cfg.Server.BaseURL, cfg.Partner.InvitationPath, url.QueryEscape(token))
+	body := fmt.Sprintf("You have been invited to join a partner team. Open this link to choose a password:\n\n%s\n\nThe link is valid for %s.",
+		link, cfg.Partner.InvitationTTL)
+	if err := c.mailer.Send(member.Email, "You have been invited", body); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", member.ID).Msg("Failed to send invitation email")
+		return nil, imhttp.NewCustomErr(http.StatusBadGateway, "Failed to send invitation email", err.Error())
+	}
+
+	invited := response.GetTeamMemberFromEnt(member)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionInvite,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   member.ID,
+		After:      invited,
+	})
+	return &response.Invitation{
+		Member:    invited,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (c *application) RemoveTeamMember(ctx context.Context, memberID string, actor domain.Actor) *imhttp.CustomError {
+	before := c.teamMemberSnapshot(ctx, memberID)
+	if err := c.repo.DeactivateTeamMember(ctx, actor.UserID, memberID); err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Failed to remove team member", err.Error())
+	}
+	c.access.forget(memberID)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionDeactivate,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   memberID,
+		Before:     before,
+		After:      c.teamMemberSnapshot(ctx, memberID),
+	})
+	return nil
+}
+
+// teamMemberSnapshot is a team member as recorded in the audit log, or nil when it cannot
+// be read.
+func (c *application) teamMemberSnapshot(ctx context.Context, id string) interface{} {
+	u, err := c.repo.GetUserByID(ctx, id)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", id).Msg("Failed to snapshot team member for audit")
+		return nil
+	}
+	return response.GetTeamMemberFromEnt(u)
+}
+
+func (c *application) AcceptInvitation(ctx context.Context, req *request.AcceptInvitationRequest, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError) {
+	invitation, err := c.repo.GetInvitationByTokenHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get invitation", err.Error())
+	}
+	if invitation == nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invitation is invalid or has expired", "Invitation is invalid or has expired")
+	}
+
+	if cerr := validatePassword(req.Password); cerr != nil {
+		return nil, cerr
+	}
+
+	hashedPassword, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to hash password", err.Error())
+	}
+
+	before := c.teamMemberSnapshot(ctx, invitation.UserID)
+	member, err := c.repo.AcceptInvitation(ctx, invitation, hashedPassword)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Failed to accept invitation", err.Error())
+	}
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      domain.Actor{UserID: member.ID, Role: member.Role.String(), IPAddress: meta.IPAddress},
+		Action:     domain.AuditActionAcceptInvitation,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   member.ID,
+		Before:     before,
+		After:      response.GetTeamMemberFromEnt(member),
+	})
+
+	return c.startSession(ctx, member, meta)
+}
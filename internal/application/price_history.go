@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+func (c *application) GetProjectPriceHistory(ctx context.Context, projectID string) (*response.ProjectPriceHistory, *imhttp.CustomError) {
+	project, err := c.repo.GetProjectByID(projectID)
+	if err != nil || project.IsDeleted {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Project not found", "Project not found")
+	}
+
+	points, err := c.repo.GetProjectPriceHistory(ctx, projectID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get price history", err.Error())
+	}
+
+	var overall []domain.PricePoint
+	for _, point := range points {
+		if point.Configuration == domain.PriceConfigurationOverall {
+			overall = append(overall, domain.PricePoint{MinPrice: point.MinPrice, MaxPrice: point.MaxPrice, RecordedAt: point.RecordedAt})
+		}
+	}
+
+	return &response.ProjectPriceHistory{
+		ProjectID: project.ID,
+		MinPrice:  project.MinPrice,
+		MaxPrice:  project.MaxPrice,
+		Change:    response.GetPriceChange(overall, time.Now()),
+		Series:    response.GetPriceSeriesFromEnt(points),
+	}, nil
+}
+
+// attachPriceChanges fills in the price change shown on listing cards. A failure only
+// drops the badge, it does not fail the listing.
+func (c *application) attachPriceChanges(ctx context.Context, cards []*response.ProjectListResponse) {
+	ids := make([]string, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ProjectID)
+	}
+
+	series, err := c.repo.GetOverallPriceSeries(ctx, ids)
+	if err != nil {
+		logger.Get().Warn().Err(err).Msg("Failed to get price changes for listing")
+		return
+	}
+	now := time.Now()
+	for _, card := range cards {
+		card.PriceChange = response.GetPriceChange(series[card.ProjectID], now)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/config"
 	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
@@ -35,7 +36,7 @@ func (c *application) GetProjectByID(id string) (*response.Project, *imhttp.Cust
 	return response.GetProjectFromEnt(project), nil
 }
 
-func (c *application) AddProject(input request.AddProjectRequest) (*response.AddProjectResponse, *imhttp.CustomError) {
+func (c *application) AddProject(ctx context.Context, input request.AddProjectRequest, actor domain.Actor) (*response.AddProjectResponse, *imhttp.CustomError) {
 
 	var project domain.Project
 
@@ -47,6 +48,9 @@ func (c *application) AddProject(input request.AddProjectRequest) (*response.Add
 	if !exist {
 		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Developer not found", "Developer not found")
 	}
+	if customErr := c.ensureSlugAvailable(ctx, input.Slug); customErr != nil {
+		return nil, customErr
+	}
 
 	project.ProjectID = fmt.Sprintf("%x", sha256.Sum256([]byte(strconv.FormatInt(time.Now().Unix(), 10))))[:16]
 	project.ProjectName = input.ProjectName
@@ -59,15 +63,23 @@ func (c *application) AddProject(input request.AddProjectRequest) (*response.Add
 	projectID, err := c.repo.AddProject(project)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to add project")
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add project", err.Error())
+		return nil, slugError(err, "Failed to add project")
 	}
+	c.similar.invalidate()
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityProject,
+		EntityID:   projectID,
+		After:      c.projectSnapshot(projectID),
+	})
 
 	return &response.AddProjectResponse{
 		ProjectID: projectID,
 	}, nil
 }
 
-func (c *application) UpdateProject(input request.UpdateProjectRequest) (*response.Project, *imhttp.CustomError) {
+func (c *application) UpdateProject(ctx context.Context, input request.UpdateProjectRequest, actor domain.Actor) (*response.Project, *imhttp.CustomError) {
 
 	var project domain.Project
 	isDeleted, err := c.repo.IsProjectDeleted(input.ProjectID)
@@ -78,9 +90,11 @@ func (c *application) UpdateProject(input request.UpdateProjectRequest) (*respon
 	if isDeleted {
 		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Project not found or deleted", "Project not found or deleted")
 	}
+	before := c.projectSnapshot(input.ProjectID)
 
 	project.ProjectID = input.ProjectID
 	project.ProjectName = input.ProjectName
+	project.Slug = input.Slug
 	project.Status = input.Status
 	project.MinPrice = input.MinPrice
 	project.MaxPrice = input.MaxPrice
@@ -98,29 +112,80 @@ func (c *application) UpdateProject(input request.UpdateProjectRequest) (*respon
 	updatedProject, err := c.repo.UpdateProject(project)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to update project")
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update project", err.Error())
+		return nil, slugError(err, "Failed to update project")
 	}
-
-	return response.GetProjectFromEnt(updatedProject), nil
+	c.similar.invalidate()
+
+	result := response.GetProjectFromEnt(updatedProject)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: domain.AuditEntityProject,
+		EntityID:   updatedProject.ID,
+		Before:     before,
+		After:      result,
+	})
+	return result, nil
 }
 
-func (c *application) DeleteProject(id string) *imhttp.CustomError {
-
+// DeleteProject soft-deletes a project. A project with live properties is only deleted
+// when the request asks to cascade, in which case the properties are deleted with it.
+func (c *application) DeleteProject(ctx context.Context, id string, req *request.DeleteRequest, actor domain.Actor) (*response.DeletionResult, *imhttp.CustomError) {
 	isDeleted, err := c.repo.IsProjectDeleted(id)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to check if project is deleted")
-		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check if project is deleted", err.Error())
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check if project is deleted", err.Error())
 	}
 	if isDeleted {
-		return imhttp.NewCustomErr(http.StatusBadRequest, "Project is already deleted", "Project is already deleted")
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Project is already deleted", "Project is already deleted")
 	}
 
-	if err := c.repo.DeleteProject(id, false); err != nil {
-		logger.Get().Error().Err(err).Msg("Failed to delete project")
-		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete project", err.Error())
+	report, err := c.repo.GetProjectDeletionReport(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Failed to get project dependents", err.Error())
+	}
+	if len(report.Properties) > 0 && !req.Cascade {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Project has live properties",
+			fmt.Sprintf("project has %d live properties; review the deletion plan and retry with cascade", len(report.Properties)))
 	}
 
-	return nil
+	redirectTo, customErr := normalizeRedirectTarget(req.RedirectTo)
+	if customErr != nil {
+		return nil, customErr
+	}
+	if redirectTo == "" {
+		redirectTo = "/"
+	}
+
+	before := c.projectSnapshot(id)
+	record := domain.DeletionRecord{
+		EntityType:   domain.DeletionEntityProject,
+		EntityID:     id,
+		Slug:         report.Slug,
+		RedirectTo:   redirectTo,
+		DeletedBy:    actor.UserID,
+		RestoreUntil: time.Now().Add(config.GetConfig().Deletion.RestoreWindow),
+	}
+	for _, p := range report.Properties {
+		record.CascadedPropertyIDs = append(record.CascadedPropertyIDs, p.ID)
+	}
+
+	deletion, err := c.repo.SoftDeleteWithRecord(ctx, record, report)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to delete project")
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete project", err.Error())
+	}
+	c.similar.invalidate()
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionDelete,
+		EntityType: domain.AuditEntityProject,
+		EntityID:   id,
+		Before:     before,
+		After:      c.projectSnapshot(id),
+	})
+
+	return response.GetDeletionResultFromEnt(deletion), nil
 }
 
 func (c *application) ListProjects(request *request.GetAllAPIRequest) ([]*response.ProjectListResponse, *imhttp.CustomError) {
@@ -159,6 +224,7 @@ func (c *application) ListProjects(request *request.GetAllAPIRequest) ([]*respon
 			projectResponses = append(projectResponses, response.GetProjectListResponse(project))
 		}
 	}
+	c.attachPriceChanges(context.Background(), projectResponses)
 
 	return projectResponses, nil
 }
@@ -200,6 +266,7 @@ func (c *application) CompareProjects(projectIDs []string) (*response.ProjectCom
 			IsPriority:    project.IsPriority,
 			WebCards:      project.WebCards,
 			DeveloperName: developerName,
+			ReraApproved:  response.IsProjectReraApproved(project),
 		}
 
 		comparisonProjects = append(comparisonProjects, comparisonProject)
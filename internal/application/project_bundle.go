@@ -0,0 +1,361 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	projectEnt "github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/domain/enums"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+var bundleSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ImportProjects validates a project bundle and, unless it is a dry run, upserts it.
+// Nothing is written when any row fails validation.
+func (c *application) ImportProjects(ctx context.Context, req *request.ImportProjectsRequest, actor domain.Actor) (*response.ProjectImportReport, *imhttp.CustomError) {
+	var bundle *domain.ProjectBundle
+	var rowErrors []domain.BundleRowError
+
+	switch req.Format {
+	case request.ProjectBundleFormatCSV:
+		var err error
+		bundle, rowErrors, err = utils.DecodeProjectBundleCSV(req.Data)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid CSV bundle", err.Error())
+		}
+	case request.ProjectBundleFormatJSON:
+		bundle = &domain.ProjectBundle{}
+		if err := json.Unmarshal(req.Data, bundle); err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid JSON bundle", err.Error())
+		}
+		for i := range bundle.Developers {
+			bundle.Developers[i].Source = fmt.Sprintf("developers[%d]", i)
+		}
+		for i := range bundle.Projects {
+			bundle.Projects[i].Source = fmt.Sprintf("projects[%d]", i)
+		}
+	default:
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Unsupported format", fmt.Sprintf("format must be %s or %s", request.ProjectBundleFormatJSON, request.ProjectBundleFormatCSV))
+	}
+
+	if len(bundle.Projects) == 0 && len(bundle.Developers) == 0 {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Bundle is empty", "Bundle has no developers or projects")
+	}
+
+	validationErrors, err := c.validateProjectBundle(ctx, bundle)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to validate bundle", err.Error())
+	}
+	rowErrors = append(rowErrors, validationErrors...)
+
+	report := &response.ProjectImportReport{
+		DryRun:  req.DryRun,
+		Valid:   len(rowErrors) == 0,
+		Errors:  rowErrors,
+		Results: []domain.BundleRowResult{},
+	}
+	if report.Errors == nil {
+		report.Errors = []domain.BundleRowError{}
+	}
+	if !report.Valid {
+		return report, nil
+	}
+
+	if req.DryRun {
+		results, err := c.planProjectBundle(ctx, bundle)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to plan import", err.Error())
+		}
+		report.Results = results
+	} else {
+		results, err := c.repo.ImportProjectBundle(ctx, *bundle)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to import projects")
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to import projects", err.Error())
+		}
+		report.Results = results
+		c.similar.invalidate()
+		c.auditProjectImport(ctx, bundle, results, actor)
+	}
+
+	for _, result := range report.Results {
+		switch result.Action {
+		case domain.BundleActionCreate:
+			report.Created++
+		case domain.BundleActionUpdate:
+			report.Updated++
+		}
+	}
+
+	return report, nil
+}
+
+// auditProjectImport records every imported project. The snapshot is the imported row,
+// since an import overwrites the fields it carries.
+func (c *application) auditProjectImport(ctx context.Context, bundle *domain.ProjectBundle, results []domain.BundleRowResult, actor domain.Actor) {
+	projects := make(map[string]domain.BundleProject, len(bundle.Projects))
+	for _, p := range bundle.Projects {
+		projects[p.Source] = p
+	}
+	for _, result := range results {
+		project, ok := projects[result.Source]
+		if !ok {
+			continue
+		}
+		c.audit(ctx, domain.AuditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionImport,
+			EntityType: domain.AuditEntityProject,
+			EntityID:   result.ID,
+			After:      project,
+		})
+	}
+}
+
+// ExportProjects writes projects in the same bundle format that ImportProjects reads.
+func (c *application) ExportProjects(ctx context.Context, req *request.ExportProjectsRequest) (*response.ProjectExport, *imhttp.CustomError) {
+	projects, err := c.repo.GetProjectsForExport(ctx, req.Slugs, req.DeveloperID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get projects", err.Error())
+	}
+
+	bundle := buildProjectBundle(projects)
+	fileName := fmt.Sprintf("projects-%s", time.Now().Format("20060102-150405"))
+
+	switch req.Format {
+	case request.ProjectBundleFormatCSV:
+		data, err := utils.EncodeProjectBundleCSV(bundle)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to export projects", err.Error())
+		}
+		return &response.ProjectExport{FileName: fileName + ".zip", ContentType: "application/zip", Data: data}, nil
+	case request.ProjectBundleFormatJSON, "":
+		data, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to export projects", err.Error())
+		}
+		return &response.ProjectExport{FileName: fileName + ".json", ContentType: "application/json", Data: data}, nil
+	default:
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Unsupported format", fmt.Sprintf("format must be %s or %s", request.ProjectBundleFormatJSON, request.ProjectBundleFormatCSV))
+	}
+}
+
+// validateProjectBundle normalizes the bundle in place and returns one error per invalid field.
+func (c *application) validateProjectBundle(ctx context.Context, bundle *domain.ProjectBundle) ([]domain.BundleRowError, error) {
+	var rowErrors []domain.BundleRowError
+	addError := func(source, key, field, message string) {
+		rowErrors = append(rowErrors, domain.BundleRowError{Source: source, Key: key, Field: field, Message: message})
+	}
+
+	developerKeys := make(map[string]string)
+	for i := range bundle.Developers {
+		d := &bundle.Developers[i]
+		d.Name = strings.TrimSpace(d.Name)
+		d.Identifier = strings.TrimSpace(d.Identifier)
+
+		if d.Name == "" {
+			addError(d.Source, d.Key(), "name", "is required")
+		}
+		if d.EstablishedYear < 0 || d.EstablishedYear > time.Now().Year() {
+			addError(d.Source, d.Key(), "established_year", "is not a valid year")
+		}
+		if d.Key() == "" {
+			continue
+		}
+		if previous, ok := developerKeys[strings.ToLower(d.Key())]; ok {
+			addError(d.Source, d.Key(), "identifier", "duplicates "+previous)
+			continue
+		}
+		developerKeys[strings.ToLower(d.Key())] = d.Source
+	}
+
+	slugs := make(map[string]string)
+	resolved := make(map[string]bool)
+	for i := range bundle.Projects {
+		p := &bundle.Projects[i]
+		p.Slug = strings.TrimSpace(p.Slug)
+		p.Name = strings.TrimSpace(p.Name)
+		p.Developer = strings.TrimSpace(p.Developer)
+		p.ProjectType = strings.ToUpper(strings.TrimSpace(p.ProjectType))
+		if p.Status == "" {
+			p.Status = enums.ProjectStatusNEWLAUNCH
+		}
+
+		switch {
+		case p.Slug == "":
+			addError(p.Source, p.Slug, "slug", "is required")
+		case !bundleSlugPattern.MatchString(p.Slug):
+			addError(p.Source, p.Slug, "slug", "must contain only lowercase letters, digits and hyphens")
+		default:
+			if previous, ok := slugs[p.Slug]; ok {
+				addError(p.Source, p.Slug, "slug", "duplicates "+previous)
+			}
+			slugs[p.Slug] = p.Source
+
+			// Existing projects are matched by slug, so only slugs held elsewhere conflict.
+			owner, err := c.repo.GetSlugOwner(ctx, p.Slug)
+			if err != nil {
+				return nil, err
+			}
+			if owner != nil && (string(owner.EntityType) != domain.SlugEntityProject || !owner.IsCurrent) {
+				addError(p.Source, p.Slug, "slug", (&domain.SlugTakenError{
+					Slug:       p.Slug,
+					EntityType: string(owner.EntityType),
+					EntityID:   owner.EntityID,
+					Retired:    !owner.IsCurrent,
+				}).Error())
+			}
+		}
+
+		if p.Name == "" {
+			addError(p.Source, p.Slug, "name", "is required")
+		}
+		if err := projectEnt.ProjectTypeValidator(projectEnt.ProjectType(p.ProjectType)); err != nil {
+			addError(p.Source, p.Slug, "project_type", "must be RESIDENTIAL or COMMERCIAL")
+		}
+		if !p.Status.IsValid() {
+			addError(p.Source, p.Slug, "status", fmt.Sprintf("%s is not a valid project status", p.Status))
+		}
+		if p.Location.City == "" {
+			addError(p.Source, p.Slug, "city", "is required")
+		}
+		if p.Location.LocalityName == "" {
+			addError(p.Source, p.Slug, "locality", "is required")
+		}
+
+		if p.Developer == "" {
+			addError(p.Source, p.Slug, "developer", "is required")
+			continue
+		}
+		developerKey := strings.ToLower(p.Developer)
+		if _, ok := developerKeys[developerKey]; ok {
+			continue
+		}
+		found, checked := resolved[developerKey]
+		if !checked {
+			developer, err := c.repo.FindDeveloperByKey(ctx, p.Developer)
+			if err != nil {
+				return nil, err
+			}
+			found = developer != nil
+			resolved[developerKey] = found
+		}
+		if !found {
+			addError(p.Source, p.Slug, "developer", fmt.Sprintf("developer %q is not in the bundle or the database", p.Developer))
+		}
+	}
+
+	return rowErrors, nil
+}
+
+// planProjectBundle reports what ImportProjectBundle would do without writing anything.
+func (c *application) planProjectBundle(ctx context.Context, bundle *domain.ProjectBundle) ([]domain.BundleRowResult, error) {
+	results := make([]domain.BundleRowResult, 0, len(bundle.Developers)+len(bundle.Projects))
+
+	for _, d := range bundle.Developers {
+		developer, err := c.repo.FindDeveloperByKey(ctx, d.Key())
+		if err != nil {
+			return nil, err
+		}
+		result := domain.BundleRowResult{Source: d.Source, Key: d.Key(), Action: domain.BundleActionCreate}
+		if developer != nil {
+			result.ID = developer.ID
+			result.Action = domain.BundleActionUpdate
+		}
+		results = append(results, result)
+	}
+
+	slugs := make([]string, 0, len(bundle.Projects))
+	for _, p := range bundle.Projects {
+		slugs = append(slugs, p.Slug)
+	}
+	existing, err := c.repo.GetProjectsBySlugs(ctx, slugs)
+	if err != nil {
+		return nil, err
+	}
+	existingIDs := make(map[string]string, len(existing))
+	for _, project := range existing {
+		existingIDs[project.Slug] = project.ID
+	}
+
+	for _, p := range bundle.Projects {
+		result := domain.BundleRowResult{Source: p.Source, Key: p.Slug, Action: domain.BundleActionCreate}
+		if id, ok := existingIDs[p.Slug]; ok {
+			result.ID = id
+			result.Action = domain.BundleActionUpdate
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func buildProjectBundle(projects []*ent.Project) *domain.ProjectBundle {
+	bundle := &domain.ProjectBundle{
+		Developers: []domain.BundleDeveloper{},
+		Projects:   make([]domain.BundleProject, 0, len(projects)),
+	}
+
+	seenDevelopers := make(map[string]string)
+	for _, project := range projects {
+		item := domain.BundleProject{
+			Slug:         project.Slug,
+			Name:         project.Name,
+			ProjectType:  string(project.ProjectType),
+			Status:       project.Status,
+			Description:  project.Description,
+			MinPrice:     project.MinPrice,
+			MaxPrice:     project.MaxPrice,
+			TimelineInfo: project.TimelineInfo,
+			MetaInfo:     project.MetaInfo,
+			WebCards:     project.WebCards,
+			LocationInfo: project.LocationInfo,
+			IsFeatured:   project.IsFeatured,
+			IsPremium:    project.IsPremium,
+			IsPriority:   project.IsPriority,
+		}
+
+		if developer := project.Edges.Developer; developer != nil {
+			key, ok := seenDevelopers[developer.ID]
+			if !ok {
+				entry := domain.BundleDeveloper{
+					Name:            developer.Name,
+					LegalName:       developer.LegalName,
+					Identifier:      developer.Identifier,
+					EstablishedYear: developer.EstablishedYear,
+					MediaContent:    developer.MediaContent,
+				}
+				key = entry.Key()
+				seenDevelopers[developer.ID] = key
+				bundle.Developers = append(bundle.Developers, entry)
+			}
+			item.Developer = key
+		}
+
+		if location := project.Edges.Location; location != nil {
+			item.Location = domain.BundleLocation{
+				LocalityName: location.LocalityName,
+				City:         location.City,
+				State:        location.State,
+				Pincode:      location.Pincode,
+				PhoneNumber:  location.PhoneNumber,
+			}
+		}
+
+		bundle.Projects = append(bundle.Projects, item)
+	}
+
+	return bundle
+}
@@ -2,10 +2,14 @@ package application
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/VI-IM/im_backend_go/ent"
+	propertyEnt "github.com/VI-IM/im_backend_go/ent/property"
 	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/config"
 	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
 	"github.com/VI-IM/im_backend_go/response"
@@ -13,6 +17,9 @@ import (
 	"github.com/VI-IM/im_backend_go/shared/logger"
 )
 
+// listingDateLayout is the format of property availability dates.
+const listingDateLayout = "2006-01-02"
+
 func (c *application) GetPropertyByID(id string) (*response.Property, *imhttp.CustomError) {
 	property, err := c.repo.GetPropertyByID(id)
 	if err != nil {
@@ -23,7 +30,20 @@ func (c *application) GetPropertyByID(id string) (*response.Property, *imhttp.Cu
 	return response.GetPropertyFromEnt(property), nil
 }
 
-func (c *application) UpdateProperty(input request.UpdatePropertyRequest) (*response.Property, *imhttp.CustomError) {
+// GetPublishedPropertyByID only returns the property once moderation has approved it.
+func (c *application) GetPublishedPropertyByID(ctx context.Context, id string) (*response.Property, *imhttp.CustomError) {
+	property, err := c.repo.GetPublishedPropertyByID(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get property", err.Error())
+	}
+	if property == nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Property not found", "Property not found")
+	}
+
+	return response.GetPropertyFromEnt(property), nil
+}
+
+func (c *application) UpdateProperty(ctx context.Context, input request.UpdatePropertyRequest, actor domain.Actor) (*response.Property, *imhttp.CustomError) {
 	existingProperty, err := c.repo.GetPropertyByID(input.PropertyID)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to get property")
@@ -68,10 +88,16 @@ func (c *application) UpdateProperty(input request.UpdatePropertyRequest) (*resp
 	if len(input.PropertyImages) > 0 {
 		property.PropertyImages = input.PropertyImages
 	}
-	// Only update PricingInfo if it contains actual data (non-empty Price field)
-	if input.PricingInfo.Price != "" {
+	// Only update PricingInfo if it contains actual data
+	if input.PricingInfo != (schema.PropertyPricingInfo{}) {
 		property.PricingInfo = input.PricingInfo
 	}
+	listingType, availableFrom, customErr := parseListingFields(input.ListingType, input.AvailableFrom)
+	if customErr != nil {
+		return nil, customErr
+	}
+	property.ListingType = listingType
+	property.AvailableFrom = availableFrom
 	// Only update WebCards if it's not empty (check if any field is provided)
 	if !isWebCardsEmpty(input.WebCards) {
 		property.WebCards = input.WebCards
@@ -104,13 +130,54 @@ func (c *application) UpdateProperty(input request.UpdatePropertyRequest) (*resp
 		property.Slug = input.Slug
 	}
 
+	if input.Moderated {
+		if existingProperty.ModerationStatus == propertyEnt.ModerationStatusApproved {
+			// The approved version stays live until the edit is reviewed
+			return c.submitPropertyEdit(ctx, existingProperty, property, input.SubmittedByUserID)
+		}
+		// Never approved, so the property is not public: edit it in place and resubmit
+		property.ModerationStatus = domain.ModerationStatusPending
+	}
+
 	updatedProperty, err := c.repo.UpdateProperty(property)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to update property")
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update property", err.Error())
+		return nil, slugError(err, "Failed to update property")
+	}
+
+	result := response.GetPropertyFromEnt(updatedProperty)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: domain.AuditEntityProperty,
+		EntityID:   updatedProperty.ID,
+		Before:     response.GetPropertyFromEnt(existingProperty),
+		After:      result,
+	})
+	if input.Moderated {
+		revision, customErr := c.submitPropertyRevision(ctx, updatedProperty.ID, domain.RevisionActionCreate, snapshotOfProperty(updatedProperty), input.SubmittedByUserID)
+		if customErr != nil {
+			return nil, customErr
+		}
+		result.PendingRevisionID = revision.ID
 	}
+	return result, nil
+}
 
-	return response.GetPropertyFromEnt(updatedProperty), nil
+// parseListingFields validates the listing type and parses the availability date of a
+// property request. Empty values are left unset.
+func parseListingFields(listingType, availableFrom string) (string, *time.Time, *imhttp.CustomError) {
+	if listingType != "" && !domain.IsValidListingType(listingType) {
+		return "", nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid listing type", fmt.Sprintf("listing_type %q is not supported", listingType))
+	}
+	if availableFrom == "" {
+		return listingType, nil, nil
+	}
+	date, err := time.Parse(listingDateLayout, availableFrom)
+	if err != nil {
+		return "", nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid available_from date", err.Error())
+	}
+	return listingType, &date, nil
 }
 
 // Helper function to check if WebCards contains any meaningful data
@@ -235,7 +302,7 @@ func (c *application) GetPropertiesOfProject(projectID string) ([]*response.Prop
 	return propertyResponses, nil
 }
 
-func (c *application) AddProperty(input request.AddPropertyRequest) (*response.AddPropertyResponse, *imhttp.CustomError) {
+func (c *application) AddProperty(ctx context.Context, input request.AddPropertyRequest, actor domain.Actor) (*response.AddPropertyResponse, *imhttp.CustomError) {
 	var property domain.Property
 
 	// Set basic property fields from request
@@ -243,6 +310,12 @@ func (c *application) AddProperty(input request.AddPropertyRequest) (*response.A
 	property.Name = input.Name
 	property.PropertyType = input.PropertyType
 	property.CreatedByUserID = input.CreatedByUserID
+	listingType, availableFrom, customErr := parseListingFields(input.ListingType, input.AvailableFrom)
+	if customErr != nil {
+		return nil, customErr
+	}
+	property.ListingType = listingType
+	property.AvailableFrom = availableFrom
 
 	// Fetch project data to prefill property information
 	if input.ProjectID != "" {
@@ -260,22 +333,60 @@ func (c *application) AddProperty(input request.AddPropertyRequest) (*response.A
 		prefillPropertyFromProject(project, &property)
 	}
 
+	property.ModerationStatus = domain.ModerationStatusApproved
+	if input.Moderated {
+		property.ModerationStatus = domain.ModerationStatusPending
+	}
+
 	result, err := c.repo.AddProperty(property)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to add property")
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add property", err.Error())
 	}
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityProperty,
+		EntityID:   result.PropertyID,
+		After:      c.propertySnapshot(result.PropertyID),
+	})
+	if input.Moderated && input.CreatedByUserID != nil {
+		property.Slug = result.Slug
+		if _, customErr := c.submitPropertyRevision(ctx, result.PropertyID, domain.RevisionActionCreate, snapshotOfDomainProperty(property), *input.CreatedByUserID); customErr != nil {
+			return nil, customErr
+		}
+	}
 	return &response.AddPropertyResponse{
-		PropertyID: result.PropertyID,
-		Slug:       result.Slug,
+		PropertyID:       result.PropertyID,
+		Slug:             result.Slug,
+		ModerationStatus: property.ModerationStatus,
 	}, nil
 }
 
-func (c *application) ListProperties(pagination *request.GetAllAPIRequest) ([]*response.PropertyListResponse, int, *imhttp.CustomError) {
-	properties, totalItems, err := c.repo.GetAllProperties(pagination.GetOffset(), pagination.GetLimit(), pagination.Filters)
+func (c *application) ListProperties(ctx context.Context, req *request.ListPropertiesRequest) (*response.PropertyListPage, *imhttp.CustomError) {
+	if !domain.IsValidPropertySort(req.Sort) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid sort", fmt.Sprintf("sort %q is not supported", req.Sort))
+	}
+
+	if types, ok := req.Filters["listing_type"].([]string); ok {
+		for _, t := range types {
+			if !domain.IsValidListingType(t) {
+				return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid listing type", fmt.Sprintf("listing_type %q is not supported", t))
+			}
+		}
+	}
+
+	if req.ApprovedOnly {
+		if req.Filters == nil {
+			req.Filters = make(map[string]interface{})
+		}
+		req.Filters["moderation_status"] = domain.ModerationStatusApproved
+	}
+
+	properties, totalItems, err := c.repo.GetAllProperties(req.GetOffset(), req.GetLimit(), req.Filters, req.Sort)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to list properties")
-		return nil, 0, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list properties", err.Error())
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list properties", err.Error())
 	}
 
 	var propertyResponses []*response.PropertyListResponse
@@ -290,25 +401,78 @@ func (c *application) ListProperties(pagination *request.GetAllAPIRequest) ([]*r
 		propertyResponses = append(propertyResponses, response.GetPropertyListResponse(property, developerName, location))
 	}
 
-	return propertyResponses, totalItems, nil
+	page := &response.PropertyListPage{
+		PaginatedResponse: response.NewPaginatedResponse(propertyResponses, req.Page, req.PageSize, totalItems),
+	}
+	if req.IncludeFacets {
+		page.Facets, err = c.repo.GetPropertyFacets(ctx, req.Filters)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to count property facets", err.Error())
+		}
+	}
+
+	return page, nil
+}
+
+// SyncPropertyAttributes normalizes the filterable attributes of properties that have
+// not been normalized yet.
+func (c *application) SyncPropertyAttributes(ctx context.Context) (int, *imhttp.CustomError) {
+	synced, err := c.repo.SyncPropertyAttributes(ctx)
+	if err != nil {
+		return synced, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to sync property attributes", err.Error())
+	}
+	return synced, nil
 }
 
-func (c *application) DeleteProperty(id string) *imhttp.CustomError {
-	isDeleted, err := c.repo.IsPropertyDeleted(id)
+func (c *application) DeleteProperty(ctx context.Context, id string, req *request.DeleteRequest, actor domain.Actor) (*response.DeletionResult, *imhttp.CustomError) {
+	property, err := c.repo.GetPropertyByID(id)
 	if err != nil {
-		logger.Get().Error().Err(err).Msg("Failed to check if property is deleted")
-		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check if property is deleted", err.Error())
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Property not found", err.Error())
 	}
-	if isDeleted {
-		return imhttp.NewCustomErr(http.StatusBadRequest, "Property is already deleted", "Property is already deleted")
+	if property.IsDeleted {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Property is already deleted", "Property is already deleted")
 	}
 
-	if err := c.repo.DeleteProperty(id, false); err != nil {
-		logger.Get().Error().Err(err).Msg("Failed to delete property")
-		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete property", err.Error())
+	report, err := c.repo.GetPropertyDeletionReport(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Failed to get property dependents", err.Error())
 	}
 
-	return nil
+	redirectTo, customErr := normalizeRedirectTarget(req.RedirectTo)
+	if customErr != nil {
+		return nil, customErr
+	}
+	if redirectTo == "" {
+		redirectTo = "/"
+		if project := property.Edges.Project; project != nil && !project.IsDeleted && project.Slug != "" {
+			redirectTo = domain.ProjectPath(project.Slug)
+		}
+	}
+
+	record := domain.DeletionRecord{
+		EntityType:   domain.DeletionEntityProperty,
+		EntityID:     id,
+		Slug:         property.Slug,
+		RedirectTo:   redirectTo,
+		DeletedBy:    actor.UserID,
+		RestoreUntil: time.Now().Add(config.GetConfig().Deletion.RestoreWindow),
+	}
+
+	deletion, err := c.repo.SoftDeleteWithRecord(ctx, record, report)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to delete property")
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete property", err.Error())
+	}
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionDelete,
+		EntityType: domain.AuditEntityProperty,
+		EntityID:   id,
+		Before:     response.GetPropertyFromEnt(property),
+		After:      c.propertySnapshot(id),
+	})
+
+	return response.GetDeletionResultFromEnt(deletion), nil
 }
 
 func (c *application) GetPropertyBySlug(ctx context.Context, slug string) (*response.Property, *imhttp.CustomError) {
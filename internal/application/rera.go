@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+const reraDateLayout = "2006-01-02"
+
+func reraWarningWindow() time.Duration {
+	return time.Duration(config.GetConfig().Rera.ExpiryWarningDays) * 24 * time.Hour
+}
+
+func (c *application) GetProjectReraRegistrations(ctx context.Context, projectID string) ([]*response.ReraRegistration, *imhttp.CustomError) {
+	if _, err := c.repo.GetProjectByID(projectID); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Project not found", err.Error())
+	}
+
+	registrations, err := c.repo.GetReraRegistrationsOfProject(ctx, projectID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get rera registrations", err.Error())
+	}
+
+	resp := make([]*response.ReraRegistration, 0, len(registrations))
+	for _, registration := range registrations {
+		resp = append(resp, response.GetReraRegistrationFromEnt(registration))
+	}
+	return resp, nil
+}
+
+func (c *application) AddReraRegistration(ctx context.Context, projectID string, req *request.ReraRegistrationRequest) (*response.ReraRegistration, *imhttp.CustomError) {
+	if _, err := c.repo.GetProjectByID(projectID); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Project not found", err.Error())
+	}
+
+	input, customErr := c.buildReraRegistration(ctx, "", req)
+	if customErr != nil {
+		return nil, customErr
+	}
+	input.ProjectID = projectID
+
+	registration, err := c.repo.AddReraRegistration(ctx, *input)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add rera registration", err.Error())
+	}
+	return response.GetReraRegistrationFromEnt(registration), nil
+}
+
+func (c *application) UpdateReraRegistration(ctx context.Context, id string, req *request.ReraRegistrationRequest) (*response.ReraRegistration, *imhttp.CustomError) {
+	existing, err := c.repo.GetReraRegistrationByID(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Rera registration not found", err.Error())
+	}
+
+	input, customErr := c.buildReraRegistration(ctx, id, req)
+	if customErr != nil {
+		return nil, customErr
+	}
+	input.ID = id
+	input.ProjectID = existing.ProjectID
+
+	registration, err := c.repo.UpdateReraRegistration(ctx, *input)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update rera registration", err.Error())
+	}
+	return response.GetReraRegistrationFromEnt(registration), nil
+}
+
+func (c *application) DeleteReraRegistration(ctx context.Context, id string) *imhttp.CustomError {
+	if err := c.repo.DeleteReraRegistration(ctx, id); err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Failed to delete rera registration", err.Error())
+	}
+	return nil
+}
+
+func (c *application) ListExpiringReraRegistrations(ctx context.Context) ([]*response.ReraRegistration, *imhttp.CustomError) {
+	registrations, err := c.repo.GetReraRegistrationsByStatus(ctx, []string{domain.ReraStatusExpiring, domain.ReraStatusExpired})
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get expiring rera registrations", err.Error())
+	}
+
+	resp := make([]*response.ReraRegistration, 0, len(registrations))
+	for _, registration := range registrations {
+		resp = append(resp, response.GetReraRegistrationFromEnt(registration))
+	}
+	return resp, nil
+}
+
+// RefreshReraStatuses recomputes the status of every registration from its validity
+// dates so that expiring and expired registrations are flagged.
+func (c *application) RefreshReraStatuses(ctx context.Context) (*response.ReraStatusRefreshResponse, *imhttp.CustomError) {
+	registrations, err := c.repo.GetReraRegistrationsForStatusCheck(ctx)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get rera registrations", err.Error())
+	}
+
+	now := time.Now()
+	window := reraWarningWindow()
+	result := &response.ReraStatusRefreshResponse{Checked: len(registrations)}
+
+	for _, registration := range registrations {
+		current := string(registration.Status)
+		status := domain.ComputeReraStatus(current, registration.ValidFrom, registration.ValidUntil, window, now)
+
+		switch status {
+		case domain.ReraStatusExpiring:
+			result.Expiring++
+		case domain.ReraStatusExpired:
+			result.Expired++
+		}
+
+		if status == current {
+			continue
+		}
+
+		if err := c.repo.UpdateReraRegistrationStatus(ctx, registration.ID, status); err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update rera registration status", err.Error())
+		}
+		logger.Get().Info().
+			Str("id", registration.ID).
+			Str("project_id", registration.ProjectID).
+			Str("from", current).
+			Str("to", status).
+			Msg("Rera registration status changed")
+		result.Updated++
+	}
+
+	return result, nil
+}
+
+// BackfillReraRegistrations creates registrations for projects that only carry RERA numbers
+// in their legacy web cards, so they keep their approved flag. Numbers that don't match the
+// state's format or are already registered elsewhere are skipped and reported.
+func (c *application) BackfillReraRegistrations(ctx context.Context) (*response.ReraBackfillResult, *imhttp.CustomError) {
+	projects, err := c.repo.GetProjectsWithoutReraRegistrations(ctx)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get projects", err.Error())
+	}
+
+	result := &response.ReraBackfillResult{Skipped: []string{}}
+	for _, project := range projects {
+		items := project.WebCards.ReraInfo.ReraList
+		if len(items) == 0 && project.WebCards.Details.ReraNumber.Value != "" {
+			items = []schema.ReraListItem{{ReraNumber: project.WebCards.Details.ReraNumber.Value}}
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		state := ""
+		if project.Edges.Location != nil {
+			state = project.Edges.Location.State
+		}
+		if state == "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("project %s: no state to register rera numbers under", project.ID))
+			continue
+		}
+		authority := domain.GetReraAuthority(state)
+
+		seen := make(map[string]bool, len(items))
+		for _, item := range items {
+			number := domain.NormalizeReraNumber(item.ReraNumber)
+			if number == "" || seen[number] {
+				continue
+			}
+			seen[number] = true
+
+			if err := domain.ValidateReraNumber(state, number); err != nil {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("project %s: %s", project.ID, err.Error()))
+				continue
+			}
+
+			input := domain.ReraRegistration{
+				ProjectID:          project.ID,
+				Phase:              item.Phase,
+				State:              authority.State,
+				Authority:          authority.Name,
+				RegistrationNumber: number,
+				Status:             domain.LegacyReraStatus(item.Status),
+				QRImage:            item.ReraQR,
+				WebsiteLink:        project.WebCards.ReraInfo.WebsiteLink,
+			}
+			if input.WebsiteLink == "" {
+				input.WebsiteLink = authority.Website
+			}
+
+			if _, err := c.repo.AddReraRegistration(ctx, input); err != nil {
+				if ent.IsConstraintError(err) {
+					result.Skipped = append(result.Skipped, fmt.Sprintf("project %s: %s is already registered", project.ID, number))
+					continue
+				}
+				return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add rera registration", err.Error())
+			}
+			result.Registered++
+		}
+	}
+
+	for _, skipped := range result.Skipped {
+		logger.Get().Warn().Str("reason", skipped).Msg("Skipped legacy rera number")
+	}
+	return result, nil
+}
+
+func (c *application) buildReraRegistration(ctx context.Context, id string, req *request.ReraRegistrationRequest) (*domain.ReraRegistration, *imhttp.CustomError) {
+	if err := domain.ValidateReraNumber(req.State, req.RegistrationNumber); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid RERA registration number", err.Error())
+	}
+
+	authority := domain.GetReraAuthority(req.State)
+	number := domain.NormalizeReraNumber(req.RegistrationNumber)
+
+	exist, err := c.repo.ExistReraRegistration(ctx, authority.State, number, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check rera registration", err.Error())
+	}
+	if exist {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "RERA registration already exists", fmt.Sprintf("%s is already registered", number))
+	}
+
+	input := &domain.ReraRegistration{
+		Phase:              req.Phase,
+		State:              authority.State,
+		Authority:          authority.Name,
+		RegistrationNumber: number,
+		QRImage:            req.QRImage,
+		WebsiteLink:        req.WebsiteLink,
+	}
+	if input.WebsiteLink == "" {
+		input.WebsiteLink = authority.Website
+	}
+
+	if req.ValidFrom != "" {
+		validFrom, err := time.Parse(reraDateLayout, req.ValidFrom)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid valid_from date", err.Error())
+		}
+		input.ValidFrom = &validFrom
+	}
+	if req.ValidUntil != "" {
+		validUntil, err := time.Parse(reraDateLayout, req.ValidUntil)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid valid_until date", err.Error())
+		}
+		input.ValidUntil = &validUntil
+	}
+	if input.ValidFrom != nil && input.ValidUntil != nil && input.ValidUntil.Before(*input.ValidFrom) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid validity dates", "valid_until must be after valid_from")
+	}
+
+	if req.Revoked {
+		input.Status = domain.ReraStatusRevoked
+	} else {
+		input.Status = domain.ComputeReraStatus("", input.ValidFrom, input.ValidUntil, reraWarningWindow(), time.Now())
+	}
+
+	return input, nil
+}
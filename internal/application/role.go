@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// roleCache holds resolved role permissions, so permission checks do not query the
+// database on every request. Edits on this instance clear it; other instances pick them
+// up when entries expire.
+type roleCache struct {
+	mu      sync.RWMutex
+	entries map[string]roleCacheEntry
+}
+
+type roleCacheEntry struct {
+	permissions map[string]bool
+	expiresAt   time.Time
+}
+
+func newRoleCache() *roleCache {
+	return &roleCache{entries: make(map[string]roleCacheEntry)}
+}
+
+func (s *roleCache) get(role string) (map[string]bool, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[role]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.permissions, true
+}
+
+func (s *roleCache) set(role string, permissions map[string]bool, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[role] = roleCacheEntry{permissions: permissions, expiresAt: time.Now().Add(ttl)}
+}
+
+func (s *roleCache) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]roleCacheEntry)
+}
+
+// EnsureDefaultRoles seeds the definitions of roles that have none.
+func (c *application) EnsureDefaultRoles(ctx context.Context) error {
+	return c.repo.EnsureRoles(ctx, domain.DefaultRolePermissions)
+}
+
+// RolePermissions returns the permission set of a role. A role without a definition has
+// no permissions.
+func (c *application) RolePermissions(ctx context.Context, role string) (map[string]bool, *imhttp.CustomError) {
+	if permissions, ok := c.roles.get(role); ok {
+		return permissions, nil
+	}
+
+	var stored []string
+	if role != domain.RoleSuperAdmin {
+		ro, err := c.repo.GetRole(ctx, role)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to resolve permissions", err.Error())
+		}
+		if ro != nil {
+			stored = ro.Permissions
+		}
+	}
+
+	permissions := domain.RolePermissionSet(role, stored)
+	c.roles.set(role, permissions, config.GetConfig().Permissions.CacheTTL)
+	return permissions, nil
+}
+
+func (c *application) ListRoles(ctx context.Context) ([]*response.Role, *imhttp.CustomError) {
+	roles, err := c.repo.ListRoles(ctx)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list roles", err.Error())
+	}
+	items := make([]*response.Role, 0, len(roles))
+	for _, ro := range roles {
+		items = append(items, response.GetRoleFromEnt(ro))
+	}
+	return items, nil
+}
+
+func (c *application) ListPermissions() []*response.Permission {
+	return response.GetPermissions()
+}
+
+// UpdateRole replaces the permission set of a role. The permissions of superadmin cannot
+// be edited, so there is always a role able to undo a bad edit; only its description and
+// two-factor requirement can change.
+func (c *application) UpdateRole(ctx context.Context, name string, req *request.UpdateRoleRequest, actor domain.Actor) (*response.Role, *imhttp.CustomError) {
+	before, err := c.repo.GetRole(ctx, name)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get role", err.Error())
+	}
+	if before == nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Role not found", "role not found")
+	}
+
+	var permissions []string
+	if name == domain.RoleSuperAdmin {
+		if req.Permissions != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "The superadmin role always has every permission", "superadmin permissions are not editable")
+		}
+	} else {
+		if req.Permissions == nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Permissions are required", "permissions are required")
+		}
+		// Permissions the role already has can be kept; only new ones must be held by the actor
+		granted := make(map[string]bool, len(actor.Permissions)+len(before.Permissions))
+		for permission, ok := range actor.Permissions {
+			granted[permission] = ok
+		}
+		for _, permission := range before.Permissions {
+			granted[permission] = true
+		}
+		var cerr *imhttp.CustomError
+		permissions, cerr = normalizePermissions(req.Permissions, granted)
+		if cerr != nil {
+			return nil, cerr
+		}
+	}
+
+	ro, err := c.repo.UpdateRolePermissions(ctx, name, permissions, req.Description, req.TwoFactorRequired, actor.UserID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Failed to update role", err.Error())
+	}
+	c.roles.invalidate()
+
+	updated := response.GetRoleFromEnt(ro)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: domain.AuditEntityRole,
+		EntityID:   name,
+		Before:     response.GetRoleFromEnt(before),
+		After:      updated,
+	})
+	logger.Get().Info().Str("role", name).Strs("permissions", permissions).Str("updated_by", actor.UserID).Msg("Role permissions updated")
+	return updated, nil
+}
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// unsubscribePath is the public endpoint linked from every digest.
+const unsubscribePath = "/v1/api/saved-searches/unsubscribe"
+
+// CreateSavedSearch saves a search and sends a verification code to its phone or email.
+// Alerts start once the code is confirmed with VerifySavedSearch.
+func (c *application) CreateSavedSearch(ctx context.Context, req *request.CreateSavedSearchRequest) (*response.SavedSearch, *imhttp.CustomError) {
+	cfg := config.GetConfig().SavedSearch
+
+	channel := req.Channel
+	if channel == "" {
+		channel = domain.AlertChannelSMS
+		if req.Phone == "" {
+			channel = domain.AlertChannelEmail
+		}
+	}
+	if channel == domain.AlertChannelSMS && req.Phone == "" {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Phone is required for SMS alerts", "Phone is required for SMS alerts")
+	}
+	if channel == domain.AlertChannelEmail && req.Email == "" {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Email is required for email alerts", "Email is required for email alerts")
+	}
+	if !domain.IsValidAlertFrequency(req.Frequency) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid frequency", "frequency must be daily or weekly")
+	}
+	if len(req.Filters) == 0 {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Filters are required", "Filters are required")
+	}
+	if err := domain.ValidateProjectFilters(req.Filters); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid filters", err.Error())
+	}
+
+	phone, email := "", ""
+	if channel == domain.AlertChannelSMS {
+		phone = req.Phone
+	} else {
+		email = strings.ToLower(strings.TrimSpace(req.Email))
+	}
+	count, err := c.repo.CountActiveSavedSearches(ctx, phone, email)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check saved searches", err.Error())
+	}
+	if count >= cfg.MaxPerContact {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Too many saved searches",
+			fmt.Sprintf("at most %d saved searches are allowed per contact", cfg.MaxPerContact))
+	}
+
+	unsubscribeToken, err := utils.GenerateSecureToken(24)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate unsubscribe token", err.Error())
+	}
+	code, err := utils.GenerateSecureOTP(6)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate verification code", err.Error())
+	}
+
+	saved, err := c.repo.CreateSavedSearch(ctx, domain.SavedSearch{
+		Name:                  strings.TrimSpace(req.Name),
+		Channel:               channel,
+		Phone:                 phone,
+		Email:                 email,
+		Filters:               req.Filters,
+		Frequency:             req.Frequency,
+		VerificationCodeHash:  utils.HashToken(code),
+		VerificationExpiresAt: time.Now().Add(cfg.VerificationTTL),
+		UnsubscribeToken:      unsubscribeToken,
+	})
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to save search", err.Error())
+	}
+
+	if channel == domain.AlertChannelSMS {
+		err = c.smsClient.SendOTP(phone, code)
+	} else {
+		err = c.mailer.Send(email, "Verify your saved search",
+			fmt.Sprintf("Your verification code is %s. It is valid for %s.", code, cfg.VerificationTTL))
+	}
+	if err != nil {
+		logger.Get().Error().Err(err).Str("saved_search_id", saved.ID).Msg("Failed to send saved search verification code")
+		return nil, imhttp.NewCustomErr(http.StatusBadGateway, "Failed to send verification code", err.Error())
+	}
+
+	return response.GetSavedSearchFromEnt(saved), nil
+}
+
+// VerifySavedSearch confirms a saved search with its code. A code is locked after
+// VerificationMaxAttempts wrong guesses.
+func (c *application) VerifySavedSearch(ctx context.Context, id string, req *request.VerifySavedSearchRequest) (*response.SavedSearch, *imhttp.CustomError) {
+	cfg := config.GetConfig().SavedSearch
+
+	saved, err := c.repo.GetSavedSearchByID(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Saved search not found", err.Error())
+	}
+	if saved.VerifiedAt != nil {
+		return response.GetSavedSearchFromEnt(saved), nil
+	}
+	if saved.VerificationExpiresAt == nil || time.Now().After(*saved.VerificationExpiresAt) ||
+		saved.VerificationAttempts >= cfg.VerificationMaxAttempts {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid or expired code", "Invalid or expired code")
+	}
+	if saved.VerificationCodeHash != utils.HashToken(req.Code) {
+		if err := c.repo.IncrementSavedSearchVerificationAttempts(ctx, saved.ID); err != nil {
+			logger.Get().Error().Err(err).Str("saved_search_id", saved.ID).Msg("Failed to record saved search verification attempt")
+		}
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid or expired code", "Invalid or expired code")
+	}
+
+	saved, err = c.repo.MarkSavedSearchVerified(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify saved search", err.Error())
+	}
+	return response.GetSavedSearchFromEnt(saved), nil
+}
+
+func (c *application) UnsubscribeSavedSearch(ctx context.Context, token string) *imhttp.CustomError {
+	if token == "" {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Token is required", "Token is required")
+	}
+	if err := c.repo.UnsubscribeSavedSearch(ctx, token); err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Failed to unsubscribe", err.Error())
+	}
+	return nil
+}
+
+// SendSavedSearchAlerts sends a digest to every saved search whose period has elapsed,
+// listing the matching projects published or re-priced since its previous check.
+func (c *application) SendSavedSearchAlerts(ctx context.Context) (*response.SavedSearchAlertResult, *imhttp.CustomError) {
+	cfg := config.GetConfig()
+	searches, err := c.repo.GetAlertingSavedSearches(ctx)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get saved searches", err.Error())
+	}
+
+	result := &response.SavedSearchAlertResult{}
+	now := time.Now()
+	for _, saved := range searches {
+		since := *saved.VerifiedAt
+		if saved.LastCheckedAt != nil {
+			since = *saved.LastCheckedAt
+		}
+		if now.Sub(since) < domain.AlertFrequencyInterval(saved.Frequency.String()) {
+			continue
+		}
+		result.Checked++
+
+		projects, err := c.repo.GetProjectsChangedSince(ctx, saved.Filters, since, cfg.SavedSearch.MaxAlertItems)
+		if err != nil {
+			result.Failed++
+			continue
+		}
+		if len(projects) > 0 {
+			if err := c.sendSavedSearchDigest(saved, projects, since, cfg.Server.BaseURL); err != nil {
+				logger.Get().Error().Err(err).Str("saved_search_id", saved.ID).Msg("Failed to send saved search digest")
+				result.Failed++
+				continue
+			}
+			result.Sent++
+		}
+		if err := c.repo.MarkSavedSearchChecked(ctx, saved.ID, now, len(projects) > 0); err != nil {
+			logger.Get().Error().Err(err).Str("saved_search_id", saved.ID).Msg("Failed to mark saved search checked")
+		}
+	}
+	return result, nil
+}
+
+func (c *application) sendSavedSearchDigest(saved *ent.SavedSearch, projects []*ent.Project, since time.Time, baseURL string) error {
+	unsubscribeURL := baseURL + unsubscribePath + "?token=" + url.QueryEscape(saved.UnsubscribeToken)
+	title := "your saved search"
+	if saved.Name != "" {
+		title = fmt.Sprintf("%q", saved.Name)
+	}
+
+	if saved.Channel.String() == domain.AlertChannelSMS {
+		names := make([]string, 0, len(projects))
+		for _, p := range projects {
+			names = append(names, p.Name)
+		}
+		message := fmt.Sprintf("Investmango: %d new matches for %s: %s. Visit %s. Stop alerts: %s",
+			len(projects), title, strings.Join(names, ", "), baseURL, unsubscribeURL)
+		return c.smsClient.SendMessage(saved.Phone, message)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "New and updated projects matching %s:\n\n", title)
+	for _, p := range projects {
+		reason := "New"
+		if !p.CreatedAt.After(since) {
+			reason = "Price updated"
+		}
+		fmt.Fprintf(&body, "- %s (%s)\n  %s%s\n", p.Name, reason, baseURL, domain.ProjectPath(p.Slug))
+	}
+	fmt.Fprintf(&body, "\nTo stop these alerts, open %s\n", unsubscribeURL)
+	return c.mailer.Send(saved.Email, fmt.Sprintf("%d new matches for %s", len(projects), title), body.String())
+}
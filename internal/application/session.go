@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// startSession issues an access token and the first refresh token of a new session.
+func (c *application) startSession(ctx context.Context, u *ent.User, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError) {
+	accessToken, err := auth.GenerateToken(u.ID, false, u.Role.String(), u.PhoneNumber)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate access token", err.Error())
+	}
+
+	refreshToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate refresh token", err.Error())
+	}
+	if _, err := c.repo.CreateRefreshToken(ctx, u.ID, utils.HashToken(refreshToken), time.Now().Add(config.GetConfig().RefreshTokenTTL), meta); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to store refresh token", err.Error())
+	}
+
+	return &response.GenerateTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Role:         u.Role.String(),
+		Name:         u.Name,
+	}, nil
+}
+
+// revokeReusedSession ends a session whose refresh token was presented after rotation.
+func (c *application) revokeReusedSession(ctx context.Context, t *ent.RefreshToken) *imhttp.CustomError {
+	logger.Get().Warn().Str("user_id", t.UserID).Str("family_id", t.FamilyID).Msg("Refresh token reused, revoking session")
+	if err := c.repo.RevokeRefreshTokenFamily(ctx, t.FamilyID, domain.SessionRevokedReuse); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to revoke session", err.Error())
+	}
+	return imhttp.NewCustomErr(http.StatusUnauthorized, "Session has ended, please sign in again", "refresh token reused")
+}
+
+// Logout ends the session a refresh token belongs to. Unknown tokens are ignored, so
+// logging out twice succeeds.
+func (c *application) Logout(ctx context.Context, refreshToken string) *imhttp.CustomError {
+	if refreshToken == "" {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Refresh token is empty", "Refresh token is empty")
+	}
+	t, err := c.repo.GetRefreshTokenByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to end session", err.Error())
+	}
+	if t == nil || t.RevokedAt != nil {
+		return nil
+	}
+	if err := c.repo.RevokeRefreshTokenFamily(ctx, t.FamilyID, domain.SessionRevokedLogout); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to end session", err.Error())
+	}
+	return nil
+}
+
+// LogoutAll ends every session of a user. Access tokens already issued stay valid until
+// they expire.
+func (c *application) LogoutAll(ctx context.Context, userID string) *imhttp.CustomError {
+	if err := c.repo.RevokeUserRefreshTokens(ctx, userID, domain.SessionRevokedLogoutAll); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to end sessions", err.Error())
+	}
+	return nil
+}
+
+func (c *application) ListUserSessions(ctx context.Context, userID string) ([]*response.Session, *imhttp.CustomError) {
+	if _, err := c.repo.GetUserByID(ctx, userID); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	tokens, err := c.repo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list sessions", err.Error())
+	}
+	sessions := make([]*response.Session, 0, len(tokens))
+	for _, t := range tokens {
+		sessions = append(sessions, response.GetSessionFromEnt(t))
+	}
+	return sessions, nil
+}
+
+func (c *application) RevokeUserSession(ctx context.Context, userID, sessionID string) *imhttp.CustomError {
+	found, err := c.repo.RevokeUserSession(ctx, userID, sessionID, domain.SessionRevokedByAdmin)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to revoke session", err.Error())
+	}
+	if !found {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Session not found", "session not found")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+const (
+	defaultSimilarLimit = 6
+	maxSimilarLimit     = 20
+)
+
+// similarCache holds computed similar-project rails. Any project change can move a
+// project in or out of other rails, so writes clear the whole cache.
+type similarCache struct {
+	mu      sync.RWMutex
+	entries map[string]similarCacheEntry
+}
+
+type similarCacheEntry struct {
+	projects  []*response.SimilarProject
+	expiresAt time.Time
+}
+
+func newSimilarCache() *similarCache {
+	return &similarCache{entries: make(map[string]similarCacheEntry)}
+}
+
+func (s *similarCache) get(key string) ([]*response.SimilarProject, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.projects, true
+}
+
+func (s *similarCache) set(key string, projects []*response.SimilarProject, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = similarCacheEntry{projects: projects, expiresAt: time.Now().Add(ttl)}
+}
+
+func (s *similarCache) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[string]similarCacheEntry)
+}
+
+func (c *application) GetSimilarProjects(ctx context.Context, projectID string, req *request.SimilarProjectsRequest) ([]*response.SimilarProject, *imhttp.CustomError) {
+	cfg := config.GetConfig().Similarity
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	if limit > maxSimilarLimit {
+		limit = maxSimilarLimit
+	}
+	excludeSameDeveloper := cfg.ExcludeSameDeveloper
+	if req.ExcludeSameDeveloper != nil {
+		excludeSameDeveloper = *req.ExcludeSameDeveloper
+	}
+
+	key := fmt.Sprintf("%s:%d:%t", projectID, limit, excludeSameDeveloper)
+	if cached, ok := c.similar.get(key); ok {
+		return cached, nil
+	}
+
+	target, err := c.repo.GetProjectByID(projectID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Project not found", err.Error())
+	}
+	targetFeatures := projectFeatures(target)
+
+	candidates, err := c.repo.GetSimilarProjectCandidates(ctx, target.ID, targetFeatures.City, targetFeatures.ProjectType)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get similar projects", err.Error())
+	}
+
+	weights := domain.SimilarityWeights{
+		City:          cfg.CityWeight,
+		Locality:      cfg.LocalityWeight,
+		ProjectType:   cfg.ProjectTypeWeight,
+		Status:        cfg.StatusWeight,
+		Price:         cfg.PriceWeight,
+		Configuration: cfg.ConfigurationWeight,
+		Developer:     cfg.DeveloperWeight,
+	}
+
+	similar := make([]*response.SimilarProject, 0, len(candidates))
+	for _, candidate := range candidates {
+		features := projectFeatures(candidate)
+		if excludeSameDeveloper && features.DeveloperID != "" && features.DeveloperID == targetFeatures.DeveloperID {
+			continue
+		}
+		score, reasons := domain.ScoreProjectSimilarity(targetFeatures, features, weights)
+		if score <= 0 {
+			continue
+		}
+		similar = append(similar, response.GetSimilarProject(candidate, score, reasons))
+	}
+	sort.SliceStable(similar, func(i, j int) bool { return similar[i].Score > similar[j].Score })
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+
+	c.similar.set(key, similar, cfg.CacheTTL)
+	return similar, nil
+}
+
+func projectFeatures(project *ent.Project) domain.ProjectFeatures {
+	features := domain.ProjectFeatures{
+		ProjectID:      project.ID,
+		ProjectType:    project.ProjectType.String(),
+		Status:         string(project.Status),
+		MinPrice:       domain.ParsePrice(project.MinPrice),
+		MaxPrice:       domain.ParsePrice(project.MaxPrice),
+		Configurations: domain.ParseConfigurations(project.WebCards.Details.Configuration.Value),
+	}
+	if project.Edges.Location != nil {
+		features.City = project.Edges.Location.City
+		features.Locality = project.Edges.Location.LocalityName
+	}
+	if project.Edges.Developer != nil {
+		features.DeveloperID = project.Edges.Developer.ID
+	}
+	return features
+}
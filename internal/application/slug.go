@@ -0,0 +1,234 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// slugError reports a slug conflict as 409 and anything else as a server error.
+func slugError(err error, errMsg string) *imhttp.CustomError {
+	var taken *domain.SlugTakenError
+	if errors.As(err, &taken) {
+		return imhttp.NewCustomErr(http.StatusConflict, "Slug already in use", taken.Error())
+	}
+	return imhttp.NewCustomErr(http.StatusInternalServerError, errMsg, err.Error())
+}
+
+// ensureSlugAvailable rejects a slug that any entity uses or used before.
+func (c *application) ensureSlugAvailable(ctx context.Context, slug string) *imhttp.CustomError {
+	result, err := c.repo.CheckURLExists(ctx, slug)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check if slug is already in use", err.Error())
+	}
+	if result.Exists {
+		return slugError(&domain.SlugTakenError{
+			Slug:       slug,
+			EntityType: result.EntityType,
+			EntityID:   result.EntityID,
+			Retired:    result.Retired,
+		}, "")
+	}
+	return nil
+}
+
+func (c *application) GetSlugHistory(ctx context.Context, entityType, entityID string) ([]*response.SlugRecord, *imhttp.CustomError) {
+	switch entityType {
+	case domain.SlugEntityProject, domain.SlugEntityProperty, domain.SlugEntityBlog, domain.SlugEntityCustomSearchPage:
+	default:
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid entity_type",
+			fmt.Sprintf("entity_type must be one of %s, %s, %s, %s", domain.SlugEntityProject, domain.SlugEntityProperty, domain.SlugEntityBlog, domain.SlugEntityCustomSearchPage))
+	}
+
+	records, err := c.repo.GetSlugHistory(ctx, entityType, entityID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get slug history", err.Error())
+	}
+
+	history := make([]*response.SlugRecord, 0, len(records))
+	for _, record := range records {
+		history = append(history, response.GetSlugRecordFromEnt(record))
+	}
+	return history, nil
+}
+
+// ReleaseSlug frees a retired slug. Redirects from its old path are left alone.
+func (c *application) ReleaseSlug(ctx context.Context, slug string) *imhttp.CustomError {
+	owner, err := c.repo.GetSlugOwner(ctx, slug)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get slug", err.Error())
+	}
+	if owner == nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Slug not found", fmt.Sprintf("slug %q is not reserved", slug))
+	}
+	if owner.IsCurrent {
+		return imhttp.NewCustomErr(http.StatusConflict, "Slug is in use",
+			fmt.Sprintf("slug %q is the current slug of %s %s", slug, owner.EntityType, owner.EntityID))
+	}
+
+	if err := c.repo.ReleaseSlug(ctx, slug); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to release slug", err.Error())
+	}
+	return nil
+}
+
+// SyncSlugRegistry registers slugs that were written before the registry existed.
+func (c *application) SyncSlugRegistry(ctx context.Context) (*response.SlugSyncResult, *imhttp.CustomError) {
+	registered, conflicts, err := c.repo.SyncSlugRegistry(ctx)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to sync slug registry", err.Error())
+	}
+
+	result := &response.SlugSyncResult{
+		Registered: registered,
+		Conflicts:  make([]string, 0, len(conflicts)),
+	}
+	for _, conflict := range conflicts {
+		logger.Get().Warn().Err(conflict).Msg("Slug conflict")
+		result.Conflicts = append(result.Conflicts, conflict.Error())
+	}
+	return result, nil
+}
+
+func (c *application) ListRedirects(ctx context.Context, req *request.ListRedirectsRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	req.Validate()
+	redirects, total, err := c.repo.ListRedirects(ctx, req.Search, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list redirects", err.Error())
+	}
+
+	items := make([]*response.Redirect, 0, len(redirects))
+	for _, rd := range redirects {
+		items = append(items, response.GetRedirectFromEnt(rd))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+func (c *application) CreateRedirect(ctx context.Context, req *request.RedirectRequest) (*response.Redirect, *imhttp.CustomError) {
+	input, customErr := c.validateRedirect(ctx, "", req)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	rd, err := c.repo.SaveRedirect(ctx, *input)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create redirect", err.Error())
+	}
+	return response.GetRedirectFromEnt(rd), nil
+}
+
+func (c *application) UpdateRedirect(ctx context.Context, id string, req *request.RedirectRequest) (*response.Redirect, *imhttp.CustomError) {
+	if _, err := c.repo.GetRedirectByID(ctx, id); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Redirect not found", err.Error())
+	}
+
+	input, customErr := c.validateRedirect(ctx, id, req)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	rd, err := c.repo.UpdateRedirect(ctx, id, *input)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update redirect", err.Error())
+	}
+	return response.GetRedirectFromEnt(rd), nil
+}
+
+func (c *application) DeleteRedirect(ctx context.Context, id string) *imhttp.CustomError {
+	if _, err := c.repo.GetRedirectByID(ctx, id); err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Redirect not found", err.Error())
+	}
+	if err := c.repo.DeleteRedirect(ctx, id); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to delete redirect", err.Error())
+	}
+	return nil
+}
+
+// validateRedirect checks a manual redirect. It must not shadow a live page or point
+// back at a path that already redirects to it.
+func (c *application) validateRedirect(ctx context.Context, id string, req *request.RedirectRequest) (*domain.Redirect, *imhttp.CustomError) {
+	fromPath := "/" + strings.Trim(strings.TrimSpace(req.FromPath), "/")
+	toPath, customErr := normalizeRedirectTarget(req.ToPath)
+	if customErr != nil {
+		return nil, customErr
+	}
+	if fromPath == "/" || toPath == "" {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid redirect", "from_path and to_path are required and from_path cannot be /")
+	}
+	if fromPath == toPath {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid redirect", "from_path and to_path must differ")
+	}
+
+	statusCode := req.StatusCode
+	switch statusCode {
+	case 0:
+		statusCode = http.StatusMovedPermanently
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid status_code", "status_code must be 301, 302, 307 or 308")
+	}
+
+	existing, err := c.repo.GetRedirectByPath(ctx, fromPath)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get redirect", err.Error())
+	}
+	if existing != nil && id != "" && existing.ID != id {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Redirect already exists", fmt.Sprintf("%s already redirects to %s", fromPath, existing.ToPath))
+	}
+
+	reverse, err := c.repo.GetRedirectByPath(ctx, toPath)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get redirect", err.Error())
+	}
+	if reverse != nil && reverse.ToPath == fromPath {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Redirect loop", fmt.Sprintf("%s already redirects to %s", toPath, fromPath))
+	}
+
+	if live, customErr := c.isLivePath(ctx, fromPath); customErr != nil {
+		return nil, customErr
+	} else if live {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Path is live", fmt.Sprintf("%s is the current page of an entity; change its slug instead", fromPath))
+	}
+
+	return &domain.Redirect{
+		FromPath:   fromPath,
+		ToPath:     toPath,
+		StatusCode: statusCode,
+		Reason:     domain.RedirectReasonManual,
+	}, nil
+}
+
+// isLivePath reports whether a path is the current page of a project, property, blog or
+// custom search page.
+func (c *application) isLivePath(ctx context.Context, path string) (bool, *imhttp.CustomError) {
+	var slug string
+	switch {
+	case strings.HasPrefix(path, domain.PropertySalePathPrefix), strings.HasPrefix(path, domain.PropertyRentPathPrefix):
+		slug, _ = domain.SplitPropertyPath(path)
+		// Both route families serve the current property page
+		path = domain.PropertyPath(slug)
+	case strings.HasPrefix(path, "/blogs/"):
+		slug = strings.TrimPrefix(path, "/blogs/")
+	case strings.Count(path, "/") == 1:
+		slug = strings.TrimPrefix(path, "/")
+	default:
+		return false, nil
+	}
+
+	owner, err := c.repo.GetSlugOwner(ctx, slug)
+	if err != nil {
+		return false, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get slug", err.Error())
+	}
+	if owner == nil || !owner.IsCurrent {
+		return false, nil
+	}
+	return domain.SlugPath(string(owner.EntityType), owner.Slug) == path, nil
+}
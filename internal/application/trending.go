@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	projectEnt "github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+const (
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = 50
+)
+
+// RecomputeTrendingProjects rescores every project with recent leads or views and
+// rebuilds the trending cache. It returns the number of scored projects.
+func (c *application) RecomputeTrendingProjects(ctx context.Context) (int, *imhttp.CustomError) {
+	cfg := config.GetConfig().Trending
+	now := time.Now()
+	since := now.Add(-cfg.Window)
+
+	leads, err := c.repo.GetLeadSignals(ctx, since)
+	if err != nil {
+		return 0, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get lead signals", err.Error())
+	}
+	views, err := c.repo.GetViewSignals(ctx, since)
+	if err != nil {
+		return 0, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get view signals", err.Error())
+	}
+
+	weights := domain.PopularityWeights{
+		Lead:         cfg.LeadWeight,
+		VerifiedLead: cfg.VerifiedLeadWeight,
+		View:         cfg.ViewWeight,
+	}
+	scored := domain.ScoreProjectPopularity(leads, views, weights, cfg.HalfLife, now)
+
+	scores := make([]domain.ProjectPopularity, 0, len(scored))
+	for _, s := range scored {
+		scores = append(scores, *s)
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Score > scores[j].Score })
+
+	written, err := c.repo.ReplaceProjectPopularity(ctx, scores, now)
+	if err != nil {
+		return 0, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to save trending projects", err.Error())
+	}
+	return written, nil
+}
+
+func (c *application) ListTrendingProjects(ctx context.Context, req *request.TrendingProjectsRequest) ([]*response.TrendingProject, *imhttp.CustomError) {
+	filters := map[string]interface{}{
+		"city":     strings.TrimSpace(req.City),
+		"locality": strings.TrimSpace(req.Locality),
+	}
+	if req.ProjectType != "" {
+		projectType := strings.ToUpper(req.ProjectType)
+		if err := projectEnt.ProjectTypeValidator(projectEnt.ProjectType(projectType)); err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid project_type", err.Error())
+		}
+		filters["project_type"] = projectType
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+
+	popularity, err := c.repo.ListTrendingProjects(ctx, filters, limit)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list trending projects", err.Error())
+	}
+
+	result := make([]*response.TrendingProject, 0, len(popularity))
+	for i, p := range popularity {
+		result = append(result, response.GetTrendingProject(p, i+1))
+	}
+	return result, nil
+}
@@ -0,0 +1,383 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+func (c *application) GetTwoFactorStatus(ctx context.Context, userID string) (*response.TwoFactorStatus, *imhttp.CustomError) {
+	u, err := c.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	tf, err := c.repo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get two-factor status", err.Error())
+	}
+	required, cerr := c.twoFactorRequired(ctx, u.Role.String())
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	status := &response.TwoFactorStatus{Required: required}
+	if tf != nil && tf.EnabledAt != nil {
+		status.Enabled = true
+		status.EnabledAt = tf.EnabledAt
+		status.RecoveryCodesRemaining = len(tf.RecoveryCodeHashes)
+	}
+	return status, nil
+}
+
+// SetupTwoFactor creates a new secret for the signed-in user. 2FA is not on until
+// EnableTwoFactor confirms a code from it.
+func (c *application) SetupTwoFactor(ctx context.Context, userID string) (*response.TwoFactorSetup, *imhttp.CustomError) {
+	u, err := c.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	return c.startTwoFactorSetup(ctx, u)
+}
+
+// EnableTwoFactor confirms a pending setup with a code from the authenticator and returns
+// the recovery codes.
+func (c *application) EnableTwoFactor(ctx context.Context, userID, code string, actor domain.Actor) (*response.RecoveryCodes, *imhttp.CustomError) {
+	tf, err := c.repo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to enable two-factor authentication", err.Error())
+	}
+	codes, cerr := c.confirmTwoFactorSetup(ctx, tf, userID, code)
+	if cerr != nil {
+		return nil, cerr
+	}
+	c.auditTwoFactor(ctx, actor, userID, domain.AuditActionTwoFactorEnable)
+	return &response.RecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor turns 2FA off after checking the password and a second factor. Users
+// whose role requires 2FA cannot turn it off.
+func (c *application) DisableTwoFactor(ctx context.Context, userID string, req *request.DisableTwoFactorRequest, actor domain.Actor) *imhttp.CustomError {
+	u, err := c.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	required, cerr := c.twoFactorRequired(ctx, u.Role.String())
+	if cerr != nil {
+		return cerr
+	}
+	if required {
+		return imhttp.NewCustomErr(http.StatusForbidden, "Two-factor authentication is required for your role", "two-factor required by role")
+	}
+	if !utils.ComparePassword(u.Password, req.Password) {
+		return imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid password", "Invalid password")
+	}
+	if cerr := c.checkSecondFactor(ctx, userID, req.Code, req.RecoveryCode); cerr != nil {
+		return cerr
+	}
+
+	if _, err := c.repo.DeleteUserTwoFactor(ctx, userID); err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to disable two-factor authentication", err.Error())
+	}
+	c.auditTwoFactor(ctx, actor, userID, domain.AuditActionTwoFactorDisable)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the signed-in user.
+func (c *application) RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*response.RecoveryCodes, *imhttp.CustomError) {
+	if cerr := c.checkSecondFactor(ctx, userID, code, ""); cerr != nil {
+		return nil, cerr
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate recovery codes", err.Error())
+	}
+	if err := c.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to save recovery codes", err.Error())
+	}
+	return &response.RecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// ResetUserTwoFactor removes another user's 2FA, for a lost authenticator. Users whose
+// role requires 2FA enroll again at their next sign-in.
+func (c *application) ResetUserTwoFactor(ctx context.Context, userID string, actor domain.Actor) *imhttp.CustomError {
+	if _, err := c.repo.GetUserByID(ctx, userID); err != nil {
+		return imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	deleted, err := c.repo.DeleteUserTwoFactor(ctx, userID)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to reset two-factor authentication", err.Error())
+	}
+	if !deleted {
+		return imhttp.NewCustomErr(http.StatusNotFound, "Two-factor authentication is not set up for this user", "no two-factor enrollment")
+	}
+	c.auditTwoFactor(ctx, actor, userID, domain.AuditActionTwoFactorReset)
+	return nil
+}
+
+// SetupTwoFactorChallenge creates the secret for a user who must enroll before signing in.
+func (c *application) SetupTwoFactorChallenge(ctx context.Context, challengeToken string) (*response.TwoFactorSetup, *imhttp.CustomError) {
+	ch, u, cerr := c.loginChallenge(ctx, challengeToken)
+	if cerr != nil {
+		return nil, cerr
+	}
+	if ch.Purpose.String() != domain.LoginChallengeEnroll {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Two-factor authentication is already set up", "not an enrollment challenge")
+	}
+	return c.startTwoFactorSetup(ctx, u)
+}
+
+// VerifyTwoFactorChallenge completes a sign-in that needs a second factor. An enrollment
+// challenge also turns 2FA on and returns the new recovery codes with the tokens.
+func (c *application) VerifyTwoFactorChallenge(ctx context.Context, req *request.VerifyTwoFactorChallengeRequest, meta domain.SessionMeta) (*response.GenerateTokenResponse, *imhttp.CustomError) {
+	ch, u, cerr := c.loginChallenge(ctx, req.ChallengeToken)
+	if cerr != nil {
+		return nil, cerr
+	}
+	if cerr := c.checkLoginAddress(ctx, u.Email, meta); cerr != nil {
+		return nil, cerr
+	}
+	if cerr := c.checkAccountLock(ctx, u, meta); cerr != nil {
+		return nil, cerr
+	}
+
+	var recoveryCodes []string
+	if ch.Purpose.String() == domain.LoginChallengeEnroll {
+		if req.Code == "" {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Enter the code from your authenticator app", "code required for enrollment")
+		}
+		tf, err := c.repo.GetUserTwoFactor(ctx, u.ID)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify code", err.Error())
+		}
+		recoveryCodes, cerr = c.confirmTwoFactorSetup(ctx, tf, u.ID, req.Code)
+	} else {
+		cerr = c.checkSecondFactor(ctx, u.ID, req.Code, req.RecoveryCode)
+	}
+	if cerr != nil {
+		if cerr.StatusCode == http.StatusUnauthorized {
+			if err := c.repo.FailLoginChallenge(ctx, ch.ID, config.GetConfig().TwoFactor.MaxChallengeAttempts); err != nil {
+				logger.Get().Error().Err(err).Str("user_id", u.ID).Msg("Failed to record login challenge attempt")
+			}
+			c.loginFailed(ctx, u, meta, domain.LoginFailureInvalidTwoFactor)
+		}
+		return nil, cerr
+	}
+
+	consumed, err := c.repo.ConsumeLoginChallenge(ctx, ch.ID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to complete sign-in", err.Error())
+	}
+	if !consumed {
+		return nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Sign-in has expired, please sign in again", "login challenge already used")
+	}
+	if recoveryCodes != nil {
+		c.auditTwoFactor(ctx, domain.Actor{UserID: u.ID, Role: u.Role.String(), IPAddress: meta.IPAddress}, u.ID, domain.AuditActionTwoFactorEnable)
+	}
+
+	c.loginSucceeded(ctx, u, meta)
+	resp, cerr := c.startSession(ctx, u, meta)
+	if cerr != nil {
+		return nil, cerr
+	}
+	resp.RecoveryCodes = recoveryCodes
+	return resp, nil
+}
+
+// twoFactorChallenge replaces the tokens of a password sign-in with a challenge when the
+// user has 2FA or their role requires it. It returns nil when no second step is needed.
+func (c *application) twoFactorChallenge(ctx context.Context, u *ent.User) (*response.GenerateTokenResponse, *imhttp.CustomError) {
+	tf, err := c.repo.GetUserTwoFactor(ctx, u.ID)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check two-factor authentication", err.Error())
+	}
+
+	purpose := domain.LoginChallengeVerify
+	if tf == nil || tf.EnabledAt == nil {
+		required, cerr := c.twoFactorRequired(ctx, u.Role.String())
+		if cerr != nil || !required {
+			return nil, cerr
+		}
+		purpose = domain.LoginChallengeEnroll
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate challenge", err.Error())
+	}
+	expiresAt := time.Now().Add(config.GetConfig().TwoFactor.ChallengeTTL)
+	if err := c.repo.CreateLoginChallenge(ctx, u.ID, purpose, utils.HashToken(token), expiresAt); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to store challenge", err.Error())
+	}
+
+	return &response.GenerateTokenResponse{
+		Role:                        u.Role.String(),
+		Name:                        u.Name,
+		TwoFactorRequired:           purpose == domain.LoginChallengeVerify,
+		TwoFactorEnrollmentRequired: purpose == domain.LoginChallengeEnroll,
+		ChallengeToken:              token,
+		ChallengeExpiresAt:          &expiresAt,
+	}, nil
+}
+
+// loginChallenge resolves a pending challenge and its user.
+func (c *application) loginChallenge(ctx context.Context, token string) (*ent.LoginChallenge, *ent.User, *imhttp.CustomError) {
+	ch, err := c.repo.GetLoginChallenge(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify challenge", err.Error())
+	}
+	if ch == nil {
+		return nil, nil, imhttp.NewCustomErr(http.StatusUnauthorized, "Sign-in has expired, please sign in again", "login challenge not found")
+	}
+	u, err := c.repo.GetUserByID(ctx, ch.UserID)
+	if err != nil {
+		return nil, nil, imhttp.NewCustomErr(http.StatusUnauthorized, "User not found", err.Error())
+	}
+	if !u.IsActive || u.DeletedAt != nil {
+		return nil, nil, imhttp.NewCustomErr(http.StatusUnauthorized, "User is not active", "User is not active")
+	}
+	return ch, u, nil
+}
+
+// twoFactorRequired reports whether the role of a user requires 2FA.
+func (c *application) twoFactorRequired(ctx context.Context, role string) (bool, *imhttp.CustomError) {
+	ro, err := c.repo.GetRole(ctx, role)
+	if err != nil {
+		return false, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to check two-factor requirement", err.Error())
+	}
+	return ro != nil && ro.TwoFactorRequired, nil
+}
+
+func (c *application) startTwoFactorSetup(ctx context.Context, u *ent.User) (*response.TwoFactorSetup, *imhttp.CustomError) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate secret", err.Error())
+	}
+	encrypted, err := auth.EncryptTOTPSecret(secret)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to encrypt secret", err.Error())
+	}
+	if err := c.repo.StartTwoFactorSetup(ctx, u.ID, encrypted); err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, imhttp.NewCustomErr(http.StatusConflict, "Two-factor authentication is already enabled", err.Error())
+		}
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to start two-factor setup", err.Error())
+	}
+
+	return &response.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(config.GetConfig().TwoFactor.Issuer, u.Email, secret),
+	}, nil
+}
+
+// confirmTwoFactorSetup turns on a pending setup with a first code and returns the new
+// recovery codes.
+func (c *application) confirmTwoFactorSetup(ctx context.Context, tf *ent.UserTwoFactor, userID, code string) ([]string, *imhttp.CustomError) {
+	if tf == nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Set up two-factor authentication first", "no pending two-factor setup")
+	}
+	if tf.EnabledAt != nil {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Two-factor authentication is already enabled", "two-factor already enabled")
+	}
+	step, cerr := validateTOTPCode(tf, code)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate recovery codes", err.Error())
+	}
+	enabled, err := c.repo.EnableTwoFactor(ctx, userID, step, hashes)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to enable two-factor authentication", err.Error())
+	}
+	if !enabled {
+		return nil, imhttp.NewCustomErr(http.StatusConflict, "Two-factor authentication is already enabled", "two-factor setup changed")
+	}
+	return codes, nil
+}
+
+// checkSecondFactor verifies a TOTP code, or failing that a recovery code, against the
+// user's enabled 2FA. Each is accepted only once.
+func (c *application) checkSecondFactor(ctx context.Context, userID, code, recoveryCode string) *imhttp.CustomError {
+	tf, err := c.repo.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify code", err.Error())
+	}
+	if tf == nil || tf.EnabledAt == nil {
+		return imhttp.NewCustomErr(http.StatusBadRequest, "Two-factor authentication is not enabled", "two-factor not enabled")
+	}
+
+	if code != "" {
+		step, cerr := validateTOTPCode(tf, code)
+		if cerr != nil {
+			return cerr
+		}
+		ok, err := c.repo.UseTwoFactorStep(ctx, userID, step)
+		if err != nil {
+			return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify code", err.Error())
+		}
+		if !ok {
+			return imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid code", "code already used")
+		}
+		return nil
+	}
+
+	ok, err := c.repo.UseRecoveryCode(ctx, userID, utils.HashToken(auth.NormalizeRecoveryCode(recoveryCode)))
+	if err != nil {
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify recovery code", err.Error())
+	}
+	if !ok {
+		return imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid recovery code", "recovery code not found")
+	}
+	logger.Get().Info().Str("user_id", userID).Int("remaining", len(tf.RecoveryCodeHashes)-1).Msg("Recovery code used")
+	return nil
+}
+
+func (c *application) auditTwoFactor(ctx context.Context, actor domain.Actor, userID, action string) {
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   userID,
+	})
+}
+
+// validateTOTPCode checks a code against the stored secret and returns its time step.
+func validateTOTPCode(tf *ent.UserTwoFactor, code string) (int64, *imhttp.CustomError) {
+	secret, err := auth.DecryptTOTPSecret(tf.SecretEncrypted)
+	if err != nil {
+		return 0, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to read two-factor secret", err.Error())
+	}
+	step, ok := auth.ValidateTOTP(secret, code, time.Now(), tf.LastUsedStep)
+	if !ok {
+		return 0, imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid code", "invalid totp code")
+	}
+	return step, nil
+}
+
+// newRecoveryCodes returns a fresh set of recovery codes and the hashes to store.
+func newRecoveryCodes() ([]string, []string, error) {
+	n := config.GetConfig().TwoFactor.RecoveryCodeCount
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		code, err := auth.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(code))
+	}
+	return codes, hashes, nil
+}
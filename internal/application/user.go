@@ -0,0 +1,213 @@
+package application
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+func (c *application) ListUsers(ctx context.Context, req *request.ListUsersRequest) (*response.PaginatedResponse, *imhttp.CustomError) {
+	req.Validate()
+	if req.Role != "" && !domain.IsValidUserRole(req.Role) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid role", "unknown role "+req.Role)
+	}
+
+	users, total, err := c.repo.ListUsers(ctx, domain.UserFilter{
+		Role:     req.Role,
+		IsActive: req.IsActive,
+		Search:   req.Search,
+	}, req.GetOffset(), req.GetLimit())
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to list users", err.Error())
+	}
+
+	items := make([]*response.AdminUser, 0, len(users))
+	for _, u := range users {
+		items = append(items, response.GetAdminUserFromEnt(u))
+	}
+	return response.NewPaginatedResponse(items, req.Page, req.PageSize, total), nil
+}
+
+func (c *application) GetUser(ctx context.Context, id string) (*response.AdminUser, *imhttp.CustomError) {
+	u, err := c.repo.GetUserForAdmin(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	return response.GetAdminUserFromEnt(u), nil
+}
+
+func (c *application) CreateUser(ctx context.Context, req *request.CreateUserRequest, actor domain.Actor) (*response.UserCredentials, *imhttp.CustomError) {
+	if !domain.IsValidUserRole(req.Role) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid role", "unknown role "+req.Role)
+	}
+
+	password, temporary, cerr := passwordOrGenerated(req.Password)
+	if cerr != nil {
+		return nil, cerr
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to hash password", err.Error())
+	}
+
+	u, err := c.repo.CreateUserByAdmin(ctx, domain.NewUser{
+		Username:        req.Username,
+		Email:           req.Email,
+		Name:            req.Name,
+		PhoneNumber:     req.PhoneNumber,
+		Role:            req.Role,
+		PasswordHash:    hash,
+		IsVerified:      req.IsVerified,
+		ParentID:        req.ParentID,
+		CreatedByUserID: actor.UserID,
+	})
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, imhttp.NewCustomErr(http.StatusConflict, "A user with this email or username already exists", err.Error())
+		}
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to create user", err.Error())
+	}
+
+	created := response.GetAdminUserFromEnt(u)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   u.ID,
+		After:      created,
+	})
+	return &response.UserCredentials{User: created, TemporaryPassword: temporary}, nil
+}
+
+func (c *application) UpdateUser(ctx context.Context, id string, req *request.UpdateUserRequest, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError) {
+	return c.changeUser(ctx, id, domain.AuditActionUpdate, domain.UserUpdate{
+		Username:    req.Username,
+		Email:       req.Email,
+		Name:        req.Name,
+		PhoneNumber: req.PhoneNumber,
+		IsVerified:  req.IsVerified,
+		ParentID:    req.ParentID,
+	}, actor)
+}
+
+// ChangeUserRole moves a user to another role and ends their sessions. Access tokens
+// issued for the old role are rejected by the auth middleware, so the user has to sign in
+// again. Superadmins cannot change their own role, so the last superadmin cannot demote
+// themselves by accident.
+func (c *application) ChangeUserRole(ctx context.Context, id string, req *request.ChangeUserRoleRequest, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError) {
+	if !domain.IsValidUserRole(req.Role) {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid role", "unknown role "+req.Role)
+	}
+	if id == actor.UserID {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "You cannot change your own role", "cannot change own role")
+	}
+	u, cerr := c.changeUser(ctx, id, domain.AuditActionRoleChange, domain.UserUpdate{Role: &req.Role}, actor)
+	if cerr != nil {
+		return nil, cerr
+	}
+	c.endUserSessions(ctx, id, domain.SessionRevokedRoleChange)
+	return u, nil
+}
+
+// DeactivateUser blocks sign-in and ends the user's sessions; their access tokens are
+// rejected by the auth middleware from then on.
+func (c *application) DeactivateUser(ctx context.Context, id string, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError) {
+	if id == actor.UserID {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "You cannot deactivate yourself", "cannot deactivate self")
+	}
+	active := false
+	u, cerr := c.changeUser(ctx, id, domain.AuditActionDeactivate, domain.UserUpdate{IsActive: &active}, actor)
+	if cerr != nil {
+		return nil, cerr
+	}
+	c.endUserSessions(ctx, id, domain.SessionRevokedUserInactive)
+	return u, nil
+}
+
+func (c *application) ReactivateUser(ctx context.Context, id string, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError) {
+	active := true
+	return c.changeUser(ctx, id, domain.AuditActionReactivate, domain.UserUpdate{IsActive: &active}, actor)
+}
+
+// ResetUserPassword sets a new password and ends the user's sessions. The password is
+// never written to the audit log.
+func (c *application) ResetUserPassword(ctx context.Context, id string, req *request.ResetUserPasswordRequest, actor domain.Actor) (*response.UserCredentials, *imhttp.CustomError) {
+	password, temporary, cerr := passwordOrGenerated(req.Password)
+	if cerr != nil {
+		return nil, cerr
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to hash password", err.Error())
+	}
+
+	u, cerr := c.changeUser(ctx, id, domain.AuditActionPasswordReset, domain.UserUpdate{PasswordHash: &hash}, actor)
+	if cerr != nil {
+		return nil, cerr
+	}
+	c.endUserSessions(ctx, id, domain.SessionRevokedPasswordReset)
+	return &response.UserCredentials{User: u, TemporaryPassword: temporary}, nil
+}
+
+// changeUser applies an admin change and audits the user before and after it.
+func (c *application) changeUser(ctx context.Context, id, action string, update domain.UserUpdate, actor domain.Actor) (*response.AdminUser, *imhttp.CustomError) {
+	before, err := c.repo.GetUserForAdmin(ctx, id)
+	if err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusNotFound, "User not found", err.Error())
+	}
+	if update.Email != nil && *update.Email != before.Email {
+		verified := false
+		update.IsEmailVerified = &verified
+	}
+
+	after, err := c.repo.UpdateUserByAdmin(ctx, id, update, actor.UserID)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, imhttp.NewCustomErr(http.StatusConflict, "A user with this email or username already exists", err.Error())
+		}
+		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update user", err.Error())
+	}
+	c.access.forget(id)
+
+	updated := response.GetAdminUserFromEnt(after)
+	c.audit(ctx, domain.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		EntityType: domain.AuditEntityUser,
+		EntityID:   id,
+		Before:     response.GetAdminUserFromEnt(before),
+		After:      updated,
+	})
+	return updated, nil
+}
+
+// endUserSessions revokes every refresh token of a user. Failures are logged, since the
+// change that triggered it has already been saved.
+func (c *application) endUserSessions(ctx context.Context, userID, reason string) {
+	if err := c.repo.RevokeUserRefreshTokens(ctx, userID, reason); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to end user sessions")
+	}
+}
+
+// passwordOrGenerated returns the requested password, or a generated one that is also
+// returned as the temporary password to hand to the user.
+func passwordOrGenerated(password string) (string, string, *imhttp.CustomError) {
+	if password != "" {
+		if cerr := validatePassword(password); cerr != nil {
+			return "", "", cerr
+		}
+		return password, "", nil
+	}
+	generated, err := utils.GenerateSecureToken(12)
+	if err != nil {
+		return "", "", imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to generate password", err.Error())
+	}
+	return generated, generated, nil
+}
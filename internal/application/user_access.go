@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/config"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+// userAccessCache holds whether users are active and their current role, so token checks
+// do not query the database on every request. Admin changes on this instance clear the
+// user's entry; other instances pick them up when entries expire.
+type userAccessCache struct {
+	mu      sync.RWMutex
+	entries map[string]userAccessEntry
+}
+
+type userAccessEntry struct {
+	exists    bool
+	active    bool
+	role      string
+	expiresAt time.Time
+}
+
+func newUserAccessCache() *userAccessCache {
+	return &userAccessCache{entries: make(map[string]userAccessEntry)}
+}
+
+func (s *userAccessCache) get(userID string) (userAccessEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[userID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return userAccessEntry{}, false
+	}
+	return entry, true
+}
+
+func (s *userAccessCache) set(userID string, entry userAccessEntry, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry.expiresAt = time.Now().Add(ttl)
+	s.entries[userID] = entry
+}
+
+func (s *userAccessCache) forget(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, userID)
+}
+
+// ValidateUserClaims rejects tokens of users who were deleted or deactivated, or whose
+// role changed since the token was issued, so those changes apply before it expires.
+func (c *application) ValidateUserClaims(ctx context.Context, claims *auth.Claims) *imhttp.CustomError {
+	entry, ok := c.access.get(claims.UserID)
+	if !ok {
+		u, err := c.repo.GetUserAccess(ctx, claims.UserID)
+		if err != nil {
+			return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to validate user", err.Error())
+		}
+		if u != nil {
+			entry = userAccessEntry{exists: true, active: u.IsActive, role: u.Role.String()}
+		}
+		c.access.set(claims.UserID, entry, config.GetConfig().Permissions.CacheTTL)
+	}
+
+	switch {
+	case !entry.exists:
+		return imhttp.NewCustomErr(http.StatusUnauthorized, "User not found", "user not found")
+	case !entry.active:
+		return imhttp.NewCustomErr(http.StatusUnauthorized, "User is deactivated", "user is inactive")
+	case entry.role != claims.Role:
+		return imhttp.NewCustomErr(http.StatusUnauthorized, "Your role has changed, please sign in again", "token role is stale")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// BuyerAudience marks buyer tokens. Internal token validation rejects it.
+const BuyerAudience = "buyer"
+
+// BuyerClaims are the claims of a buyer token. They carry no role, so a buyer can never
+// satisfy an internal role check.
+type BuyerClaims struct {
+	BuyerID string `json:"buyer_id"`
+	Phone   string `json:"phone"`
+	jwt.RegisteredClaims
+}
+
+// buyerSecret is the key for buyer tokens, derived from the internal secret when no
+// dedicated one is configured.
+func buyerSecret() []byte {
+	cfg := config.GetConfig()
+	if cfg.Buyer.JWTSecret != "" {
+		return []byte(cfg.Buyer.JWTSecret)
+	}
+	sum := sha256.Sum256([]byte("buyer:" + cfg.AuthSecret))
+	return []byte(hex.EncodeToString(sum[:]))
+}
+
+func GenerateBuyerToken(buyerID, phone string) (string, time.Time, error) {
+	expirationTime := time.Now().Add(config.GetConfig().Buyer.TokenTTL)
+
+	claims := &BuyerClaims{
+		BuyerID: buyerID,
+		Phone:   phone,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{BuyerAudience},
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(buyerSecret())
+	return signed, expirationTime, err
+}
+
+func ValidateBuyerToken(tokenString string) (*BuyerClaims, error) {
+	claims := &BuyerClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return buyerSecret(), nil
+	}, jwt.WithAudience(BuyerAudience), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid || claims.BuyerID == "" {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
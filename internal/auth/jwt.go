@@ -2,6 +2,7 @@ package auth
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/VI-IM/im_backend_go/internal/config"
@@ -13,11 +14,14 @@ type Claims struct {
 	IsAdmin bool   `json:"is_admin"`
 	Role    string `json:"role"`
 	Phone   string `json:"phone"`
+	// APIKeyID is set when the request was authenticated with an API key rather than a
+	// token. It is never read from a token.
+	APIKeyID string `json:"-"`
 	jwt.RegisteredClaims
 }
 
 func GenerateToken(userID string, isAdmin bool, role string, phone string) (string, error) {
-	expirationTime := time.Now().Add(time.Duration(config.GetConfig().ExpiresInDuration) * time.Hour)
+	expirationTime := time.Now().Add(config.GetConfig().ExpiresInDuration)
 
 	claims := &Claims{
 		UserID:  userID,
@@ -29,15 +33,12 @@ func GenerateToken(userID string, isAdmin bool, role string, phone string) (stri
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(config.GetConfig().AuthSecret))
+	return signToken(claims)
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.GetConfig().AuthSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, verificationKey)
 
 	if err != nil {
 		return nil, err
@@ -47,5 +48,11 @@ func ValidateToken(tokenString string) (*Claims, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	// Buyer tokens are signed with a different key; this also guards against a
+	// misconfiguration where both keys are the same.
+	if slices.Contains(claims.Audience, BuyerAudience) {
+		return nil, errors.New("invalid token")
+	}
+
 	return claims, nil
 }
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingKey is one key of the set tokens are signed and verified with. Retired keys
+// may have no private half; they only verify tokens issued before the rotation.
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// keySet holds the configured keys. Tokens are signed with active and verified with the
+// key named by their "kid" header, so a new key can be published before it is used and
+// an old one kept until the tokens it signed have expired.
+type keySet struct {
+	active *signingKey
+	keys   map[string]*signingKey
+}
+
+// signingKeys is nil when no keys are configured, in which case tokens are signed with
+// AUTH_JWT_SECRET.
+var signingKeys *keySet
+
+// LoadSigningKeys reads the keys in JWT_SIGNING_KEYS. It must run after the
+// configuration is loaded and before tokens are issued.
+func LoadSigningKeys() error {
+	if err := checkSecrets(); err != nil {
+		return err
+	}
+
+	cfg := config.GetConfig().JWTConfig
+	if len(cfg.SigningKeys) == 0 {
+		signingKeys = nil
+		return nil
+	}
+
+	set := &keySet{keys: make(map[string]*signingKey, len(cfg.SigningKeys))}
+	for id, path := range cfg.SigningKeys {
+		key, err := readSigningKey(id, path)
+		if err != nil {
+			return fmt.Errorf("signing key %q: %w", id, err)
+		}
+		set.keys[id] = key
+	}
+
+	active, ok := set.keys[cfg.SigningKeyID]
+	if !ok {
+		return fmt.Errorf("JWT_SIGNING_KEY_ID %q is not one of JWT_SIGNING_KEYS", cfg.SigningKeyID)
+	}
+	if active.private == nil {
+		return fmt.Errorf("signing key %q has no private key", cfg.SigningKeyID)
+	}
+	set.active = active
+
+	signingKeys = set
+	return nil
+}
+
+// checkSecrets refuses to start when a key would be derived from an empty
+// AUTH_JWT_SECRET: access tokens without JWT_SIGNING_KEYS, buyer tokens without
+// BUYER_JWT_SECRET and TOTP secrets without TWO_FACTOR_ENCRYPTION_KEY all fall back to it.
+func checkSecrets() error {
+	cfg := config.GetConfig()
+	if cfg.AuthSecret != "" {
+		return nil
+	}
+	var missing []string
+	if len(cfg.JWTConfig.SigningKeys) == 0 {
+		missing = append(missing, "JWT_SIGNING_KEYS")
+	}
+	if cfg.Buyer.JWTSecret == "" {
+		missing = append(missing, "BUYER_JWT_SECRET")
+	}
+	if cfg.TwoFactor.EncryptionKey == "" {
+		missing = append(missing, "TWO_FACTOR_ENCRYPTION_KEY")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("AUTH_JWT_SECRET is not set, so %s must be", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// SigningKeysConfigured reports whether tokens are signed with asymmetric keys.
+func SigningKeysConfigured() bool {
+	return signingKeys != nil
+}
+
+func readSigningKey(id, path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key := &signingKey{id: id}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := parsed.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key")
+		}
+		key.private = signer
+		key.public = signer.Public()
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.private = parsed
+		key.public = parsed.Public()
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.public = parsed
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must be at least 2048 bits")
+		}
+		key.method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("only RSA and Ed25519 keys are supported")
+	}
+	return key, nil
+}
+
+// signToken signs claims with the active key, or with AUTH_JWT_SECRET when no keys are
+// configured.
+func signToken(claims jwt.Claims) (string, error) {
+	if signingKeys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(config.GetConfig().AuthSecret))
+	}
+	key := signingKeys.active
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
+
+// verificationKey resolves the key a token claims to be signed with. Only the algorithm
+// of that key is accepted, so a public key can never be used as an HMAC secret.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	if signingKeys == nil {
+		if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(config.GetConfig().AuthSecret), nil
+	}
+	id, _ := token.Header["kid"].(string)
+	key, ok := signingKeys.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", id)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.public, nil
+}
+
+// JWK is the public half of a signing key, as published in the JWKS document.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	// RSA
+	Modulus  string `json:"n,omitempty"`
+	Exponent string `json:"e,omitempty"`
+	// Ed25519
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS is the JSON Web Key Set of the public keys tokens may be signed with.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeys returns every configured key, retired ones included, sorted by ID. It is
+// empty when tokens are signed with AUTH_JWT_SECRET, which must never be published.
+func PublicKeys() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if signingKeys == nil {
+		return jwks
+	}
+
+	for _, key := range signingKeys.keys {
+		jwk := JWK{Use: "sig", Algorithm: key.method.Alg(), KeyID: key.id}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.Modulus = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID })
+	return jwks
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"fmt"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+type MailerInterface interface {
+	Send(to, subject, body string) error
+}
+
+// SMTPMailer sends plain-text email through an SMTP relay.
+type SMTPMailer struct {
+	config config.Mail
+}
+
+// LogMailer only logs outgoing email. It is used when no SMTP host is configured.
+type LogMailer struct{}
+
+// FileMailer writes each outgoing email to a file, so links in it can be followed during
+// development and read back in tests.
+type FileMailer struct {
+	dir string
+	mu  sync.Mutex
+	seq int
+}
+
+// Mail drivers selected with MAIL_DRIVER.
+const (
+	MailDriverSMTP = "smtp"
+	MailDriverFile = "file"
+	MailDriverLog  = "log"
+)
+
+func NewMailer(cfg config.Mail) MailerInterface {
+	driver := cfg.Driver
+	if driver == "" {
+		driver = MailDriverSMTP
+		if cfg.SMTPHost == "" {
+			driver = MailDriverLog
+		}
+	}
+
+	switch driver {
+	case MailDriverFile:
+		logger.Get().Info().Str("dir", cfg.OutboxDir).Msg("Outgoing email will be written to files")
+		return NewFileMailer(cfg.OutboxDir)
+	case MailDriverSMTP:
+		if cfg.SMTPHost != "" {
+			return &SMTPMailer{config: cfg}
+		}
+	}
+	logger.Get().Warn().Msg("SMTP is not configured, outgoing email will only be logged")
+	return &LogMailer{}
+}
+
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{dir: dir}
+}
+
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	addr := fmt.Sprintf("%s:%d", m.config.SMTPHost, m.config.SMTPPort)
+	var auth smtp.Auth
+	if m.config.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", m.config.SMTPUsername, m.config.SMTPPassword, m.config.SMTPHost)
+	}
+
+	message := strings.Join([]string{
+		"From: " + m.config.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(addr, auth, m.config.From, []string{to}, []byte(message)); err != nil {
+		logger.Get().Error().Err(err).Str("to", to).Msg("Failed to send email")
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	logger.Get().Info().Str("to", to).Str("subject", subject).Msg("Email sent successfully")
+	return nil
+}
+
+func (m *LogMailer) Send(to, subject, body string) error {
+	logger.Get().Info().Str("to", to).Str("subject", subject).Msg("Email not sent, SMTP is not configured")
+	return nil
+}
+
+func (m *FileMailer) Send(to, subject, body string) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create outbox: %w", err)
+	}
+
+	m.mu.Lock()
+	m.seq++
+	name := fmt.Sprintf("%s-%04d.eml", time.Now().Format("20060102T150405.000"), m.seq)
+	m.mu.Unlock()
+
+	message := strings.Join([]string{
+		"To: " + to,
+		"Subject: " + subject,
+		"",
+		body,
+	}, "\n")
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, []byte(message), 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+
+	logger.Get().Info().Str("to", to).Str("subject", subject).Str("file", path).Msg("Email written to outbox")
+	return nil
+}
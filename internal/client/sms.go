@@ -17,6 +17,7 @@ type SMSClient struct {
 
 type SMSClientInterface interface {
 	SendOTP(phone, otp string) error
+	SendMessage(phone, message string) error
 }
 
 type SMSRequest struct {
@@ -45,6 +46,10 @@ func (s *SMSClient) SendOTP(phone, otp string) error {
 		Str("phone", phone).
 		Msg("Sending OTP SMS")
 
+	return s.SendMessage(phone, message)
+}
+
+func (s *SMSClient) SendMessage(phone, message string) error {
 	smsRequest := SMSRequest{
 		Mobile:  phone,
 		Message: message,
@@ -19,6 +19,22 @@ type (
 		Logger
 		S3
 		CRM
+		Rera
+		Deletion
+		Slug
+		PropertySearch
+		Partner
+		Events
+		Trending
+		Similarity
+		Mail
+		Account
+		TwoFactor
+		Login
+		SavedSearch
+		Buyer
+		Finance
+		Permissions
 	}
 
 	Server struct {
@@ -27,6 +43,11 @@ type (
 		BaseURL          string `envconfig:"BASE_URL" default:"https://investmango.com"`
 		StaticAssetsURL  string `envconfig:"STATIC_ASSETS_URL"`
 		FrontendProxyURL string `envconfig:"FRONTEND_PROXY_URL"`
+		// TrustedProxies lists the addresses or CIDR ranges of the load balancers in front
+		// of the service. X-Forwarded-For is only read from requests they forward.
+		TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
+		// ShutdownTimeout is how long in-flight requests get to finish on SIGTERM.
+		ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 	}
 	Database struct {
 		DB_Port int    `envconfig:"DB_PORT"`
@@ -37,6 +58,15 @@ type (
 		AuthSecret        string        `envconfig:"AUTH_JWT_SECRET"`
 		ExpiresIn         string        `envconfig:"JWT_EXPIRATION_DURATION" default:"24h"`
 		ExpiresInDuration time.Duration `envconfig:"-"`
+		// SigningKeys maps key IDs to PEM files of RSA or Ed25519 keys that sign and verify
+		// access tokens, as "kid:path,kid:path". A file holding only a public key still
+		// verifies tokens, for retiring a key. When empty, tokens are signed with
+		// AUTH_JWT_SECRET.
+		SigningKeys map[string]string `envconfig:"JWT_SIGNING_KEYS"`
+		// SigningKeyID is the key new tokens are signed with.
+		SigningKeyID string `envconfig:"JWT_SIGNING_KEY_ID"`
+		// RefreshTokenTTL is how long a session lasts without being refreshed.
+		RefreshTokenTTL time.Duration `envconfig:"REFRESH_TOKEN_TTL" default:"168h"`
 	}
 	Logger struct {
 		Level zerolog.Level `envconfig:"LOG_LEVEL" default:"1"`
@@ -56,6 +86,140 @@ type (
 		Enabled    bool          `envconfig:"CRM_ENABLED" default:"true"`
 		MaxRetries int           `envconfig:"CRM_MAX_RETRIES" default:"3"`
 	}
+
+	Rera struct {
+		ExpiryWarningDays int           `envconfig:"RERA_EXPIRY_WARNING_DAYS" default:"90"`
+		CheckInterval     time.Duration `envconfig:"RERA_CHECK_INTERVAL" default:"24h"`
+	}
+
+	Deletion struct {
+		RestoreWindow time.Duration `envconfig:"DELETION_RESTORE_WINDOW" default:"720h"`
+		PurgeInterval time.Duration `envconfig:"DELETION_PURGE_INTERVAL" default:"1h"`
+	}
+
+	Slug struct {
+		SyncInterval time.Duration `envconfig:"SLUG_SYNC_INTERVAL" default:"24h"`
+	}
+
+	PropertySearch struct {
+		AttributesSyncInterval time.Duration `envconfig:"PROPERTY_ATTRIBUTES_SYNC_INTERVAL" default:"6h"`
+	}
+
+	Partner struct {
+		InvitationTTL  time.Duration `envconfig:"PARTNER_INVITATION_TTL" default:"72h"`
+		InvitationPath string        `envconfig:"PARTNER_INVITATION_PATH" default:"/accept-invitation"`
+	}
+
+	Events struct {
+		FlushInterval  time.Duration `envconfig:"EVENTS_FLUSH_INTERVAL" default:"10s"`
+		BatchSize      int           `envconfig:"EVENTS_BATCH_SIZE" default:"500"`
+		MaxBuffered    int           `envconfig:"EVENTS_MAX_BUFFERED" default:"20000"`
+		RollupInterval time.Duration `envconfig:"EVENTS_ROLLUP_INTERVAL" default:"1h"`
+	}
+
+	Trending struct {
+		Window             time.Duration `envconfig:"TRENDING_WINDOW" default:"720h"`
+		HalfLife           time.Duration `envconfig:"TRENDING_HALF_LIFE" default:"168h"`
+		RecomputeInterval  time.Duration `envconfig:"TRENDING_RECOMPUTE_INTERVAL" default:"1h"`
+		LeadWeight         float64       `envconfig:"TRENDING_LEAD_WEIGHT" default:"3"`
+		VerifiedLeadWeight float64       `envconfig:"TRENDING_VERIFIED_LEAD_WEIGHT" default:"5"`
+		ViewWeight         float64       `envconfig:"TRENDING_VIEW_WEIGHT" default:"0.1"`
+	}
+
+	Similarity struct {
+		CityWeight           float64       `envconfig:"SIMILARITY_CITY_WEIGHT" default:"3"`
+		LocalityWeight       float64       `envconfig:"SIMILARITY_LOCALITY_WEIGHT" default:"4"`
+		ProjectTypeWeight    float64       `envconfig:"SIMILARITY_PROJECT_TYPE_WEIGHT" default:"2"`
+		StatusWeight         float64       `envconfig:"SIMILARITY_STATUS_WEIGHT" default:"1"`
+		PriceWeight          float64       `envconfig:"SIMILARITY_PRICE_WEIGHT" default:"3"`
+		ConfigurationWeight  float64       `envconfig:"SIMILARITY_CONFIGURATION_WEIGHT" default:"2"`
+		DeveloperWeight      float64       `envconfig:"SIMILARITY_DEVELOPER_WEIGHT" default:"0.5"`
+		ExcludeSameDeveloper bool          `envconfig:"SIMILARITY_EXCLUDE_SAME_DEVELOPER" default:"false"`
+		CacheTTL             time.Duration `envconfig:"SIMILARITY_CACHE_TTL" default:"6h"`
+	}
+
+	Mail struct {
+		SMTPHost     string `envconfig:"SMTP_HOST"`
+		SMTPPort     int    `envconfig:"SMTP_PORT" default:"587"`
+		SMTPUsername string `envconfig:"SMTP_USERNAME"`
+		SMTPPassword string `envconfig:"SMTP_PASSWORD"`
+		From         string `envconfig:"MAIL_FROM" default:"no-reply@investmango.com"`
+		// Driver is smtp, file or log. When empty, smtp is used if SMTP_HOST is set.
+		Driver string `envconfig:"MAIL_DRIVER"`
+		// OutboxDir is where the file driver writes each email, for development and tests.
+		OutboxDir string `envconfig:"MAIL_OUTBOX_DIR" default:"./tmp/outbox"`
+	}
+
+	Account struct {
+		PasswordResetTTL     time.Duration `envconfig:"ACCOUNT_PASSWORD_RESET_TTL" default:"1h"`
+		EmailVerificationTTL time.Duration `envconfig:"ACCOUNT_EMAIL_VERIFICATION_TTL" default:"48h"`
+		// TokenResendInterval is the minimum time between two emails of the same kind.
+		TokenResendInterval   time.Duration `envconfig:"ACCOUNT_TOKEN_RESEND_INTERVAL" default:"1m"`
+		PasswordResetPath     string        `envconfig:"ACCOUNT_PASSWORD_RESET_PATH" default:"/reset-password"`
+		EmailVerificationPath string        `envconfig:"ACCOUNT_EMAIL_VERIFICATION_PATH" default:"/verify-email"`
+		PasswordMinLength     int           `envconfig:"ACCOUNT_PASSWORD_MIN_LENGTH" default:"8"`
+	}
+
+	TwoFactor struct {
+		Issuer string `envconfig:"TWO_FACTOR_ISSUER" default:"InvestMango"`
+		// EncryptionKey encrypts stored TOTP secrets; when empty one is derived from
+		// AUTH_JWT_SECRET.
+		EncryptionKey        string        `envconfig:"TWO_FACTOR_ENCRYPTION_KEY"`
+		ChallengeTTL         time.Duration `envconfig:"TWO_FACTOR_CHALLENGE_TTL" default:"5m"`
+		MaxChallengeAttempts int           `envconfig:"TWO_FACTOR_MAX_CHALLENGE_ATTEMPTS" default:"5"`
+		RecoveryCodeCount    int           `envconfig:"TWO_FACTOR_RECOVERY_CODE_COUNT" default:"10"`
+	}
+
+	Login struct {
+		// MaxFailedAttempts failed sign-ins lock an account for LockoutDuration.
+		MaxFailedAttempts int           `envconfig:"LOGIN_MAX_FAILED_ATTEMPTS" default:"10"`
+		LockoutDuration   time.Duration `envconfig:"LOGIN_LOCKOUT_DURATION" default:"15m"`
+		// After DelayAfterFailures failures each attempt must wait BaseDelay, doubling per
+		// further failure up to MaxDelay.
+		DelayAfterFailures int           `envconfig:"LOGIN_DELAY_AFTER_FAILURES" default:"3"`
+		BaseDelay          time.Duration `envconfig:"LOGIN_BASE_DELAY" default:"2s"`
+		MaxDelay           time.Duration `envconfig:"LOGIN_MAX_DELAY" default:"1m"`
+		// IPMaxFailures failed sign-ins from one address within IPWindow block it until
+		// the window moves on.
+		IPMaxFailures int           `envconfig:"LOGIN_IP_MAX_FAILURES" default:"30"`
+		IPWindow      time.Duration `envconfig:"LOGIN_IP_WINDOW" default:"15m"`
+	}
+
+	SavedSearch struct {
+		AlertInterval           time.Duration `envconfig:"SAVED_SEARCH_ALERT_INTERVAL" default:"1h"`
+		VerificationTTL         time.Duration `envconfig:"SAVED_SEARCH_VERIFICATION_TTL" default:"15m"`
+		VerificationMaxAttempts int           `envconfig:"SAVED_SEARCH_VERIFICATION_MAX_ATTEMPTS" default:"5"`
+		MaxPerContact           int           `envconfig:"SAVED_SEARCH_MAX_PER_CONTACT" default:"10"`
+		MaxAlertItems           int           `envconfig:"SAVED_SEARCH_MAX_ALERT_ITEMS" default:"10"`
+	}
+
+	Buyer struct {
+		// JWTSecret signs buyer tokens. It must differ from AUTH_JWT_SECRET so a buyer
+		// token never validates on internal routes; when empty one is derived from it.
+		JWTSecret         string        `envconfig:"BUYER_JWT_SECRET"`
+		TokenTTL          time.Duration `envconfig:"BUYER_TOKEN_TTL" default:"720h"`
+		OTPTTL            time.Duration `envconfig:"BUYER_OTP_TTL" default:"5m"`
+		OTPMaxAttempts    int           `envconfig:"BUYER_OTP_MAX_ATTEMPTS" default:"5"`
+		OTPResendInterval time.Duration `envconfig:"BUYER_OTP_RESEND_INTERVAL" default:"30s"`
+		MaxComparisonSize int           `envconfig:"BUYER_MAX_COMPARISON_SIZE" default:"4"`
+	}
+
+	Permissions struct {
+		// CacheTTL bounds how long a role definition edited, or a user deactivated or moved to
+		// another role, on another instance takes to apply.
+		CacheTTL time.Duration `envconfig:"PERMISSIONS_CACHE_TTL" default:"1m"`
+	}
+
+	Finance struct {
+		DefaultInterestRate float64 `envconfig:"FINANCE_DEFAULT_INTEREST_RATE" default:"8.5"` // annual, percent
+		DefaultTenureYears  int     `envconfig:"FINANCE_DEFAULT_TENURE_YEARS" default:"20"`
+		MaxTenureYears      int     `envconfig:"FINANCE_MAX_TENURE_YEARS" default:"30"`
+		FOIR                float64 `envconfig:"FINANCE_FOIR" default:"0.5"`    // share of income lenders allow for all EMIs
+		MaxLTV              float64 `envconfig:"FINANCE_MAX_LTV" default:"0.8"` // share of the price lenders finance
+		// StampDutyTableFile is a JSON object of state name to rates; built-in rates are
+		// used when empty.
+		StampDutyTableFile string `envconfig:"FINANCE_STAMP_DUTY_TABLE_FILE"`
+	}
 )
 
 func LoadConfig() error {
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// APIKeyPrefix starts every API key, so leaked keys are easy to recognise.
+const APIKeyPrefix = "imk_"
+
+// RoleAPIKey is the role on the claims of a request authenticated with an API key. It has
+// no role definition; the key's own permissions apply.
+const RoleAPIKey = "api_key"
+
+// NewAPIKey holds the stored parts of a generated API key.
+type NewAPIKey struct {
+	Name            string
+	Prefix          string
+	KeyHash         string
+	Permissions     []string
+	AllowedIPs      []string
+	ExpiresAt       *time.Time
+	CreatedByUserID string
+}
@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// Actor is who is behind a change: a signed-in user, an API key, or an anonymous
+// visitor known only by address. Permissions holds what the actor was granted on
+// permission-guarded routes, and is not recorded in the audit log.
+type Actor struct {
+	UserID      string
+	Role        string
+	APIKeyID    string
+	IPAddress   string
+	Permissions map[string]bool
+}
+
+// Audited entity types.
+const (
+	AuditEntityUser             = "user"
+	AuditEntityAPIKey           = "api_key"
+	AuditEntityProject          = "project"
+	AuditEntityProperty         = "property"
+	AuditEntityBlog             = "blog"
+	AuditEntityCustomSearchPage = "custom_search_page"
+	AuditEntityAmenityCategory  = "amenity_category"
+	AuditEntityStaticSiteData   = "static_site_data"
+	AuditEntityLead             = "lead"
+	AuditEntityRole             = "role"
+)
+
+// Audited actions.
+const (
+	AuditActionCreate           = "create"
+	AuditActionUpdate           = "update"
+	AuditActionDelete           = "delete"
+	AuditActionRestore          = "restore"
+	AuditActionImport           = "import"
+	AuditActionApprove          = "approve"
+	AuditActionReject           = "reject"
+	AuditActionVerify           = "verify"
+	AuditActionResendOTP        = "resend_otp"
+	AuditActionInvite           = "invite"
+	AuditActionAcceptInvitation = "accept_invitation"
+	AuditActionRoleChange       = "role_change"
+	AuditActionDeactivate       = "deactivate"
+	AuditActionReactivate       = "reactivate"
+	AuditActionUnlock           = "unlock"
+	AuditActionRevoke           = "revoke"
+	AuditActionPasswordReset    = "password_reset"
+	AuditActionTwoFactorEnable  = "two_factor_enable"
+	AuditActionTwoFactorDisable = "two_factor_disable"
+	AuditActionTwoFactorReset   = "two_factor_reset"
+)
+
+// AuditEntry is one change to record. Before and After are snapshots that are stored as
+// JSON; either is nil when the entity did not exist on that side of the change.
+type AuditEntry struct {
+	Actor      Actor
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     interface{}
+	After      interface{}
+}
+
+// AuditLogFilter narrows the audit log. Empty values do not filter; From and To bound
+// the time of the change, inclusive.
+type AuditLogFilter struct {
+	ActorUserID string
+	EntityType  string
+	EntityID    string
+	Action      string
+	From        *time.Time
+	To          *time.Time
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Entity types a buyer can shortlist, kept in sync with the BuyerShortlist ent schema.
+const (
+	ShortlistProject  = "project"
+	ShortlistProperty = "property"
+)
+
+func IsValidShortlistEntity(entityType string) bool {
+	return entityType == ShortlistProject || entityType == ShortlistProperty
+}
+
+// ShortlistItem is a shortlisted listing joined with the fields a buyer sees in the list.
+// Available is false once the listing is deleted or no longer exists.
+type ShortlistItem struct {
+	EntityType    string
+	EntityID      string
+	Name          string
+	Slug          string
+	Available     bool
+	ShortlistedAt time.Time
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Entity types that can be soft-deleted, kept in sync with the DeletionRecord ent schema.
+const (
+	DeletionEntityProject  = "project"
+	DeletionEntityProperty = "property"
+)
+
+// RedirectReasonPurge marks redirects written when a deleted entity is purged.
+const RedirectReasonPurge = "purge"
+
+type DeletionRecord struct {
+	EntityType          string
+	EntityID            string
+	Slug                string
+	RedirectTo          string
+	CascadedPropertyIDs []string
+	DeletedBy           string
+	RestoreUntil        time.Time
+}
+
+type Redirect struct {
+	FromPath   string
+	ToPath     string
+	StatusCode int
+	Reason     string
+}
+
+// ProjectPath is the public path of a project page.
+func ProjectPath(slug string) string {
+	return "/" + slug
+}
+
+// PropertyPath is the public path of a property page in the sale family. Redirects for
+// properties are recorded against this path whatever the listing type.
+func PropertyPath(slug string) string {
+	return PropertySalePathPrefix + slug
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// Listing entity types that events are recorded against.
+const (
+	EventEntityProject  = "project"
+	EventEntityProperty = "property"
+)
+
+// Listing event types, kept in sync with the ListingEvent ent schema.
+const (
+	EventPageView         = "page_view"
+	EventGalleryOpen      = "gallery_open"
+	EventBrochureDownload = "brochure_download"
+	EventCallClick        = "call_click"
+	EventWhatsAppClick    = "whatsapp_click"
+)
+
+// ListingEvent is one visitor interaction with a project or property page.
+type ListingEvent struct {
+	EntityType string
+	EntityID   string
+	EventType  string
+	SessionID  string
+	Source     string
+	OccurredAt time.Time
+}
+
+func IsValidEventEntity(entityType string) bool {
+	return entityType == EventEntityProject || entityType == EventEntityProperty
+}
+
+func IsValidEventType(eventType string) bool {
+	switch eventType {
+	case EventPageView, EventGalleryOpen, EventBrochureDownload, EventCallClick, EventWhatsAppClick:
+		return true
+	}
+	return false
+}
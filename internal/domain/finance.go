@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// LoanYear summarises one year of a loan's amortization schedule.
+type LoanYear struct {
+	Year             int
+	PrincipalPaid    float64
+	InterestPaid     float64
+	ClosingPrincipal float64
+}
+
+// LoanSummary is the repayment of a fixed-rate loan with monthly instalments.
+type LoanSummary struct {
+	Principal     float64
+	AnnualRate    float64
+	TenureMonths  int
+	EMI           float64
+	TotalInterest float64
+	TotalPayment  float64
+	Schedule      []LoanYear
+}
+
+// monthlyRate converts an annual percentage rate into a monthly fraction.
+func monthlyRate(annualRate float64) float64 {
+	return annualRate / 12 / 100
+}
+
+// CalculateEMI returns the monthly instalment of a loan at an annual percentage rate.
+func CalculateEMI(principal, annualRate float64, months int) float64 {
+	if principal <= 0 || months <= 0 {
+		return 0
+	}
+	r := monthlyRate(annualRate)
+	if r == 0 {
+		return principal / float64(months)
+	}
+	factor := math.Pow(1+r, float64(months))
+	return principal * r * factor / (factor - 1)
+}
+
+// MaxLoanForEMI is the inverse of CalculateEMI: the largest principal an instalment can repay.
+func MaxLoanForEMI(emi, annualRate float64, months int) float64 {
+	if emi <= 0 || months <= 0 {
+		return 0
+	}
+	r := monthlyRate(annualRate)
+	if r == 0 {
+		return emi * float64(months)
+	}
+	factor := math.Pow(1+r, float64(months))
+	return emi * (factor - 1) / (r * factor)
+}
+
+// AmortizeLoan computes the EMI and the year-by-year repayment schedule of a loan.
+func AmortizeLoan(principal, annualRate float64, months int) LoanSummary {
+	emi := CalculateEMI(principal, annualRate, months)
+	summary := LoanSummary{
+		Principal:    principal,
+		AnnualRate:   annualRate,
+		TenureMonths: months,
+		EMI:          roundRupees(emi),
+	}
+
+	r := monthlyRate(annualRate)
+	balance := principal
+	var year LoanYear
+	for month := 1; month <= months; month++ {
+		interest := balance * r
+		repaid := math.Min(emi-interest, balance)
+		balance -= repaid
+		year.InterestPaid += interest
+		year.PrincipalPaid += repaid
+		summary.TotalInterest += interest
+
+		if month%12 == 0 || month == months {
+			year.Year = (month + 11) / 12
+			year.ClosingPrincipal = roundRupees(math.Max(balance, 0))
+			year.InterestPaid = roundRupees(year.InterestPaid)
+			year.PrincipalPaid = roundRupees(year.PrincipalPaid)
+			summary.Schedule = append(summary.Schedule, year)
+			year = LoanYear{}
+		}
+	}
+
+	summary.TotalInterest = roundRupees(summary.TotalInterest)
+	summary.TotalPayment = roundRupees(principal + summary.TotalInterest)
+	return summary
+}
+
+// Affordability is how large a loan and property a household can take on. Lenders cap
+// all EMIs, existing ones included, at a share of monthly income (the FOIR).
+type Affordability struct {
+	MaxEMI          float64
+	MaxLoan         float64
+	MaxPropertyCost float64
+}
+
+// CalculateAffordability sizes a loan from income. maxLTV is the largest share of the
+// property price a lender finances. With a downPayment, the cash available also limits
+// the property price; without one, the buyer is assumed to fund the rest.
+func CalculateAffordability(monthlyIncome, monthlyObligations, foir, annualRate float64, months int, downPayment, maxLTV float64) Affordability {
+	maxEMI := math.Max(monthlyIncome*foir-monthlyObligations, 0)
+	maxLoan := MaxLoanForEMI(maxEMI, annualRate, months)
+
+	maxCost := maxLoan + downPayment
+	if maxLTV > 0 && maxLTV < 1 {
+		if downPayment > 0 {
+			// the down payment must cover the share the lender does not finance
+			maxCost = math.Min(maxCost, downPayment/(1-maxLTV))
+			maxLoan = math.Min(maxLoan, maxCost*maxLTV)
+		} else {
+			maxCost = maxLoan / maxLTV
+		}
+	}
+	return Affordability{
+		MaxEMI:          roundRupees(maxEMI),
+		MaxLoan:         roundRupees(maxLoan),
+		MaxPropertyCost: roundRupees(maxCost),
+	}
+}
+
+// StampDutyRate is the stamp duty and registration charged on a property purchase in a
+// state, as percentages of the property value.
+type StampDutyRate struct {
+	StampDutyPercent      float64 `json:"stamp_duty_percent"`
+	WomenStampDutyPercent float64 `json:"women_stamp_duty_percent,omitempty"` // 0 if there is no concession
+	RegistrationPercent   float64 `json:"registration_percent"`
+	RegistrationCap       float64 `json:"registration_cap,omitempty"` // in rupees, 0 if uncapped
+}
+
+// DefaultStampDutyTable holds indicative rates for the states we list in. Rates change and
+// vary by city and value slab, so deployments override them with a table file.
+var DefaultStampDutyTable = map[string]StampDutyRate{
+	"maharashtra":    {StampDutyPercent: 6, WomenStampDutyPercent: 5, RegistrationPercent: 1, RegistrationCap: 30000},
+	"karnataka":      {StampDutyPercent: 5, RegistrationPercent: 1},
+	"delhi":          {StampDutyPercent: 6, WomenStampDutyPercent: 4, RegistrationPercent: 1},
+	"uttar pradesh":  {StampDutyPercent: 7, WomenStampDutyPercent: 6, RegistrationPercent: 1},
+	"haryana":        {StampDutyPercent: 7, WomenStampDutyPercent: 5, RegistrationPercent: 1, RegistrationCap: 50000},
+	"tamil nadu":     {StampDutyPercent: 7, RegistrationPercent: 2},
+	"telangana":      {StampDutyPercent: 5.5, RegistrationPercent: 0.5},
+	"gujarat":        {StampDutyPercent: 4.9, RegistrationPercent: 1},
+	"west bengal":    {StampDutyPercent: 6, RegistrationPercent: 1},
+	"rajasthan":      {StampDutyPercent: 6, WomenStampDutyPercent: 5, RegistrationPercent: 1},
+	"madhya pradesh": {StampDutyPercent: 7.5, RegistrationPercent: 3},
+	"punjab":         {StampDutyPercent: 7, WomenStampDutyPercent: 5, RegistrationPercent: 1},
+}
+
+// LoadStampDutyTable reads a JSON object of state name to StampDutyRate. Without a path
+// the default table is returned.
+func LoadStampDutyTable(path string) (map[string]StampDutyRate, error) {
+	if path == "" {
+		return DefaultStampDutyTable, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]StampDutyRate
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid stamp duty table %s: %w", path, err)
+	}
+	table := make(map[string]StampDutyRate, len(raw))
+	for state, rate := range raw {
+		table[NormalizeState(state)] = rate
+	}
+	return table, nil
+}
+
+// NormalizeState is the key of a state in the stamp duty table.
+func NormalizeState(state string) string {
+	return strings.Join(strings.Fields(strings.ToLower(state)), " ")
+}
+
+// PurchaseCharges are the government charges on a property purchase, in rupees.
+type PurchaseCharges struct {
+	StampDuty    float64
+	Registration float64
+	Total        float64
+}
+
+// CalculatePurchaseCharges applies a state's rates to a property value.
+func CalculatePurchaseCharges(rate StampDutyRate, value float64, woman bool) PurchaseCharges {
+	stampPercent := rate.StampDutyPercent
+	if woman && rate.WomenStampDutyPercent > 0 {
+		stampPercent = rate.WomenStampDutyPercent
+	}
+	registration := value * rate.RegistrationPercent / 100
+	if rate.RegistrationCap > 0 {
+		registration = math.Min(registration, rate.RegistrationCap)
+	}
+
+	charges := PurchaseCharges{
+		StampDuty:    roundRupees(value * stampPercent / 100),
+		Registration: roundRupees(registration),
+	}
+	charges.Total = charges.StampDuty + charges.Registration
+	return charges
+}
+
+func roundRupees(amount float64) float64 {
+	return math.Round(amount)
+}
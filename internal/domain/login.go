@@ -0,0 +1,34 @@
+package domain
+
+import "net"
+
+// Reasons a sign-in attempt failed.
+const (
+	LoginFailureUnknownUser      = "unknown_user"
+	LoginFailureInvalidPassword  = "invalid_password"
+	LoginFailureInactive         = "inactive"
+	LoginFailureInvalidTwoFactor = "invalid_two_factor"
+	LoginFailureLocked           = "locked"
+	LoginFailureThrottled        = "throttled"
+)
+
+// LoginAttempt is a sign-in to record in the login history. UserID is empty when the
+// email matched no user, and FailureReason is empty on success.
+type LoginAttempt struct {
+	UserID        string
+	Email         string
+	Meta          SessionMeta
+	FailureReason string
+}
+
+// LoginNetwork is what per-address throttling counts failed sign-ins against: the
+// address itself for IPv4, and its /64 for IPv6, since a single client is usually
+// handed a whole /64 to rotate through.
+func LoginNetwork(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || ip.To4() != nil {
+		return ipAddress
+	}
+	network := net.IPNet{IP: ip.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}
+	return network.String()
+}
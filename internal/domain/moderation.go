@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/VI-IM/im_backend_go/ent/schema"
+)
+
+// Moderation statuses, kept in sync with the Property and PropertyRevision ent schemas.
+const (
+	ModerationStatusPending    = "pending"
+	ModerationStatusApproved   = "approved"
+	ModerationStatusRejected   = "rejected"
+	ModerationStatusSuperseded = "superseded"
+)
+
+// Revision actions.
+const (
+	RevisionActionCreate = "create"
+	RevisionActionUpdate = "update"
+)
+
+// Notification kinds.
+const (
+	NotificationPropertyApproved = "property_approved"
+	NotificationPropertyRejected = "property_rejected"
+)
+
+type PropertyRevision struct {
+	PropertyID        string
+	Action            string
+	Snapshot          schema.PropertySnapshot
+	SubmittedByUserID string
+}
+
+type Notification struct {
+	UserID     string
+	Kind       string
+	Title      string
+	Message    string
+	EntityType string
+	EntityID   string
+}
+
+// FieldChange is one changed value between two property snapshots. Field is the dotted
+// JSON path of the value, such as "web_cards.property_details.facing.value".
+type FieldChange struct {
+	Field  string
+	Before interface{}
+	After  interface{}
+}
+
+// DiffPropertySnapshots lists the values that differ between two snapshots, ordered by field.
+func DiffPropertySnapshots(before, after schema.PropertySnapshot) ([]FieldChange, error) {
+	beforeValues, err := flattenSnapshot(before)
+	if err != nil {
+		return nil, err
+	}
+	afterValues, err := flattenSnapshot(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FieldChange
+	for field, value := range afterValues {
+		if old, ok := beforeValues[field]; !ok || !reflect.DeepEqual(old, value) {
+			changes = append(changes, FieldChange{Field: field, Before: beforeValues[field], After: value})
+		}
+	}
+	for field, value := range beforeValues {
+		if _, ok := afterValues[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, Before: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// flattenSnapshot maps every leaf value of a snapshot to its dotted JSON path. Lists are
+// compared as a whole.
+func flattenSnapshot(snapshot schema.PropertySnapshot) (map[string]interface{}, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{})
+	var walk func(prefix string, node map[string]interface{})
+	walk = func(prefix string, node map[string]interface{}) {
+		for key, value := range node {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			if child, ok := value.(map[string]interface{}); ok {
+				walk(path, child)
+				continue
+			}
+			values[path] = value
+		}
+	}
+	walk("", tree)
+	return values, nil
+}
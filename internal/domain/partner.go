@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// User roles, kept in sync with the User ent schema.
+const (
+	RoleBusinessPartner = "business_partner"
+	RolePartnerMember   = "partner_member"
+	RoleSuperAdmin      = "superadmin"
+	RoleDM              = "dm"
+)
+
+// UserProfile holds the self-editable fields of a user. Empty values are left unchanged.
+type UserProfile struct {
+	Name             string
+	PhoneNumber      string
+	Gender           string
+	CurrentAddress   string
+	PermanentAddress string
+	DateOfBirth      *time.Time
+}
+
+// PartnerInvitation creates an inactive partner_member user who activates the account by
+// setting a password with the invite token.
+type PartnerInvitation struct {
+	Name            string
+	Username        string
+	Email           string
+	PhoneNumber     string
+	InvitedByUserID string
+	TokenHash       string
+	ExpiresAt       time.Time
+}
+
+// ListingStats is the performance of one partner property. Leads are counted within the
+// requested period; views are lifetime totals.
+type ListingStats struct {
+	PropertyID       string
+	Name             string
+	Slug             string
+	ModerationStatus string
+	Views            int
+	Leads            int
+	VerifiedLeads    int
+	LastLeadAt       *time.Time
+}
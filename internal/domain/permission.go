@@ -0,0 +1,106 @@
+package domain
+
+// Permissions guard internal routes and role-dependent behaviour. Roles are sets of these,
+// stored in the database and editable by superadmins.
+const (
+	PermissionProjectWrite      = "project.write"
+	PermissionProjectDelete     = "project.delete"
+	PermissionPropertyWrite     = "property.write"     // own listings
+	PermissionPropertyWriteAll  = "property.write.all" // any listing
+	PermissionPropertyReadAll   = "property.read.all"
+	PermissionPropertyPublish   = "property.publish" // changes go live without moderation
+	PermissionPropertyModerate  = "property.moderate"
+	PermissionLeadRead          = "lead.read"     // leads on own listings
+	PermissionLeadReadAll       = "lead.read.all" // every lead
+	PermissionBlogPublish       = "blog.publish"
+	PermissionContentWrite      = "content.write" // amenities, site data, search pages, redirects and slugs
+	PermissionLocationWrite     = "location.write"
+	PermissionDeveloperWrite    = "developer.write"
+	PermissionMediaUpload       = "media.upload"
+	PermissionAnalyticsRead     = "analytics.read"
+	PermissionNotificationRead  = "notification.read"
+	PermissionPartnerPortal     = "partner.portal"
+	PermissionPartnerTeamManage = "partner.team.manage"
+	PermissionSystemMaintain    = "system.maintain" // purges and registry syncs
+	PermissionRoleManage        = "role.manage"
+	PermissionUserManage        = "user.manage"
+	PermissionAPIKeyManage      = "api_key.manage"
+	PermissionAuditRead         = "audit.read"
+)
+
+// Permission describes a permission for the role editor.
+type Permission struct {
+	Name        string
+	Description string
+}
+
+// AllPermissions lists every permission the code checks.
+var AllPermissions = []Permission{
+	{PermissionProjectWrite, "Create, update, import and restore projects and their RERA registrations"},
+	{PermissionProjectDelete, "Delete projects"},
+	{PermissionPropertyWrite, "Create and edit own property listings"},
+	{PermissionPropertyWriteAll, "Edit any property listing"},
+	{PermissionPropertyReadAll, "See every property in the admin dashboard"},
+	{PermissionPropertyPublish, "Publish property changes without moderation"},
+	{PermissionPropertyModerate, "Review and approve property submissions"},
+	{PermissionLeadRead, "Read leads on own listings"},
+	{PermissionLeadReadAll, "Read every lead"},
+	{PermissionBlogPublish, "Create, edit and delete blogs"},
+	{PermissionContentWrite, "Manage amenities, static site data, custom search pages, redirects and slugs"},
+	{PermissionLocationWrite, "Add and delete locations"},
+	{PermissionDeveloperWrite, "Delete developers"},
+	{PermissionMediaUpload, "Upload files"},
+	{PermissionAnalyticsRead, "Read listing analytics"},
+	{PermissionNotificationRead, "Read own notifications"},
+	{PermissionPartnerPortal, "Use the partner portal"},
+	{PermissionPartnerTeamManage, "Manage the partner team"},
+	{PermissionSystemMaintain, "Run purges and registry syncs"},
+	{PermissionRoleManage, "Edit role definitions"},
+	{PermissionUserManage, "View and manage users and their sessions"},
+	{PermissionAPIKeyManage, "Create and revoke API keys for integrations"},
+	{PermissionAuditRead, "Search and export the audit log"},
+}
+
+// IsValidPermission reports whether the code knows a permission.
+func IsValidPermission(name string) bool {
+	for _, permission := range AllPermissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRolePermissions seeds the role definitions the first time the service starts.
+// Later edits are kept. The superadmin role always has every permission, so it cannot be
+// locked out and receives new permissions without an edit.
+var DefaultRolePermissions = map[string][]string{
+	RoleDM: {
+		PermissionProjectWrite, PermissionProjectDelete, PermissionLeadRead, PermissionLeadReadAll,
+		PermissionBlogPublish, PermissionContentWrite, PermissionLocationWrite, PermissionDeveloperWrite,
+		PermissionMediaUpload,
+	},
+	RoleBusinessPartner: {
+		PermissionPropertyWrite, PermissionLeadRead, PermissionMediaUpload, PermissionNotificationRead,
+		PermissionPartnerPortal, PermissionPartnerTeamManage,
+	},
+	RolePartnerMember: {
+		PermissionPartnerPortal,
+	},
+	RoleSuperAdmin: {},
+}
+
+// RolePermissionSet resolves the permissions of a role from its stored definition.
+func RolePermissionSet(role string, stored []string) map[string]bool {
+	set := make(map[string]bool, len(AllPermissions))
+	if role == RoleSuperAdmin {
+		for _, permission := range AllPermissions {
+			set[permission.Name] = true
+		}
+		return set
+	}
+	for _, permission := range stored {
+		set[permission] = true
+	}
+	return set
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent/schema"
+)
+
+// PriceConfigurationOverall is the configuration key of a project's overall price range.
+const PriceConfigurationOverall = ""
+
+// PriceChangePeriods are the look-back windows, in months, shown on listing cards.
+var PriceChangePeriods = []int{3, 6, 12}
+
+// PricePoint is a project price at a moment in time, in rupees.
+type PricePoint struct {
+	Configuration string
+	Size          string
+	MinPrice      float64
+	MaxPrice      float64
+	RecordedAt    time.Time
+}
+
+// ProjectPricePoints extracts the current price points of a project: its overall range and
+// one point per priced price list configuration. Unparseable prices are skipped.
+func ProjectPricePoints(minPrice, maxPrice string, priceList schema.PriceList) []PricePoint {
+	var points []PricePoint
+
+	low, high := ParsePrice(minPrice), ParsePrice(maxPrice)
+	if low != nil || high != nil {
+		if low == nil {
+			low = high
+		}
+		if high == nil {
+			high = low
+		}
+		points = append(points, PricePoint{
+			Configuration: PriceConfigurationOverall,
+			MinPrice:      *low,
+			MaxPrice:      *high,
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, option := range priceList.BHKOptionsWithPrices {
+		key := PriceConfigurationKey(option.ConfigurationName, option.Size)
+		price := ParsePrice(option.Price)
+		if key == PriceConfigurationOverall || price == nil || seen[key] {
+			continue
+		}
+		seen[key] = true
+		points = append(points, PricePoint{
+			Configuration: key,
+			Size:          option.Size,
+			MinPrice:      *price,
+			MaxPrice:      *price,
+		})
+	}
+	return points
+}
+
+// PriceConfigurationKey identifies a price list entry. The size is part of the key since
+// the same configuration is often listed in several sizes.
+func PriceConfigurationKey(name, size string) string {
+	name, size = strings.TrimSpace(name), strings.TrimSpace(size)
+	if size == "" {
+		return name
+	}
+	if name == "" {
+		return size
+	}
+	return name + " - " + size
+}
+
+// ChangedPricePoints returns the points of current whose price differs from, or is missing
+// in, previous.
+func ChangedPricePoints(previous, current []PricePoint) []PricePoint {
+	before := make(map[string]PricePoint, len(previous))
+	for _, point := range previous {
+		before[point.Configuration] = point
+	}
+
+	var changed []PricePoint
+	for _, point := range current {
+		old, ok := before[point.Configuration]
+		if !ok || old.MinPrice != point.MinPrice || old.MaxPrice != point.MaxPrice {
+			changed = append(changed, point)
+		}
+	}
+	return changed
+}
+
+// PriceChangePercent returns the percentage change of the minimum price between the
+// point in effect months ago and the latest point of a series sorted by RecordedAt.
+// It returns nil when the series does not reach back that far.
+func PriceChangePercent(series []PricePoint, now time.Time, months int) *float64 {
+	if len(series) == 0 {
+		return nil
+	}
+	cutoff := now.AddDate(0, -months, 0)
+
+	var base *PricePoint
+	for i := range series {
+		if series[i].RecordedAt.After(cutoff) {
+			break
+		}
+		base = &series[i]
+	}
+	if base == nil || base.MinPrice <= 0 {
+		return nil
+	}
+
+	latest := series[len(series)-1]
+	change := float64(int64((latest.MinPrice-base.MinPrice)/base.MinPrice*10000)) / 100
+	return &change
+}
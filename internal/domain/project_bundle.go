@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain/enums"
+)
+
+// ProjectBundle is the interchange format used to bulk import and export projects.
+// The JSON form is the bundle itself; the CSV form is a zip of one file per section.
+type ProjectBundle struct {
+	Developers []BundleDeveloper `json:"developers"`
+	Projects   []BundleProject   `json:"projects"`
+}
+
+// BundleDeveloper is matched against existing developers by identifier, falling back to name.
+type BundleDeveloper struct {
+	Name            string                       `json:"name"`
+	LegalName       string                       `json:"legal_name,omitempty"`
+	Identifier      string                       `json:"identifier,omitempty"`
+	EstablishedYear int                          `json:"established_year"`
+	MediaContent    schema.DeveloperMediaContent `json:"media_content"`
+
+	// Source points at the row the developer was read from, for error reports.
+	Source string `json:"-"`
+}
+
+// Key is the value projects use to reference this developer.
+func (d BundleDeveloper) Key() string {
+	if d.Identifier != "" {
+		return d.Identifier
+	}
+	return d.Name
+}
+
+// BundleLocation is matched against existing locations by locality and city.
+type BundleLocation struct {
+	LocalityName string `json:"locality_name"`
+	City         string `json:"city"`
+	State        string `json:"state,omitempty"`
+	Pincode      string `json:"pincode,omitempty"`
+	PhoneNumber  string `json:"phone_number,omitempty"`
+}
+
+// BundleProject is upserted by slug.
+type BundleProject struct {
+	Slug         string                 `json:"slug"`
+	Name         string                 `json:"name"`
+	ProjectType  string                 `json:"project_type"`
+	Status       enums.ProjectStatus    `json:"status"`
+	Description  string                 `json:"description,omitempty"`
+	MinPrice     string                 `json:"min_price,omitempty"`
+	MaxPrice     string                 `json:"max_price,omitempty"`
+	Developer    string                 `json:"developer"`
+	Location     BundleLocation         `json:"location"`
+	TimelineInfo schema.TimelineInfo    `json:"timeline_info"`
+	MetaInfo     schema.SEOMeta         `json:"meta_info"`
+	WebCards     schema.ProjectWebCards `json:"web_cards"`
+	LocationInfo schema.LocationInfo    `json:"location_info"`
+	IsFeatured   bool                   `json:"is_featured"`
+	IsPremium    bool                   `json:"is_premium"`
+	IsPriority   bool                   `json:"is_priority"`
+
+	// Source points at the row the project was read from, for error reports.
+	Source string `json:"-"`
+}
+
+// Bundle import actions.
+const (
+	BundleActionCreate = "create"
+	BundleActionUpdate = "update"
+)
+
+// BundleRowError is a validation error for a single row of an import.
+type BundleRowError struct {
+	Source  string `json:"source"`
+	Key     string `json:"key,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// BundleRowResult is the outcome of importing a single developer or project.
+type BundleRowResult struct {
+	Source string `json:"source"`
+	Key    string `json:"key"`
+	ID     string `json:"id,omitempty"`
+	Action string `json:"action"`
+}
@@ -1,6 +1,25 @@
 package domain
 
-import "github.com/VI-IM/im_backend_go/ent/schema"
+import (
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent/schema"
+)
+
+// Listing types, kept in sync with the Property ent schema.
+const (
+	ListingTypePrimarySale = "primary_sale"
+	ListingTypeResale      = "resale"
+	ListingTypeRent        = "rent"
+	ListingTypeLease       = "lease"
+)
+
+// Public path prefixes of the property route families.
+const (
+	PropertySalePathPrefix = "/propertyforsale/"
+	PropertyRentPathPrefix = "/propertyforrent/"
+)
 
 type Property struct {
 	PropertyID       string
@@ -13,9 +32,165 @@ type Property struct {
 	MetaInfo         schema.PropertyMetaInfo
 	IsFeatured       bool
 	IsDeleted        bool
+	ListingType      string
+	AvailableFrom    *time.Time
+	ModerationStatus string
 	Slug             string
 	DeveloperID      string
 	LocationID       string
 	ProjectID        string
 	CreatedByUserID  *string
 }
+
+// IsValidListingType reports whether listingType is a supported listing type.
+func IsValidListingType(listingType string) bool {
+	switch listingType {
+	case ListingTypePrimarySale, ListingTypeResale, ListingTypeRent, ListingTypeLease:
+		return true
+	}
+	return false
+}
+
+// IsRentalListing reports whether a listing is priced by monthly rent rather than a
+// selling price.
+func IsRentalListing(listingType string) bool {
+	return listingType == ListingTypeRent || listingType == ListingTypeLease
+}
+
+// PropertyListingPath is the public path of a property page in the route family of its
+// listing type: /propertyforrent/ for rent and lease, /propertyforsale/ otherwise.
+func PropertyListingPath(listingType, slug string) string {
+	if IsRentalListing(listingType) {
+		return PropertyRentPathPrefix + slug
+	}
+	return PropertySalePathPrefix + slug
+}
+
+// SplitPropertyPath returns the slug of a path in either property route family.
+func SplitPropertyPath(path string) (slug string, ok bool) {
+	for _, prefix := range []string{PropertySalePathPrefix, PropertyRentPathPrefix} {
+		if strings.HasPrefix(path, prefix) {
+			return strings.TrimPrefix(path, prefix), true
+		}
+	}
+	return "", false
+}
+
+// MergePropertyWebCards applies the non-empty web card fields of an update over the
+// current web cards and reports whether any field was set.
+func MergePropertyWebCards(current, update schema.WebCards) (schema.WebCards, bool) {
+	newWebCards := current
+	hasWebCardChanges := false
+
+	// property details
+	if update.PropertyDetails != (current.PropertyDetails) {
+		if update.PropertyDetails.BuiltUpArea.Value != "" {
+			newWebCards.PropertyDetails.BuiltUpArea.Value = update.PropertyDetails.BuiltUpArea.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.Sizes.Value != "" {
+			newWebCards.PropertyDetails.Sizes.Value = update.PropertyDetails.Sizes.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.FloorNumber.Value != "" {
+			newWebCards.PropertyDetails.FloorNumber.Value = update.PropertyDetails.FloorNumber.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.Configuration.Value != "" {
+			newWebCards.PropertyDetails.Configuration.Value = update.PropertyDetails.Configuration.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.PossessionStatus.Value != "" {
+			newWebCards.PropertyDetails.PossessionStatus.Value = update.PropertyDetails.PossessionStatus.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.Balconies.Value != "" {
+			newWebCards.PropertyDetails.Balconies.Value = update.PropertyDetails.Balconies.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.CoveredParking.Value != "" {
+			newWebCards.PropertyDetails.CoveredParking.Value = update.PropertyDetails.CoveredParking.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.Bedrooms.Value != "" {
+			newWebCards.PropertyDetails.Bedrooms.Value = update.PropertyDetails.Bedrooms.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.PropertyType.Value != "" {
+			newWebCards.PropertyDetails.PropertyType.Value = update.PropertyDetails.PropertyType.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.AgeOfProperty.Value != "" {
+			newWebCards.PropertyDetails.AgeOfProperty.Value = update.PropertyDetails.AgeOfProperty.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.FurnishingType.Value != "" {
+			newWebCards.PropertyDetails.FurnishingType.Value = update.PropertyDetails.FurnishingType.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.ReraNumber.Value != "" {
+			newWebCards.PropertyDetails.ReraNumber.Value = update.PropertyDetails.ReraNumber.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.Facing.Value != "" {
+			newWebCards.PropertyDetails.Facing.Value = update.PropertyDetails.Facing.Value
+			hasWebCardChanges = true
+		}
+		if update.PropertyDetails.Bathrooms.Value != "" {
+			newWebCards.PropertyDetails.Bathrooms.Value = update.PropertyDetails.Bathrooms.Value
+			hasWebCardChanges = true
+		}
+	}
+
+	// why choose us
+	// if len(update.WhyChooseUs.ImageUrls) > 0 || len(update.WhyChooseUs.USP_List) > 0 {
+	// 	if len(update.WhyChooseUs.ImageUrls) > 0 {
+	// 		newWebCards.WhyChooseUs.ImageUrls = update.WhyChooseUs.ImageUrls
+	// 	}
+	// 	if len(update.WhyChooseUs.USP_List) > 0 {
+	// 		newWebCards.WhyChooseUs.USP_List = update.WhyChooseUs.USP_List
+	// 	}
+	// 	hasWebCardChanges = true
+	// }
+
+	// // know about
+	if update.KnowAbout.Description != "" {
+		newWebCards.KnowAbout.Description = update.KnowAbout.Description
+		hasWebCardChanges = true
+	}
+
+	// // video presentation
+	// if update.VideoPresentation.Title != "" || update.VideoPresentation.VideoUrl != "" {
+	// 	if update.VideoPresentation.Title != "" {
+	// 		newWebCards.VideoPresentation.Title = update.VideoPresentation.Title
+	// 	}
+	// 	if update.VideoPresentation.VideoUrl != "" {
+	// 		newWebCards.VideoPresentation.VideoUrl = update.VideoPresentation.VideoUrl
+	// 	}
+	// 	hasWebCardChanges = true
+	// }
+
+	// location map
+	if update.LocationMap.Description != "" || update.LocationMap.GoogleMapLink != "" {
+		if update.LocationMap.Description != "" {
+			newWebCards.LocationMap.Description = update.LocationMap.Description
+		}
+		if update.LocationMap.GoogleMapLink != "" {
+			newWebCards.LocationMap.GoogleMapLink = update.LocationMap.GoogleMapLink
+		}
+		hasWebCardChanges = true
+	}
+
+	// property floor plan
+	if update.PropertyFloorPlan.Title != "" || len(update.PropertyFloorPlan.Plans) > 0 {
+		if update.PropertyFloorPlan.Title != "" {
+			newWebCards.PropertyFloorPlan.Title = update.PropertyFloorPlan.Title
+		}
+		if len(update.PropertyFloorPlan.Plans) > 0 {
+			newWebCards.PropertyFloorPlan.Plans = update.PropertyFloorPlan.Plans
+		}
+		hasWebCardChanges = true
+	}
+
+	return newWebCards, hasWebCardChanges
+}
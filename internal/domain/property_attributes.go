@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/ent/schema"
+)
+
+// Normalized furnishing values.
+const (
+	FurnishingUnfurnished = "unfurnished"
+	FurnishingSemi        = "semi-furnished"
+	FurnishingFull        = "furnished"
+)
+
+// Normalized possession values.
+const (
+	PossessionReadyToMove       = "ready-to-move"
+	PossessionUnderConstruction = "under-construction"
+	PossessionNewLaunch         = "new-launch"
+)
+
+// Property list sort options.
+const (
+	PropertySortNewest           = "newest"
+	PropertySortPriceAsc         = "price_asc"
+	PropertySortPriceDesc        = "price_desc"
+	PropertySortAreaAsc          = "area_asc"
+	PropertySortAreaDesc         = "area_desc"
+	PropertySortPricePerSqftAsc  = "price_per_sqft_asc"
+	PropertySortPricePerSqftDesc = "price_per_sqft_desc"
+)
+
+// IsValidPropertySort reports whether sort is a supported sort option. Empty means newest.
+func IsValidPropertySort(sort string) bool {
+	switch sort {
+	case "", PropertySortNewest, PropertySortPriceAsc, PropertySortPriceDesc, PropertySortAreaAsc,
+		PropertySortAreaDesc, PropertySortPricePerSqftAsc, PropertySortPricePerSqftDesc:
+		return true
+	}
+	return false
+}
+
+// PropertyAttributes are the queryable values parsed from a property's web cards and
+// pricing info. A nil field means the value is missing or could not be parsed.
+type PropertyAttributes struct {
+	Price           *float64
+	BuiltUpAreaSqft *float64
+	PricePerSqft    *float64
+	MonthlyRent     *float64
+	Bedrooms        *int
+	Bathrooms       *int
+	Furnishing      *string
+	Facing          *string
+	FloorNumber     *int
+	Possession      *string
+	AgeYears        *int // lower bound of ranges such as "5-10 years"
+}
+
+var (
+	numberPattern   = regexp.MustCompile(`\d+(\.\d+)?`)
+	bhkPattern      = regexp.MustCompile(`(?i)(\d+)\s*(bhk|rk|bed)`)
+	separatorRegexp = regexp.MustCompile(`[\s_/]+`)
+)
+
+// NormalizePropertyAttributes parses the free-text property details into typed values.
+func NormalizePropertyAttributes(details schema.PropertyDetails, pricing schema.PropertyPricingInfo) PropertyAttributes {
+	attrs := PropertyAttributes{
+		Price:           ParsePrice(pricing.Price),
+		BuiltUpAreaSqft: ParseAreaSqft(details.BuiltUpArea.Value),
+		MonthlyRent:     ParsePrice(pricing.MonthlyRent),
+		Bathrooms:       parseFirstInt(details.Bathrooms.Value),
+		Furnishing:      normalizeFurnishing(details.FurnishingType.Value),
+		Facing:          normalizeFacing(details.Facing.Value),
+		FloorNumber:     parseFloor(details.FloorNumber.Value),
+		Possession:      normalizePossession(details.PossessionStatus.Value),
+		AgeYears:        parseFirstInt(details.AgeOfProperty.Value),
+	}
+
+	attrs.Bedrooms = parseFirstInt(details.Bedrooms.Value)
+	if attrs.Bedrooms == nil {
+		if m := bhkPattern.FindStringSubmatch(details.Configuration.Value); m != nil {
+			attrs.Bedrooms = parseFirstInt(m[1])
+		}
+	}
+
+	if attrs.Price != nil && attrs.BuiltUpAreaSqft != nil && *attrs.BuiltUpAreaSqft > 0 {
+		perSqft := float64(int64(*attrs.Price / *attrs.BuiltUpAreaSqft))
+		attrs.PricePerSqft = &perSqft
+	}
+	return attrs
+}
+
+// ParsePrice reads prices such as "1.25 Cr", "85 Lakh", "₹ 45,00,000" or "90k" and
+// returns the amount in rupees.
+func ParsePrice(value string) *float64 {
+	value = strings.ToLower(strings.ReplaceAll(value, ",", ""))
+	number := numberPattern.FindString(value)
+	if number == "" {
+		return nil
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil || amount <= 0 {
+		return nil
+	}
+
+	unit := strings.TrimLeft(value[strings.Index(value, number)+len(number):], " .")
+	switch {
+	case strings.HasPrefix(unit, "cr"):
+		amount *= 1e7
+	case strings.HasPrefix(unit, "l"):
+		amount *= 1e5
+	case strings.HasPrefix(unit, "k"):
+		amount *= 1e3
+	}
+	return &amount
+}
+
+// ParseAreaSqft reads areas such as "1,250 sq.ft", "110 sq m" or "150 sq yd" and returns
+// square feet. Values without a unit are taken to be square feet.
+func ParseAreaSqft(value string) *float64 {
+	value = strings.ToLower(strings.ReplaceAll(value, ",", ""))
+	number := numberPattern.FindString(value)
+	if number == "" {
+		return nil
+	}
+	area, err := strconv.ParseFloat(number, 64)
+	if err != nil || area <= 0 {
+		return nil
+	}
+
+	unit := strings.NewReplacer(" ", "", ".", "").Replace(value[strings.Index(value, number)+len(number):])
+	switch {
+	case strings.HasPrefix(unit, "sqm"), strings.HasPrefix(unit, "sqmt"), strings.HasPrefix(unit, "m2"):
+		area *= 10.7639
+	case strings.HasPrefix(unit, "sqyd"), strings.HasPrefix(unit, "yd"), strings.HasPrefix(unit, "gaj"):
+		area *= 9
+	}
+	return &area
+}
+
+func parseFirstInt(value string) *int {
+	number := numberPattern.FindString(value)
+	if number == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(strings.Split(number, ".")[0])
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func parseFloor(value string) *int {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	if lower == "g" || strings.HasPrefix(lower, "ground") {
+		ground := 0
+		return &ground
+	}
+	return parseFirstInt(lower)
+}
+
+func normalizeFurnishing(value string) *string {
+	lower := strings.ToLower(value)
+	var normalized string
+	switch {
+	case lower == "":
+		return nil
+	case strings.Contains(lower, "semi"):
+		normalized = FurnishingSemi
+	case strings.Contains(lower, "unfurnish"), strings.Contains(lower, "un-furnish"), strings.Contains(lower, "bare"):
+		normalized = FurnishingUnfurnished
+	case strings.Contains(lower, "furnish"):
+		normalized = FurnishingFull
+	default:
+		return nil
+	}
+	return &normalized
+}
+
+func normalizeFacing(value string) *string {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	if lower == "" {
+		return nil
+	}
+	lower = strings.TrimSuffix(strings.TrimSpace(strings.TrimSuffix(lower, "facing")), "-")
+	for _, compound := range []string{"north", "south"} {
+		for _, side := range []string{"east", "west"} {
+			lower = strings.ReplaceAll(lower, compound+side, compound+"-"+side)
+		}
+	}
+	normalized := separatorRegexp.ReplaceAllString(strings.TrimSpace(lower), "-")
+	switch normalized {
+	case "n":
+		normalized = "north"
+	case "s":
+		normalized = "south"
+	case "e":
+		normalized = "east"
+	case "w":
+		normalized = "west"
+	case "ne":
+		normalized = "north-east"
+	case "nw":
+		normalized = "north-west"
+	case "se":
+		normalized = "south-east"
+	case "sw":
+		normalized = "south-west"
+	}
+	return &normalized
+}
+
+func normalizePossession(value string) *string {
+	lower := strings.ToLower(value)
+	var normalized string
+	switch {
+	case lower == "":
+		return nil
+	case strings.Contains(lower, "ready"):
+		normalized = PossessionReadyToMove
+	case strings.Contains(lower, "under"), strings.Contains(lower, "construction"):
+		normalized = PossessionUnderConstruction
+	case strings.Contains(lower, "launch"):
+		normalized = PossessionNewLaunch
+	default:
+		normalized = separatorRegexp.ReplaceAllString(strings.TrimSpace(lower), "-")
+	}
+	return &normalized
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RERA registration statuses, kept in sync with the ReraRegistration ent schema.
+const (
+	ReraStatusPending  = "pending"
+	ReraStatusActive   = "active"
+	ReraStatusExpiring = "expiring"
+	ReraStatusExpired  = "expired"
+	ReraStatusRevoked  = "revoked"
+)
+
+type ReraRegistration struct {
+	ID                 string
+	ProjectID          string
+	Phase              string
+	State              string
+	Authority          string
+	RegistrationNumber string
+	ValidFrom          *time.Time
+	ValidUntil         *time.Time
+	Status             string
+	QRImage            string
+	WebsiteLink        string
+}
+
+// ReraAuthority describes a state RERA authority and the format of the
+// project registration numbers it issues.
+type ReraAuthority struct {
+	State   string
+	Name    string
+	Website string
+	Pattern *regexp.Regexp
+}
+
+// genericReraPattern is used for states we don't have a specific format for.
+var genericReraPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9/\-. ]{5,79}$`)
+
+var reraAuthorities = map[string]ReraAuthority{
+	"UP": {State: "UP", Name: "UP RERA", Website: "https://www.up-rera.in", Pattern: regexp.MustCompile(`^UPRERAPRJ\d{1,8}(/\d{2}/\d{4})?$`)},
+	"MH": {State: "MH", Name: "MahaRERA", Website: "https://maharera.maharashtra.gov.in", Pattern: regexp.MustCompile(`^P[A-Z0-9]\d{10}$`)},
+	"HR": {State: "HR", Name: "HRERA", Website: "https://haryanarera.gov.in", Pattern: regexp.MustCompile(`^((HRERA|RC/REP/HARERA)[A-Z0-9/\-]*\d+[A-Z0-9/\-]*|HRERA-(PKL|GGM)-[A-Z]{2,4}-\d{1,5}-\d{4})$`)},
+	"DL": {State: "DL", Name: "Delhi RERA", Website: "https://rera.delhi.gov.in", Pattern: regexp.MustCompile(`^DLRERA\d{4}P\d{4}$`)},
+	"KA": {State: "KA", Name: "K-RERA", Website: "https://rera.karnataka.gov.in", Pattern: regexp.MustCompile(`^PRM/KA/RERA/\d{4}/\d{3}/PR/\d{6}/\d{6}$`)},
+	"GJ": {State: "GJ", Name: "GujRERA", Website: "https://gujrera.gujarat.gov.in", Pattern: regexp.MustCompile(`^PR/GJ/[A-Z0-9 ./\-]+/[A-Z]{2,4}\d{5}/\d{6}$`)},
+	"RJ": {State: "RJ", Name: "RajRERA", Website: "https://rera.rajasthan.gov.in", Pattern: regexp.MustCompile(`^RAJ/P/\d{4}/\d{1,6}$`)},
+	"TN": {State: "TN", Name: "TNRERA", Website: "https://www.rera.tn.gov.in", Pattern: regexp.MustCompile(`^TN/\d{2}/(BUILDING|LAYOUT)/\d{4}/\d{4}$`)},
+	"TG": {State: "TG", Name: "TG RERA", Website: "https://rera.telangana.gov.in", Pattern: regexp.MustCompile(`^P\d{11}$`)},
+	"PB": {State: "PB", Name: "PBRERA", Website: "https://rera.punjab.gov.in", Pattern: regexp.MustCompile(`^PBRERA-[A-Z]{2,5}\d{0,3}-(PR|PC|PM)\d{4}$`)},
+	"UK": {State: "UK", Name: "UK RERA", Website: "https://ukrera.uk.gov.in", Pattern: regexp.MustCompile(`^UKREP\d{8,12}$`)},
+	"MP": {State: "MP", Name: "MP RERA", Website: "https://www.rera.mp.gov.in", Pattern: regexp.MustCompile(`^P-[A-Z]{3}-\d{2}-\d{1,5}$`)},
+	"WB": {State: "WB", Name: "WBRERA", Website: "https://rera.wb.gov.in", Pattern: regexp.MustCompile(`^WBRERA/P/[A-Z]{3}/\d{4}/\d{6}$`)},
+}
+
+// stateAliases maps full state names to the codes used in reraAuthorities.
+var stateAliases = map[string]string{
+	"UTTAR PRADESH":  "UP",
+	"MAHARASHTRA":    "MH",
+	"HARYANA":        "HR",
+	"DELHI":          "DL",
+	"NEW DELHI":      "DL",
+	"KARNATAKA":      "KA",
+	"GUJARAT":        "GJ",
+	"RAJASTHAN":      "RJ",
+	"TAMIL NADU":     "TN",
+	"TELANGANA":      "TG",
+	"PUNJAB":         "PB",
+	"UTTARAKHAND":    "UK",
+	"MADHYA PRADESH": "MP",
+	"WEST BENGAL":    "WB",
+}
+
+// NormalizeReraState converts a state name or code into the state code used for RERA authorities.
+func NormalizeReraState(state string) string {
+	normalized := strings.ToUpper(strings.TrimSpace(state))
+	if code, ok := stateAliases[normalized]; ok {
+		return code
+	}
+	return normalized
+}
+
+// NormalizeReraNumber trims and upper-cases a registration number so that
+// the same number typed differently is stored once.
+func NormalizeReraNumber(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), " "))
+}
+
+// GetReraAuthority returns the authority for a state, falling back to a generic
+// authority when the state has no known registration number format.
+func GetReraAuthority(state string) ReraAuthority {
+	code := NormalizeReraState(state)
+	if authority, ok := reraAuthorities[code]; ok {
+		return authority
+	}
+	return ReraAuthority{
+		State:   code,
+		Name:    fmt.Sprintf("%s RERA", code),
+		Pattern: genericReraPattern,
+	}
+}
+
+// ValidateReraNumber checks a registration number against the format used by the state's authority.
+func ValidateReraNumber(state, number string) error {
+	if strings.TrimSpace(state) == "" {
+		return fmt.Errorf("state is required")
+	}
+	normalized := NormalizeReraNumber(number)
+	if normalized == "" {
+		return fmt.Errorf("registration number is required")
+	}
+
+	authority := GetReraAuthority(state)
+	if !authority.Pattern.MatchString(normalized) {
+		return fmt.Errorf("%s is not a valid %s registration number", normalized, authority.Name)
+	}
+	return nil
+}
+
+// ComputeReraStatus derives the status of a registration from its validity dates.
+// Registrations without a validity end date keep their current status (pending when
+// there is none), and revoked registrations are never reactivated by date changes.
+func ComputeReraStatus(current string, validFrom, validUntil *time.Time, warningWindow time.Duration, now time.Time) string {
+	if current == ReraStatusRevoked {
+		return current
+	}
+	if validFrom != nil && now.Before(*validFrom) {
+		return ReraStatusPending
+	}
+	if validUntil == nil {
+		if current == "" {
+			return ReraStatusPending
+		}
+		return current
+	}
+	if now.After(*validUntil) {
+		return ReraStatusExpired
+	}
+	if validUntil.Sub(now) <= warningWindow {
+		return ReraStatusExpiring
+	}
+	return ReraStatusActive
+}
+
+// LegacyReraStatus maps the free-form status stored with RERA numbers before
+// registrations existed onto a registration status. Numbers without a status
+// were shown as approved, so they are treated as active.
+func LegacyReraStatus(status string) string {
+	normalized := strings.ToLower(strings.TrimSpace(status))
+	switch {
+	case strings.Contains(normalized, "revok"), strings.Contains(normalized, "cancel"):
+		return ReraStatusRevoked
+	case strings.Contains(normalized, "expir"), strings.Contains(normalized, "lapse"):
+		return ReraStatusExpired
+	case strings.Contains(normalized, "pend"), strings.Contains(normalized, "appl"), strings.Contains(normalized, "process"):
+		return ReraStatusPending
+	default:
+		return ReraStatusActive
+	}
+}
+
+// IsReraApprovedStatus reports whether a registration in this status counts as a valid approval.
+func IsReraApprovedStatus(status string) bool {
+	return status == ReraStatusActive || status == ReraStatusExpiring
+}
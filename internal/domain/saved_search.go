@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Saved search alert channels, kept in sync with the SavedSearch ent schema.
+const (
+	AlertChannelSMS   = "sms"
+	AlertChannelEmail = "email"
+)
+
+// Saved search digest frequencies.
+const (
+	AlertFrequencyDaily  = "daily"
+	AlertFrequencyWeekly = "weekly"
+)
+
+// Reasons a project appears in a saved search digest.
+const (
+	AlertReasonNew          = "new"
+	AlertReasonPriceChanged = "price_changed"
+)
+
+// projectFilterKinds lists the ListProjects filters a saved search may store, with
+// whether each holds a bool or a string.
+var projectFilterKinds = map[string]string{
+	"is_premium":   "bool",
+	"is_priority":  "bool",
+	"is_featured":  "bool",
+	"location_id":  "string",
+	"developer_id": "string",
+	"name":         "string",
+	"city":         "string",
+	"type":         "string",
+}
+
+// SavedSearch is a new saved search waiting for its contact to be verified.
+type SavedSearch struct {
+	Name                  string
+	Channel               string
+	Phone                 string
+	Email                 string
+	Filters               map[string]interface{}
+	Frequency             string
+	VerificationCodeHash  string
+	VerificationExpiresAt time.Time
+	UnsubscribeToken      string
+}
+
+// ValidateProjectFilters checks a filter map against the filters ListProjects applies.
+func ValidateProjectFilters(filters map[string]interface{}) error {
+	for key, value := range filters {
+		kind, ok := projectFilterKinds[key]
+		if !ok {
+			return fmt.Errorf("unsupported filter %q", key)
+		}
+		switch kind {
+		case "bool":
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("filter %q must be a boolean", key)
+			}
+		case "string":
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("filter %q must be a string", key)
+			}
+		}
+	}
+	return nil
+}
+
+// AlertFrequencyInterval is the time between two digests of a saved search.
+func AlertFrequencyInterval(frequency string) time.Duration {
+	if frequency == AlertFrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// IsValidAlertFrequency reports whether frequency is supported. Empty means daily.
+func IsValidAlertFrequency(frequency string) bool {
+	return frequency == "" || frequency == AlertFrequencyDaily || frequency == AlertFrequencyWeekly
+}
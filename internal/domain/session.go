@@ -0,0 +1,24 @@
+package domain
+
+// Reasons a refresh token family was revoked.
+const (
+	SessionRevokedLogout        = "logout"
+	SessionRevokedLogoutAll     = "logout_all"
+	SessionRevokedReuse         = "reuse_detected"
+	SessionRevokedByAdmin       = "revoked_by_admin"
+	SessionRevokedUserInactive  = "user_inactive"
+	SessionRevokedPasswordReset = "password_reset"
+	SessionRevokedRoleChange    = "role_change"
+)
+
+// SessionMeta identifies the client a session was started from.
+type SessionMeta struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Purposes of tokens emailed to users.
+const (
+	UserTokenEmailVerification = "email_verification"
+	UserTokenPasswordReset     = "password_reset"
+)
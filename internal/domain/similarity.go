@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Reasons reported for a similar project, one per matching signal.
+const (
+	SimilarityReasonCity          = "same_city"
+	SimilarityReasonLocality      = "same_locality"
+	SimilarityReasonProjectType   = "same_project_type"
+	SimilarityReasonStatus        = "same_status"
+	SimilarityReasonPrice         = "similar_price"
+	SimilarityReasonConfiguration = "similar_configuration"
+	SimilarityReasonDeveloper     = "same_developer"
+)
+
+// similarPriceThreshold is the price similarity above which similar_price is reported.
+const similarPriceThreshold = 0.75
+
+// SimilarityWeights are the points each matching signal adds to a similarity score.
+// Price and configuration earn their weight scaled by how closely they match.
+type SimilarityWeights struct {
+	City          float64
+	Locality      float64
+	ProjectType   float64
+	Status        float64
+	Price         float64
+	Configuration float64
+	Developer     float64
+}
+
+// ProjectFeatures are the attributes of a project that similarity is measured on.
+type ProjectFeatures struct {
+	ProjectID      string
+	City           string
+	Locality       string
+	ProjectType    string
+	Status         string
+	DeveloperID    string
+	MinPrice       *float64
+	MaxPrice       *float64
+	Configurations []string
+}
+
+var configurationNumberPattern = regexp.MustCompile(`\d+(\.\d+)?`)
+
+// ParseConfigurations normalizes a configuration string such as "2, 3 BHK" or
+// "Studio, 1 BHK & 2 BHK" into a sorted set like ["1bhk", "2bhk", "studio"].
+func ParseConfigurations(value string) []string {
+	value = strings.ToLower(value)
+	value = strings.NewReplacer("&", ",", "/", ",", " and ", ",", "|", ",").Replace(value)
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if number := configurationNumberPattern.FindString(part); number != "" {
+			seen[number+"bhk"] = true
+			continue
+		}
+		seen[strings.Join(strings.Fields(part), " ")] = true
+	}
+
+	configurations := make([]string, 0, len(seen))
+	for c := range seen {
+		configurations = append(configurations, c)
+	}
+	sort.Strings(configurations)
+	return configurations
+}
+
+// ScoreProjectSimilarity scores how comparable candidate is to target and lists the
+// signals that matched.
+func ScoreProjectSimilarity(target, candidate ProjectFeatures, weights SimilarityWeights) (float64, []string) {
+	var score float64
+	var reasons []string
+
+	sameCity := target.City != "" && strings.EqualFold(target.City, candidate.City)
+	if sameCity {
+		score += weights.City
+		reasons = append(reasons, SimilarityReasonCity)
+	}
+	if sameCity && target.Locality != "" && strings.EqualFold(target.Locality, candidate.Locality) {
+		score += weights.Locality
+		reasons = append(reasons, SimilarityReasonLocality)
+	}
+	if target.ProjectType != "" && target.ProjectType == candidate.ProjectType {
+		score += weights.ProjectType
+		reasons = append(reasons, SimilarityReasonProjectType)
+	}
+	if target.Status != "" && target.Status == candidate.Status {
+		score += weights.Status
+		reasons = append(reasons, SimilarityReasonStatus)
+	}
+	if priceSimilarity := priceRangeSimilarity(target, candidate); priceSimilarity > 0 {
+		score += weights.Price * priceSimilarity
+		if priceSimilarity >= similarPriceThreshold {
+			reasons = append(reasons, SimilarityReasonPrice)
+		}
+	}
+	if overlap := jaccard(target.Configurations, candidate.Configurations); overlap > 0 {
+		score += weights.Configuration * overlap
+		reasons = append(reasons, SimilarityReasonConfiguration)
+	}
+	if target.DeveloperID != "" && target.DeveloperID == candidate.DeveloperID {
+		score += weights.Developer
+		reasons = append(reasons, SimilarityReasonDeveloper)
+	}
+	return score, reasons
+}
+
+// priceRangeSimilarity compares the midpoints of two price ranges, from 0 (unrelated or
+// unknown) to 1 (same midpoint).
+func priceRangeSimilarity(a, b ProjectFeatures) float64 {
+	midA, okA := priceMidpoint(a)
+	midB, okB := priceMidpoint(b)
+	if !okA || !okB {
+		return 0
+	}
+	if midA > midB {
+		midA, midB = midB, midA
+	}
+	return midA / midB
+}
+
+func priceMidpoint(f ProjectFeatures) (float64, bool) {
+	var low, high float64
+	if f.MinPrice != nil && *f.MinPrice > 0 {
+		low = *f.MinPrice
+	}
+	if f.MaxPrice != nil && *f.MaxPrice > 0 {
+		high = *f.MaxPrice
+	}
+	switch {
+	case low > 0 && high > 0:
+		return (low + high) / 2, true
+	case low > 0:
+		return low, true
+	case high > 0:
+		return high, true
+	}
+	return 0, false
+}
+
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := make(map[string]bool, len(a))
+	for _, v := range a {
+		set[v] = true
+	}
+	intersection := 0
+	for _, v := range b {
+		if set[v] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
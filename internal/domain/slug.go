@@ -0,0 +1,65 @@
+package domain
+
+import "fmt"
+
+// Entity types that own slugs, kept in sync with the SlugRecord ent schema.
+const (
+	SlugEntityProject          = "project"
+	SlugEntityProperty         = "property"
+	SlugEntityBlog             = "blog"
+	SlugEntityCustomSearchPage = "custom_search_page"
+)
+
+// Reasons recorded on redirects that are not written by a purge.
+const (
+	RedirectReasonSlugChange = "slug_change"
+	RedirectReasonManual     = "manual"
+)
+
+// SlugChange moves an entity from its current slug to a new one.
+type SlugChange struct {
+	EntityType string
+	EntityID   string
+	OldSlug    string
+	NewSlug    string
+}
+
+// SlugTakenError is returned when a slug is reserved by a different entity.
+type SlugTakenError struct {
+	Slug       string
+	EntityType string
+	EntityID   string
+	Retired    bool
+}
+
+func (e *SlugTakenError) Error() string {
+	if e.Retired {
+		return fmt.Sprintf("slug %q was previously used by %s %s and still redirects there", e.Slug, e.EntityType, e.EntityID)
+	}
+	return fmt.Sprintf("slug %q is already used by %s %s", e.Slug, e.EntityType, e.EntityID)
+}
+
+// BlogPath is the public path of a blog page.
+func BlogPath(slug string) string {
+	return "/blogs/" + slug
+}
+
+// CustomSearchPagePath is the public path of a custom search page. Search pages share
+// the root namespace with projects.
+func CustomSearchPagePath(slug string) string {
+	return "/" + slug
+}
+
+// SlugPath returns the public path of an entity's page.
+func SlugPath(entityType, slug string) string {
+	switch entityType {
+	case SlugEntityProperty:
+		return PropertyPath(slug)
+	case SlugEntityBlog:
+		return BlogPath(slug)
+	case SlugEntityCustomSearchPage:
+		return CustomSearchPagePath(slug)
+	default:
+		return ProjectPath(slug)
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// LeadSignal is a recent lead on a project, directly or through one of its properties.
+type LeadSignal struct {
+	ProjectID   string
+	OtpVerified bool
+	CreatedAt   time.Time
+}
+
+// ViewSignal is the number of page views of a project and its properties on one day.
+type ViewSignal struct {
+	ProjectID string
+	Day       time.Time
+	Views     int
+}
+
+// PopularityWeights are the points a single signal earns before decay. A verified lead
+// earns VerifiedLead instead of Lead.
+type PopularityWeights struct {
+	Lead         float64
+	VerifiedLead float64
+	View         float64
+}
+
+// ProjectPopularity is the decayed trending score of a project with the raw counts it
+// was computed from.
+type ProjectPopularity struct {
+	ProjectID     string
+	Score         float64
+	Leads         int
+	VerifiedLeads int
+	Views         int
+}
+
+// DecayFactor halves the weight of a signal every halfLife.
+func DecayFactor(age, halfLife time.Duration) float64 {
+	if age <= 0 || halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// ScoreProjectPopularity combines lead and view signals into one time-decayed score per
+// project, measured at now.
+func ScoreProjectPopularity(leads []LeadSignal, views []ViewSignal, weights PopularityWeights, halfLife time.Duration, now time.Time) map[string]*ProjectPopularity {
+	scores := make(map[string]*ProjectPopularity)
+	get := func(projectID string) *ProjectPopularity {
+		p, ok := scores[projectID]
+		if !ok {
+			p = &ProjectPopularity{ProjectID: projectID}
+			scores[projectID] = p
+		}
+		return p
+	}
+
+	for _, lead := range leads {
+		p := get(lead.ProjectID)
+		p.Leads++
+		weight := weights.Lead
+		if lead.OtpVerified {
+			p.VerifiedLeads++
+			weight = weights.VerifiedLead
+		}
+		p.Score += weight * DecayFactor(now.Sub(lead.CreatedAt), halfLife)
+	}
+	for _, view := range views {
+		p := get(view.ProjectID)
+		p.Views += view.Views
+		p.Score += weights.View * float64(view.Views) * DecayFactor(now.Sub(view.Day), halfLife)
+	}
+	return scores
+}
@@ -0,0 +1,9 @@
+package domain
+
+// Purposes of login challenges.
+const (
+	// LoginChallengeVerify asks for a TOTP or recovery code.
+	LoginChallengeVerify = "verify"
+	// LoginChallengeEnroll asks a user whose role requires 2FA to set it up.
+	LoginChallengeEnroll = "enroll"
+)
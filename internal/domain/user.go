@@ -0,0 +1,47 @@
+package domain
+
+// IsValidUserRole reports whether a role exists in the User schema.
+func IsValidUserRole(role string) bool {
+	switch role {
+	case RoleBusinessPartner, RolePartnerMember, RoleSuperAdmin, RoleDM:
+		return true
+	}
+	return false
+}
+
+// UserFilter narrows the admin user listing. Empty values do not filter.
+type UserFilter struct {
+	Role     string
+	IsActive *bool
+	Search   string // matched against name, email and username
+}
+
+// NewUser is a user created by an admin.
+type NewUser struct {
+	Username        string
+	Email           string
+	Name            string
+	PhoneNumber     string
+	Role            string
+	PasswordHash    string
+	IsVerified      bool
+	ParentID        *int
+	CreatedByUserID string
+}
+
+// UserUpdate holds admin changes to a user. Nil fields are left unchanged.
+type UserUpdate struct {
+	Username     *string
+	Email        *string
+	Name         *string
+	PhoneNumber  *string
+	Role         *string
+	IsActive     *bool
+	IsVerified   *bool
+	ParentID     *int
+	PasswordHash *string
+	// IsEmailVerified is cleared when the email changes.
+	IsEmailVerified *bool
+	// Unlock clears failed sign-ins and any lockout.
+	Unlock bool
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+func (h *Handler) ForgotPassword(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	if err := h.app.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "If an account exists for this email, a reset link has been sent"},
+		StatusCode: http.StatusAccepted,
+	}, nil
+}
+
+func (h *Handler) ResetPassword(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	if err := h.app.ResetPassword(r.Context(), &req); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Password has been reset, please sign in again"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RequestEmailVerification(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	if err := h.app.RequestEmailVerification(r.Context(), userIDFromContext(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Verification email sent"},
+		StatusCode: http.StatusAccepted,
+	}, nil
+}
+
+func (h *Handler) VerifyEmail(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	if err := h.app.VerifyEmail(r.Context(), req.Token); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Email verified"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
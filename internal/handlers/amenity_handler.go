@@ -43,7 +43,7 @@ func (h *Handler) AddCategoryWithAmenities(r *http.Request) (*imhttp.Response, *
 		}
 	}
 
-	if err := h.app.AddCategoryWithAmenities(&req); err != nil {
+	if err := h.app.AddCategoryWithAmenities(r.Context(), &req, actorFromRequest(r)); err != nil {
 		return nil, err
 	}
 
@@ -63,7 +63,7 @@ func (h *Handler) UpdateStaticSiteData(r *http.Request) (*imhttp.Response, *imht
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
 	}
 
-	staticSiteData, err := h.app.UpdateStaticSiteData(&req)
+	staticSiteData, err := h.app.UpdateStaticSiteData(r.Context(), &req, actorFromRequest(r))
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (h *Handler) AddCategory(r *http.Request) (*imhttp.Response, *imhttp.Custom
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Category name is required", "Category name field cannot be empty")
 	}
 
-	if err := h.app.AddCategory(req.CategoryName); err != nil {
+	if err := h.app.AddCategory(r.Context(), req.CategoryName, actorFromRequest(r)); err != nil {
 		return nil, err
 	}
 
@@ -119,7 +119,7 @@ func (h *Handler) AddAmenityToCategory(r *http.Request) (*imhttp.Response, *imht
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Amenities are required", "Amenities field cannot be empty")
 	}
 
-	if err := h.app.AddAmenityToCategory(&req); err != nil {
+	if err := h.app.AddAmenityToCategory(r.Context(), &req, actorFromRequest(r)); err != nil {
 		return nil, err
 	}
 
@@ -152,7 +152,7 @@ func (h *Handler) DeleteAmenityFromCategory(r *http.Request) (*imhttp.Response,
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Amenity name is required", "Amenity name field cannot be empty")
 	}
 
-	if err := h.app.DeleteAmenityFromCategory(&req); err != nil {
+	if err := h.app.DeleteAmenityFromCategory(r.Context(), &req, actorFromRequest(r)); err != nil {
 		return nil, err
 	}
 
@@ -171,7 +171,7 @@ func (h *Handler) DeleteCategoryWithAmenities(r *http.Request) (*imhttp.Response
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Category name is required", "Category name field cannot be empty")
 	}
 
-	if err := h.app.DeleteCategoryWithAmenities(categoryName); err != nil {
+	if err := h.app.DeleteCategoryWithAmenities(r.Context(), categoryName, actorFromRequest(r)); err != nil {
 		return nil, err
 	}
 
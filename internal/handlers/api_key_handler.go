@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) ListAPIKeys(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	keys, err := h.app.ListAPIKeys(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       keys,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+// CreateAPIKey returns the new key once; it cannot be shown again
+func (h *Handler) CreateAPIKey(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	key, err := h.app.CreateAPIKey(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       key,
+		StatusCode: http.StatusCreated,
+	}, nil
+}
+
+func (h *Handler) RevokeAPIKey(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	key, err := h.app.RevokeAPIKey(r.Context(), mux.Vars(r)["api_key_id"], actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       key,
+		StatusCode: http.StatusOK,
+	}, nil
+}
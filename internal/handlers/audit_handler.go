@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+func (h *Handler) ListAuditLogs(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	req := auditLogsRequest(r)
+	req.Page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	req.PageSize, _ = strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	result, err := h.app.ListAuditLogs(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+// ExportAuditLogs downloads the audit log entries matching the same filters as
+// ListAuditLogs as a CSV file.
+func (h *Handler) ExportAuditLogs(w http.ResponseWriter, r *http.Request) {
+	req := auditLogsRequest(r)
+
+	export, cerr := h.app.ExportAuditLogs(r.Context(), &req)
+	if cerr != nil {
+		imhttp.AppHandler(func(*http.Request) (*imhttp.Response, *imhttp.CustomError) {
+			return nil, cerr
+		}).ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+	w.Header().Set("Content-Length", strconv.Itoa(len(export.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(export.Data)
+}
+
+func auditLogsRequest(r *http.Request) request.ListAuditLogsRequest {
+	query := r.URL.Query()
+	return request.ListAuditLogsRequest{
+		ActorUserID: query.Get("actor_user_id"),
+		EntityType:  query.Get("entity_type"),
+		EntityID:    query.Get("entity_id"),
+		Action:      query.Get("action"),
+		From:        query.Get("from"),
+		To:          query.Get("to"),
+	}
+}
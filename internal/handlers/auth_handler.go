@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/VI-IM/im_backend_go/internal/config"
 	"github.com/VI-IM/im_backend_go/request"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
 	"github.com/rs/zerolog/log"
@@ -15,7 +16,7 @@ func (h *Handler) GenerateToken(r *http.Request) (*imhttp.Response, *imhttp.Cust
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
 	}
-	resp, err := h.app.GetAccessToken(req.Email, req.Password)
+	resp, err := h.app.GetAccessToken(r.Context(), req.Email, req.Password, sessionMeta(r))
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusOK, "Invalid request", err.Error())
 	}
@@ -26,21 +27,26 @@ func (h *Handler) GenerateToken(r *http.Request) (*imhttp.Response, *imhttp.Cust
 	}
 
 	if resp.AccessToken != "" {
-		authCookie := &http.Cookie{
-			Name:     "authToken",
-			Value:    resp.AccessToken,
-			Path:     "/",
-			HttpOnly: true,
-			Secure:   true,
-			SameSite: http.SameSiteStrictMode,
-			Expires:  time.Now().Add(24 * time.Hour),
-		}
-		response.Cookies = []*http.Cookie{authCookie}
+		response.Cookies = []*http.Cookie{authCookie(resp.AccessToken, time.Now().Add(config.GetConfig().ExpiresInDuration))}
 	}
 
 	return response, nil
 }
 
+// authCookie carries the access token for browser clients. An empty token with a past
+// expiry clears it.
+func authCookie(token string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     "authToken",
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Expires:  expires,
+	}
+}
+
 // RefreshToken refreshes the access token
 func (h *Handler) RefreshToken(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
 	var req request.RefreshTokenRequest
@@ -49,15 +55,47 @@ func (h *Handler) RefreshToken(r *http.Request) (*imhttp.Response, *imhttp.Custo
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
 	}
 
-	resp, err := h.app.RefreshToken(req.RefreshToken)
+	resp, err := h.app.RefreshToken(r.Context(), req.RefreshToken, sessionMeta(r))
 	if err != nil {
 		log.Error().Err(err).Msg("Error refreshing token")
-		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Refresh token failed", err.Error())
+		return nil, err
 	}
 
 	return &imhttp.Response{
 		Data:       resp,
 		StatusCode: http.StatusOK,
+		Cookies:    []*http.Cookie{authCookie(resp.AccessToken, time.Now().Add(config.GetConfig().ExpiresInDuration))},
+	}, nil
+}
+
+// Logout ends the session of a refresh token and clears the auth cookie
+func (h *Handler) Logout(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	if err := h.app.Logout(r.Context(), req.RefreshToken); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Logged out"},
+		StatusCode: http.StatusOK,
+		Cookies:    []*http.Cookie{authCookie("", time.Unix(0, 0))},
+	}, nil
+}
+
+// LogoutAll ends every session of the signed-in user
+func (h *Handler) LogoutAll(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	if err := h.app.LogoutAll(r.Context(), userIDFromContext(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Logged out of all sessions"},
+		StatusCode: http.StatusOK,
+		Cookies:    []*http.Cookie{authCookie("", time.Unix(0, 0))},
 	}, nil
 }
 
@@ -74,7 +112,7 @@ func (h *Handler) Signup(r *http.Request) (*imhttp.Response, *imhttp.CustomError
 	}
 
 	// Call application layer to create user
-	resp, err := h.app.Signup(r.Context(), &req)
+	resp, err := h.app.Signup(r.Context(), &req, sessionMeta(r))
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/utils"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -16,3 +21,60 @@ func NewHandler(app application.ApplicationInterface) *Handler {
 		validate: validator.New(),
 	}
 }
+
+// userIDFromContext returns the ID of the authenticated user, or an empty string.
+func userIDFromContext(r *http.Request) string {
+	claims, ok := r.Context().Value("user_claims").(*auth.Claims)
+	if !ok {
+		return ""
+	}
+	return claims.UserID
+}
+
+// buyerIDFromContext returns the ID of the authenticated buyer, or an empty string.
+func buyerIDFromContext(r *http.Request) string {
+	claims, ok := r.Context().Value("buyer_claims").(*auth.BuyerClaims)
+	if !ok {
+		return ""
+	}
+	return claims.BuyerID
+}
+
+// hasPermission reports whether the authenticated user's role grants a permission.
+func (h *Handler) hasPermission(r *http.Request, permission string) bool {
+	if permissions, ok := r.Context().Value("user_permissions").(map[string]bool); ok {
+		return permissions[permission]
+	}
+	claims, ok := r.Context().Value("user_claims").(*auth.Claims)
+	if !ok {
+		return false
+	}
+	permissions, err := h.app.RolePermissions(r.Context(), claims.Role)
+	if err != nil {
+		return false
+	}
+	return permissions[permission]
+}
+
+// sessionMeta describes the client making a request, for recording on new sessions.
+func sessionMeta(r *http.Request) domain.SessionMeta {
+	return domain.SessionMeta{
+		UserAgent: r.UserAgent(),
+		IPAddress: utils.ClientIP(r),
+	}
+}
+
+// actorFromRequest identifies who is making a change, for the audit log. Requests
+// without credentials are known only by their address.
+func actorFromRequest(r *http.Request) domain.Actor {
+	actor := domain.Actor{IPAddress: utils.ClientIP(r)}
+	if claims, ok := r.Context().Value("user_claims").(*auth.Claims); ok {
+		actor.UserID = claims.UserID
+		actor.Role = claims.Role
+		actor.APIKeyID = claims.APIKeyID
+	}
+	if permissions, ok := r.Context().Value("user_permissions").(map[string]bool); ok {
+		actor.Permissions = permissions
+	}
+	return actor
+}
@@ -79,7 +79,7 @@ func (h *Handler) CreateBlog(r *http.Request) (*imhttp.Response, *imhttp.CustomE
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
 	}
 
-	blog, err := h.app.CreateBlog(r.Context(), &req)
+	blog, err := h.app.CreateBlog(r.Context(), &req, actorFromRequest(r))
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +94,7 @@ func (h *Handler) DeleteBlog(r *http.Request) (*imhttp.Response, *imhttp.CustomE
 	vars := mux.Vars(r)
 	blogID := vars["blog_id"]
 
-	if err := h.app.DeleteBlog(r.Context(), blogID); err != nil {
+	if err := h.app.DeleteBlog(r.Context(), blogID, actorFromRequest(r)); err != nil {
 		return nil, err
 	}
 
@@ -117,7 +117,7 @@ func (h *Handler) UpdateBlog(r *http.Request) (*imhttp.Response, *imhttp.CustomE
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
 	}
 
-	blog, err := h.app.UpdateBlog(r.Context(), blogID, &req)
+	blog, err := h.app.UpdateBlog(r.Context(), blogID, &req, actorFromRequest(r))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) RequestBuyerOTP(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.BuyerOTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	if err := h.app.RequestBuyerOTP(r.Context(), &req); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "OTP sent"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) VerifyBuyerOTP(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.VerifyBuyerOTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	resp, err := h.app.VerifyBuyerOTP(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       resp,
+		StatusCode: http.StatusOK,
+		Cookies: []*http.Cookie{{
+			Name:     "buyerToken",
+			Value:    resp.AccessToken,
+			Path:     "/v1/api/buyer",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+			Expires:  resp.ExpiresAt,
+		}},
+	}, nil
+}
+
+func (h *Handler) GetBuyerProfile(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	buyer, err := h.app.GetBuyerProfile(r.Context(), buyerIDFromContext(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       buyer,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) UpdateBuyerProfile(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.UpdateBuyerProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	buyer, err := h.app.UpdateBuyerProfile(r.Context(), buyerIDFromContext(r), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       buyer,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetBuyerShortlist(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	items, err := h.app.GetBuyerShortlist(r.Context(), buyerIDFromContext(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       items,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) AddToShortlist(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.ShortlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	if err := h.app.AddToShortlist(r.Context(), buyerIDFromContext(r), &req); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Added to shortlist"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RemoveFromShortlist(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	if err := h.app.RemoveFromShortlist(r.Context(), buyerIDFromContext(r), vars["entity_type"], vars["entity_id"]); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Removed from shortlist"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ListBuyerEnquiries(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	var req request.GetAllAPIRequest
+	req.Page, _ = strconv.Atoi(query.Get("page"))
+	req.PageSize, _ = strconv.Atoi(query.Get("page_size"))
+
+	result, err := h.app.ListBuyerEnquiries(r.Context(), buyerIDFromContext(r), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) CreateBuyerComparison(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.BuyerComparisonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	comparison, err := h.app.CreateBuyerComparison(r.Context(), buyerIDFromContext(r), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       comparison,
+		StatusCode: http.StatusCreated,
+	}, nil
+}
+
+func (h *Handler) ListBuyerComparisons(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	comparisons, err := h.app.ListBuyerComparisons(r.Context(), buyerIDFromContext(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       comparisons,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetBuyerComparison(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	comparison, err := h.app.GetBuyerComparison(r.Context(), buyerIDFromContext(r), mux.Vars(r)["comparison_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       comparison,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) UpdateBuyerComparison(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.UpdateBuyerComparisonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	comparison, err := h.app.UpdateBuyerComparison(r.Context(), buyerIDFromContext(r), mux.Vars(r)["comparison_id"], &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       comparison,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) DeleteBuyerComparison(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	if err := h.app.DeleteBuyerComparison(r.Context(), buyerIDFromContext(r), mux.Vars(r)["comparison_id"]); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Comparison deleted"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
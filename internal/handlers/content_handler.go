@@ -293,8 +293,8 @@ func (h *Handler) GetHTMLContent(r *http.Request) (*imhttp.Response, *imhttp.Cus
 			"<p>Email: <a href='mailto:info@investmango.com'>info@investmango.com</a>, <a href='mailto:hr@investmango.com'>hr@investmango.com</a></p>" +
 			"</div></body></html>"
 
-	case strings.HasPrefix(url, "propertyforsale/"):
-		canonical := strings.TrimPrefix(url, "propertyforsale/")
+	case strings.HasPrefix(url, "propertyforsale/"), strings.HasPrefix(url, "propertyforrent/"):
+		canonical := url[strings.Index(url, "/")+1:]
 		logger.Get().Info().Msg("Hello from property")
 		logger.Get().Info().Msgf("Canocical in property-------->%s", canonical)
 
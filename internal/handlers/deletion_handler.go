@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+// decodeDeleteRequest reads the optional body of a DELETE request. cascade and
+// redirect_to may also be passed as query parameters.
+func decodeDeleteRequest(r *http.Request) (*request.DeleteRequest, *imhttp.CustomError) {
+	var req request.DeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if cascade := r.URL.Query().Get("cascade"); cascade != "" {
+		parsed, err := strconv.ParseBool(cascade)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid cascade", err.Error())
+		}
+		req.Cascade = parsed
+	}
+	if redirectTo := r.URL.Query().Get("redirect_to"); redirectTo != "" {
+		req.RedirectTo = redirectTo
+	}
+	return &req, nil
+}
+
+func (h *Handler) PurgeDeletions(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	result, err := h.app.PurgeExpiredDeletions(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
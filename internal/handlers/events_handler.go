@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+// sessionHeader carries the frontend's anonymous visitor session, used to tell repeat
+// events of one visitor apart.
+const sessionHeader = "X-Session-ID"
+
+// recordPageView buffers a server-side page view of a listing.
+func (h *Handler) recordPageView(r *http.Request, entityType, entityID string) {
+	h.app.RecordListingEvent(request.ListingEventRequest{
+		EntityType: entityType,
+		EntityID:   entityID,
+		EventType:  "page_view",
+		SessionID:  r.Header.Get(sessionHeader),
+		Source:     "server",
+	})
+}
+
+func (h *Handler) IngestListingEvents(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.IngestEventsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	sessionID := r.Header.Get(sessionHeader)
+	for i := range req.Events {
+		if req.Events[i].SessionID == "" {
+			req.Events[i].SessionID = sessionID
+		}
+	}
+
+	result, err := h.app.IngestListingEvents(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusAccepted,
+	}, nil
+}
+
+func (h *Handler) GetListingAnalytics(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+	req := request.ListingAnalyticsRequest{
+		StartDate: query.Get("start_date"),
+		EndDate:   query.Get("end_date"),
+	}
+
+	analytics, err := h.app.GetListingAnalytics(r.Context(), vars["entity_type"], vars["entity_id"], &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       analytics,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetPartnerPropertyAnalytics(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+	req := request.ListingAnalyticsRequest{
+		StartDate: query.Get("start_date"),
+		EndDate:   query.Get("end_date"),
+	}
+
+	analytics, err := h.app.GetPartnerPropertyAnalytics(r.Context(), userIDFromContext(r), vars["property_id"], &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       analytics,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetProjectBrochure(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+
+	brochure, err := h.app.GetProjectBrochure(r.Context(), vars["project_id"], r.Header.Get(sessionHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       brochure,
+		StatusCode: http.StatusOK,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+func (h *Handler) CalculateEMI(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.EMIRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	result, err := h.app.CalculateEMI(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) CalculateAffordability(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.AffordabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	result, err := h.app.CalculateAffordability(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) CalculatePurchaseCharges(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.PurchaseChargesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	result, err := h.app.CalculatePurchaseCharges(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ListStampDutyRates(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	return &imhttp.Response{
+		Data:       h.app.ListStampDutyRates(),
+		StatusCode: http.StatusOK,
+	}, nil
+}
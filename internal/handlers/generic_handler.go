@@ -132,7 +132,7 @@ func (h *Handler) AddCustomSearchPage(r *http.Request) (*imhttp.Response, *imhtt
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", "Invalid request body")
 	}
-	customSearchPageResponse, err := h.app.AddCustomSearchPage(ctx, customSearchPage)
+	customSearchPageResponse, err := h.app.AddCustomSearchPage(ctx, customSearchPage, actorFromRequest(r))
 
 	// if err == nil {
 	// 	return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Failed to add custom search page", err.Error())
@@ -160,7 +160,7 @@ func (h *Handler) UpdateCustomSearchPage(r *http.Request) (*imhttp.Response, *im
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", "Invalid request body")
 	}
-	customSearchPageResponse, err := h.app.UpdateCustomSearchPage(ctx, id, customSearchPage)
+	customSearchPageResponse, err := h.app.UpdateCustomSearchPage(ctx, id, customSearchPage, actorFromRequest(r))
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Custom search page not found", "Custom search page not found")
 	}
@@ -180,7 +180,7 @@ func (h *Handler) DeleteCustomSearchPage(r *http.Request) (*imhttp.Response, *im
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "ID is required", "ID is required")
 	}
 
-	err := h.app.DeleteCustomSearchPage(ctx, id)
+	err := h.app.DeleteCustomSearchPage(ctx, id, actorFromRequest(r))
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusNotFound, "Custom search page not found", "Custom search page not found")
 	}
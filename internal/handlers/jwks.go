@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/internal/auth"
+)
+
+// JWKS publishes the public keys access tokens are signed with, for services that verify
+// tokens without holding a signing secret.
+func JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(auth.PublicKeys())
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
 	"github.com/VI-IM/im_backend_go/shared/logger"
@@ -28,7 +29,7 @@ func (h *Handler) CreateLeadWithOTP(r *http.Request) (*imhttp.Response, *imhttp.
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Phone must be 10 digits", "Validation error")
 	}
 
-	result, customErr := h.app.CreateLeadWithOTP(r.Context(), &req)
+	result, customErr := h.app.CreateLeadWithOTP(r.Context(), &req, actorFromRequest(r))
 	if customErr != nil {
 		return nil, customErr
 	}
@@ -54,7 +55,7 @@ func (h *Handler) CreateLead(r *http.Request) (*imhttp.Response, *imhttp.CustomE
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Phone must be 10 digits", "Validation error")
 	}
 
-	result, customErr := h.app.CreateLead(r.Context(), &req)
+	result, customErr := h.app.CreateLead(r.Context(), &req, actorFromRequest(r))
 	if customErr != nil {
 		return nil, customErr
 	}
@@ -79,14 +80,14 @@ func (h *Handler) GetLeadByID(r *http.Request) (*imhttp.Response, *imhttp.Custom
 		return nil, customErr
 	}
 
-	// Validate property ownership for business partners
-	claims, ok := r.Context().Value("user_claims").(*auth.Claims)
-	if ok && claims.Role == "business_partner" {
-		// If lead has a property, validate ownership
-		if result.PropertyID != "" {
-			if err := h.validatePropertyOwnership(r, []string{result.PropertyID}); err != nil {
-				return nil, err
-			}
+	// Validate property ownership unless the user can read every lead. Leads without a
+	// property belong to no partner, so they need lead.read.all.
+	if !h.hasPermission(r, domain.PermissionLeadReadAll) {
+		if result.PropertyID == "" {
+			return nil, imhttp.NewCustomErr(http.StatusForbidden, "Access denied: you can only access leads for properties you created", "Lead has no property")
+		}
+		if err := h.validatePropertyOwnership(r, []string{result.PropertyID}); err != nil {
+			return nil, err
 		}
 	}
 
@@ -103,26 +104,24 @@ func (h *Handler) validatePropertyOwnership(r *http.Request, propertyIDs []strin
 		return imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid user context", "Invalid user context")
 	}
 
-	// Superadmin and dm can access all leads
-	if claims.Role == "superadmin" || claims.Role == "dm" {
+	// Users who can read every lead skip the ownership check
+	if h.hasPermission(r, domain.PermissionLeadReadAll) {
 		return nil
 	}
 
-	// Business partner can only access leads for properties they created
-	if claims.Role == "business_partner" {
-		for _, propertyID := range propertyIDs {
-			if propertyID == "" {
-				continue
-			}
-			property, err := h.app.GetPropertyByID(propertyID)
-			if err != nil {
-				logger.Get().Error().Err(err).Str("property_id", propertyID).Msg("Failed to get property for ownership check")
-				return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify property ownership", err.Error())
-			}
-			// Check if the property was created by this user
-			if property.CreatedByUserID == "" || property.CreatedByUserID != claims.UserID {
-				return imhttp.NewCustomErr(http.StatusForbidden, "Access denied: you can only access leads for properties you created", "Property ownership check failed")
-			}
+	// Everyone else can only access leads for properties they created
+	for _, propertyID := range propertyIDs {
+		if propertyID == "" {
+			continue
+		}
+		property, err := h.app.GetPropertyByID(propertyID)
+		if err != nil {
+			logger.Get().Error().Err(err).Str("property_id", propertyID).Msg("Failed to get property for ownership check")
+			return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify property ownership", err.Error())
+		}
+		// Check if the property was created by this user
+		if property.CreatedByUserID == "" || property.CreatedByUserID != claims.UserID {
+			return imhttp.NewCustomErr(http.StatusForbidden, "Access denied: you can only access leads for properties you created", "Property ownership check failed")
 		}
 	}
 
@@ -155,8 +154,12 @@ func (h *Handler) GetAllLeads(r *http.Request) (*imhttp.Response, *imhttp.Custom
 		req.PropertyIDs = append(req.PropertyIDs, req.PropertyID)
 	}
 
-	// Validate property ownership for business partners
-	if len(req.PropertyIDs) > 0 {
+	// Without lead.read.all the leads are always scoped to the caller's own properties
+	if !h.hasPermission(r, domain.PermissionLeadReadAll) {
+		req.OwnerUserID = userIDFromContext(r)
+		if req.OwnerUserID == "" {
+			return nil, imhttp.NewCustomErr(http.StatusForbidden, "Access denied: requires "+domain.PermissionLeadReadAll+" permission", "No user to scope leads to")
+		}
 		if err := h.validatePropertyOwnership(r, req.PropertyIDs); err != nil {
 			return nil, err
 		}
@@ -197,7 +200,7 @@ func (h *Handler) ValidateOTP(r *http.Request) (*imhttp.Response, *imhttp.Custom
 		OTP:   otp,
 	}
 
-	result, customErr := h.app.ValidateOTP(r.Context(), req)
+	result, customErr := h.app.ValidateOTP(r.Context(), req, actorFromRequest(r))
 	if customErr != nil {
 		return nil, customErr
 	}
@@ -224,7 +227,7 @@ func (h *Handler) ResendOTP(r *http.Request) (*imhttp.Response, *imhttp.CustomEr
 		Phone: phone,
 	}
 
-	result, customErr := h.app.ResendOTP(r.Context(), req)
+	result, customErr := h.app.ResendOTP(r.Context(), req, actorFromRequest(r))
 	if customErr != nil {
 		return nil, customErr
 	}
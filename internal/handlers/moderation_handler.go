@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) ListPropertyRevisions(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	req := request.ListPropertyRevisionsRequest{
+		Status:     query.Get("status"),
+		PropertyID: query.Get("property_id"),
+	}
+	if req.Status == "" && req.PropertyID == "" {
+		req.Status = "pending"
+	}
+	req.Page, _ = strconv.Atoi(query.Get("page"))
+	req.PageSize, _ = strconv.Atoi(query.Get("page_size"))
+
+	result, err := h.app.ListPropertyRevisions(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetPropertyRevision(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	revisionID := vars["revision_id"]
+
+	revision, err := h.app.GetPropertyRevision(r.Context(), revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       revision,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ApprovePropertyRevision(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	revisionID := vars["revision_id"]
+
+	revision, err := h.app.ApprovePropertyRevision(r.Context(), revisionID, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       revision,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RejectPropertyRevision(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	revisionID := vars["revision_id"]
+
+	var req request.ReviewPropertyRevisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	revision, err := h.app.RejectPropertyRevision(r.Context(), revisionID, actorFromRequest(r), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       revision,
+		StatusCode: http.StatusOK,
+	}, nil
+}
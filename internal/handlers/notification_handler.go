@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) ListNotifications(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	req := request.ListNotificationsRequest{
+		UnreadOnly: query.Get("unread") == "true",
+	}
+	req.Page, _ = strconv.Atoi(query.Get("page"))
+	req.PageSize, _ = strconv.Atoi(query.Get("page_size"))
+
+	result, err := h.app.ListNotifications(r.Context(), userIDFromContext(r), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) MarkNotificationRead(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	notificationID := vars["notification_id"]
+
+	if err := h.app.MarkNotificationRead(r.Context(), notificationID, userIDFromContext(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Notification marked as read"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetPartnerProfile(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	profile, err := h.app.GetPartnerProfile(r.Context(), userIDFromContext(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       profile,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) UpdatePartnerProfile(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	profile, err := h.app.UpdatePartnerProfile(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       profile,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetListingPerformance(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	req := request.PartnerPerformanceRequest{
+		StartDate: query.Get("start_date"),
+		EndDate:   query.Get("end_date"),
+	}
+
+	performance, err := h.app.GetListingPerformance(r.Context(), userIDFromContext(r), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       performance,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ListPartnerLeads(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	req := request.ListPartnerLeadsRequest{
+		PropertyID: query.Get("property_id"),
+		StartDate:  query.Get("start_date"),
+		EndDate:    query.Get("end_date"),
+	}
+	req.Page, _ = strconv.Atoi(query.Get("page"))
+	req.PageSize, _ = strconv.Atoi(query.Get("page_size"))
+
+	result, err := h.app.ListPartnerLeads(r.Context(), userIDFromContext(r), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ListTeamMembers(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	members, err := h.app.ListTeamMembers(r.Context(), userIDFromContext(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       members,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) InviteTeamMember(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.InviteTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	invitation, err := h.app.InviteTeamMember(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       invitation,
+		StatusCode: http.StatusCreated,
+	}, nil
+}
+
+func (h *Handler) RemoveTeamMember(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	memberID := vars["user_id"]
+
+	if err := h.app.RemoveTeamMember(r.Context(), memberID, actorFromRequest(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Team member removed"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) AcceptInvitation(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	resp, err := h.app.AcceptInvitation(r.Context(), &req, sessionMeta(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       resp,
+		StatusCode: http.StatusOK,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+// maxProjectBundleSize caps the size of an uploaded project bundle.
+const maxProjectBundleSize = 32 << 20
+
+// ImportProjects accepts a project bundle either as the request body (application/json or
+// application/zip) or as the "file" field of a multipart form. Pass dry_run=true to only
+// validate the bundle and report what would change.
+func (h *Handler) ImportProjects(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	r.Body = http.MaxBytesReader(nil, r.Body, maxProjectBundleSize)
+
+	req := request.ImportProjectsRequest{
+		Format: strings.ToLower(r.URL.Query().Get("format")),
+	}
+	if dryRun := r.URL.Query().Get("dry_run"); dryRun != "" {
+		parsed, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid dry_run", err.Error())
+		}
+		req.DryRun = parsed
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch contentType {
+	case "multipart/form-data":
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "File is required", err.Error())
+		}
+		defer file.Close()
+
+		req.Data, err = io.ReadAll(file)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Failed to read file", err.Error())
+		}
+		if req.Format == "" {
+			req.Format = projectBundleFormatFromName(header.Filename)
+		}
+	default:
+		var err error
+		req.Data, err = io.ReadAll(r.Body)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Failed to read request body", err.Error())
+		}
+		if req.Format == "" {
+			if contentType == "application/zip" {
+				req.Format = request.ProjectBundleFormatCSV
+			} else {
+				req.Format = request.ProjectBundleFormatJSON
+			}
+		}
+	}
+
+	report, err := h.app.ImportProjects(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	statusCode := http.StatusOK
+	if !report.Valid {
+		statusCode = http.StatusUnprocessableEntity
+	}
+
+	return &imhttp.Response{
+		Data:       report,
+		StatusCode: statusCode,
+	}, nil
+}
+
+// ExportProjects streams the bundle as a file download, so it is a plain http handler
+// rather than an AppHandler. Filter with repeated slug parameters or developer_id.
+func (h *Handler) ExportProjects(w http.ResponseWriter, r *http.Request) {
+	req := request.ExportProjectsRequest{
+		Format:      strings.ToLower(r.URL.Query().Get("format")),
+		Slugs:       r.URL.Query()["slug"],
+		DeveloperID: r.URL.Query().Get("developer_id"),
+	}
+
+	export, cerr := h.app.ExportProjects(r.Context(), &req)
+	if cerr != nil {
+		imhttp.AppHandler(func(*http.Request) (*imhttp.Response, *imhttp.CustomError) {
+			return nil, cerr
+		}).ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.FileName))
+	w.Header().Set("Content-Length", strconv.Itoa(len(export.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(export.Data)
+}
+
+func projectBundleFormatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".zip":
+		return request.ProjectBundleFormatCSV
+	default:
+		return request.ProjectBundleFormatJSON
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/VI-IM/im_backend_go/request"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
@@ -38,7 +39,7 @@ func (h *Handler) AddProject(r *http.Request) (*imhttp.Response, *imhttp.CustomE
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", "Project name, project URL, project type, locality, project city, and developer ID are required")
 	}
 
-	response, err := h.app.AddProject(input)
+	response, err := h.app.AddProject(r.Context(), input, actorFromRequest(r))
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to add project")
 		return nil, err
@@ -65,7 +66,7 @@ func (h *Handler) UpdateProject(r *http.Request) (*imhttp.Response, *imhttp.Cust
 		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
 	}
 
-	response, err := h.app.UpdateProject(input)
+	response, err := h.app.UpdateProject(r.Context(), input, actorFromRequest(r))
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update project", err.Error())
 	}
@@ -80,17 +81,53 @@ func (h *Handler) DeleteProject(r *http.Request) (*imhttp.Response, *imhttp.Cust
 	vars := mux.Vars(r)
 	projectID := vars["project_id"]
 
-	if err := h.app.DeleteProject(projectID); err != nil {
+	req, err := decodeDeleteRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.app.DeleteProject(r.Context(), projectID, req, actorFromRequest(r))
+	if err != nil {
 		return nil, err
 	}
 
 	return &imhttp.Response{
-		Data:       "Project deleted successfully",
+		Data:       result,
 		StatusCode: http.StatusOK,
 		Message:    "Project deleted successfully",
 	}, nil
 }
 
+func (h *Handler) GetProjectDeletionPlan(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	projectID := vars["project_id"]
+
+	plan, err := h.app.GetProjectDeletionPlan(r.Context(), projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       plan,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RestoreProject(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	projectID := vars["project_id"]
+
+	if err := h.app.RestoreProject(r.Context(), projectID, actorFromRequest(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       "Project restored successfully",
+		StatusCode: http.StatusOK,
+		Message:    "Project restored successfully",
+	}, nil
+}
+
 func (h *Handler) ListProjects(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
 	// Create filter map
 	filters := make(map[string]interface{})
@@ -200,9 +237,63 @@ func (h *Handler) GetProjectBySlug(r *http.Request) (*imhttp.Response, *imhttp.C
 	if err != nil {
 		return nil, err
 	}
+	h.recordPageView(r, "project", response.ProjectID)
 
 	return &imhttp.Response{
 		Data:       response,
 		StatusCode: http.StatusOK,
 	}, nil
 }
+
+func (h *Handler) ListTrendingProjects(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	req := request.TrendingProjectsRequest{
+		City:        query.Get("city"),
+		Locality:    query.Get("locality"),
+		ProjectType: query.Get("type"),
+	}
+	req.Limit, _ = strconv.Atoi(query.Get("limit"))
+
+	projects, err := h.app.ListTrendingProjects(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       projects,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetSimilarProjects(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+	req := request.SimilarProjectsRequest{}
+	req.Limit, _ = strconv.Atoi(query.Get("limit"))
+	if exclude := query.Get("exclude_same_developer"); exclude != "" {
+		value := exclude == "true"
+		req.ExcludeSameDeveloper = &value
+	}
+
+	projects, err := h.app.GetSimilarProjects(r.Context(), vars["project_id"], &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       projects,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetProjectPriceHistory(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	history, err := h.app.GetProjectPriceHistory(r.Context(), mux.Vars(r)["project_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       history,
+		StatusCode: http.StatusOK,
+	}, nil
+}
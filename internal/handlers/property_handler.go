@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/request"
-	"github.com/VI-IM/im_backend_go/response"
 	imhttp "github.com/VI-IM/im_backend_go/shared"
 	"github.com/VI-IM/im_backend_go/shared/logger"
 	"github.com/gorilla/mux"
@@ -20,37 +22,32 @@ func (h *Handler) checkPropertyOwnership(r *http.Request, propertyID string) *im
 		return imhttp.NewCustomErr(http.StatusUnauthorized, "Invalid user context", "Invalid user context")
 	}
 
-	// Superadmin can modify any property
-	if claims.Role == "superadmin" {
+	// Users who may edit any listing skip the ownership check
+	if h.hasPermission(r, domain.PermissionPropertyWriteAll) {
 		return nil
 	}
 
-	// Business partner can only modify properties they created
-	if claims.Role == "business_partner" {
-		property, err := h.app.GetPropertyByID(propertyID)
-		if err != nil {
-			logger.Get().Error().Err(err).Msg("Failed to get property for ownership check")
-			return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify property ownership", err.Error())
-		}
-
-		// Check if the property was created by this user
-		if property.CreatedByUserID == "" || property.CreatedByUserID != claims.UserID {
-			return imhttp.NewCustomErr(http.StatusForbidden, "Access denied: you can only modify properties you created", "Access denied")
-		}
-		return nil
+	// Everyone else can only modify properties they created
+	property, err := h.app.GetPropertyByID(propertyID)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get property for ownership check")
+		return imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to verify property ownership", err.Error())
 	}
 
-	return imhttp.NewCustomErr(http.StatusForbidden, "Access denied: insufficient role permissions", "Access denied")
+	// Check if the property was created by this user
+	if property.CreatedByUserID == "" || property.CreatedByUserID != claims.UserID {
+		return imhttp.NewCustomErr(http.StatusForbidden, "Access denied: you can only modify properties you created", "Access denied")
+	}
+	return nil
 }
 
 func (h *Handler) GetProperty(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
 	vars := mux.Vars(r)
 	propertyID := vars["property_id"]
 
-	response, err := h.app.GetPropertyByID(propertyID)
+	response, err := h.app.GetPublishedPropertyByID(r.Context(), propertyID)
 	if err != nil {
-		logger.Get().Error().Err(err).Msg("Failed to get property")
-		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get property", err.Error())
+		return nil, err
 	}
 
 	return &imhttp.Response{
@@ -79,14 +76,24 @@ func (h *Handler) UpdateProperty(r *http.Request) (*imhttp.Response, *imhttp.Cus
 	}
 
 	input.PropertyID = propertyID
-	response, err := h.app.UpdateProperty(input)
+	if claims, ok := r.Context().Value("user_claims").(*auth.Claims); ok && !h.hasPermission(r, domain.PermissionPropertyPublish) {
+		input.Moderated = true
+		input.SubmittedByUserID = claims.UserID
+	}
+	response, err := h.app.UpdateProperty(r.Context(), input, actorFromRequest(r))
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to update property", err.Error())
 	}
 
+	// Edits held for review are accepted but not yet public
+	statusCode := http.StatusOK
+	if response.PendingRevisionID != "" {
+		statusCode = http.StatusAccepted
+	}
+
 	return &imhttp.Response{
 		Data:       response,
-		StatusCode: http.StatusOK,
+		StatusCode: statusCode,
 	}, nil
 }
 
@@ -126,9 +133,10 @@ func (h *Handler) AddProperty(r *http.Request) (*imhttp.Response, *imhttp.Custom
 	if ok {
 		userID := claims.UserID
 		input.CreatedByUserID = &userID
+		input.Moderated = !h.hasPermission(r, domain.PermissionPropertyPublish)
 	}
 
-	propertyID, err := h.app.AddProperty(input)
+	propertyID, err := h.app.AddProperty(r.Context(), input, actorFromRequest(r))
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to add property", err.Error())
 	}
@@ -140,60 +148,121 @@ func (h *Handler) AddProperty(r *http.Request) (*imhttp.Response, *imhttp.Custom
 }
 
 func (h *Handler) ListProperties(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+
 	// Parse pagination parameters from query
-	pagination := &request.GetAllAPIRequest{
-		Page:     1,
-		PageSize: 10,
+	req := &request.ListPropertiesRequest{
+		GetAllAPIRequest: request.GetAllAPIRequest{
+			Page:     1,
+			PageSize: 10,
+		},
+		Sort:          query.Get("sort"),
+		IncludeFacets: query.Get("facets") != "false",
+		ApprovedOnly:  true,
 	}
 
-	if page := r.URL.Query().Get("page"); page != "" {
+	if page := query.Get("page"); page != "" {
 		if pageNum, err := strconv.Atoi(page); err == nil {
-			pagination.Page = pageNum
+			req.Page = pageNum
 		}
 	}
 
-	if pageSize := r.URL.Query().Get("page_size"); pageSize != "" {
+	if pageSize := query.Get("page_size"); pageSize != "" {
 		if pageSizeNum, err := strconv.Atoi(pageSize); err == nil {
-			pagination.PageSize = pageSizeNum
+			req.PageSize = pageSizeNum
 		}
 	}
 
-	pagination.Validate()
+	req.Validate()
 
 	// Create filter map
 	filters := make(map[string]interface{})
 
 	// Parse query parameters
-	if configuration := r.URL.Query().Get("configuration"); configuration != "" {
+	if configuration := query.Get("configuration"); configuration != "" {
 		filters["configuration"] = configuration
 	}
-	if propertyType := r.URL.Query().Get("property_type"); propertyType != "" {
+	if propertyType := query.Get("property_type"); propertyType != "" {
 		filters["property_type"] = propertyType
 	}
-	if city := r.URL.Query().Get("city"); city != "" {
+	if city := query.Get("city"); city != "" {
 		filters["city"] = city
 	}
 
-	pagination.Filters = filters
+	for _, key := range []string{"min_price", "max_price", "min_rent", "max_rent"} {
+		if value := query.Get(key); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid "+key, err.Error())
+			}
+			filters[key] = parsed
+		}
+	}
+	for _, key := range []string{"min_floor", "max_floor", "min_age", "max_age"} {
+		if value := query.Get(key); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid "+key, err.Error())
+			}
+			filters[key] = parsed
+		}
+	}
+	for _, key := range []string{"bedrooms", "bathrooms"} {
+		if values := queryList(query[key]); len(values) > 0 {
+			parsed := make([]int, 0, len(values))
+			for _, value := range values {
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid "+key, err.Error())
+				}
+				parsed = append(parsed, n)
+			}
+			filters[key] = parsed
+		}
+	}
+	for _, key := range []string{"listing_type", "furnishing", "facing", "possession_status"} {
+		if values := queryList(query[key]); len(values) > 0 {
+			for i := range values {
+				values[i] = strings.ToLower(values[i])
+			}
+			filters[key] = values
+		}
+	}
+
+	if value := query.Get("available_by"); value != "" {
+		availableBy, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid available_by", err.Error())
+		}
+		filters["available_by"] = availableBy
+	}
 
-	properties, totalItems, err := h.app.ListProperties(pagination)
+	req.Filters = filters
+
+	page, err := h.app.ListProperties(r.Context(), req)
 	if err != nil {
 		return nil, err
 	}
 
-	paginatedResponse := response.NewPaginatedResponse(
-		properties,
-		pagination.Page,
-		pagination.PageSize,
-		totalItems,
-	)
-
 	return &imhttp.Response{
-		Data:       paginatedResponse,
+		Data:       page,
 		StatusCode: http.StatusOK,
 	}, nil
 }
 
+// queryList accepts both repeated parameters and comma-separated values.
+func queryList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+	}
+	return list
+}
+
 func (h *Handler) DeleteProperty(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
 	vars := mux.Vars(r)
 	propertyID := vars["property_id"]
@@ -207,17 +276,61 @@ func (h *Handler) DeleteProperty(r *http.Request) (*imhttp.Response, *imhttp.Cus
 		return nil, err
 	}
 
-	if err := h.app.DeleteProperty(propertyID); err != nil {
+	req, err := decodeDeleteRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.app.DeleteProperty(r.Context(), propertyID, req, actorFromRequest(r))
+	if err != nil {
 		return nil, err
 	}
 
 	return &imhttp.Response{
-		Data:       nil,
+		Data:       result,
 		StatusCode: http.StatusOK,
 		Message:    "Property deleted successfully",
 	}, nil
 }
 
+func (h *Handler) GetPropertyDeletionPlan(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	propertyID := vars["property_id"]
+
+	if err := h.checkPropertyOwnership(r, propertyID); err != nil {
+		return nil, err
+	}
+
+	plan, err := h.app.GetPropertyDeletionPlan(r.Context(), propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       plan,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RestoreProperty(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	propertyID := vars["property_id"]
+
+	if err := h.checkPropertyOwnership(r, propertyID); err != nil {
+		return nil, err
+	}
+
+	if err := h.app.RestoreProperty(r.Context(), propertyID, actorFromRequest(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       nil,
+		StatusCode: http.StatusOK,
+		Message:    "Property restored successfully",
+	}, nil
+}
+
 func (h *Handler) AdminListProperties(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
 	// Get user claims from context
 	claims, ok := r.Context().Value("user_claims").(*auth.Claims)
@@ -258,30 +371,27 @@ func (h *Handler) AdminListProperties(r *http.Request) (*imhttp.Response, *imhtt
 	if city := r.URL.Query().Get("city"); city != "" {
 		filters["city"] = city
 	}
+	if moderationStatus := r.URL.Query().Get("moderation_status"); moderationStatus != "" {
+		filters["moderation_status"] = moderationStatus
+	}
 
-	// Add role-based filtering
-	if claims.Role == "business_partner" {
-		// Business partners can only see properties they created
+	// Users without property.read.all only see properties they created
+	if !h.hasPermission(r, domain.PermissionPropertyReadAll) {
 		filters["created_by_user_id"] = claims.UserID
 	}
-	// Superadmin can see all properties (no additional filter needed)
 
 	pagination.Filters = filters
 
-	properties, totalItems, err := h.app.ListProperties(pagination)
+	page, err := h.app.ListProperties(r.Context(), &request.ListPropertiesRequest{
+		GetAllAPIRequest: *pagination,
+		Sort:             r.URL.Query().Get("sort"),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	paginatedResponse := response.NewPaginatedResponse(
-		properties,
-		pagination.Page,
-		pagination.PageSize,
-		totalItems,
-	)
-
 	return &imhttp.Response{
-		Data:       paginatedResponse,
+		Data:       page,
 		StatusCode: http.StatusOK,
 	}, nil
 }
@@ -299,6 +409,7 @@ func (h *Handler) GetPropertyBySlug(r *http.Request) (*imhttp.Response, *imhttp.
 	if err != nil {
 		return nil, imhttp.NewCustomErr(http.StatusInternalServerError, "Failed to get property by slug", err.Error())
 	}
+	h.recordPageView(r, "property", response.ID)
 
 	return &imhttp.Response{
 		Data:       response,
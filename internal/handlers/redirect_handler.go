@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) ListRedirects(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	req := request.ListRedirectsRequest{
+		Search: r.URL.Query().Get("search"),
+	}
+	req.Page, _ = strconv.Atoi(r.URL.Query().Get("page"))
+	req.PageSize, _ = strconv.Atoi(r.URL.Query().Get("page_size"))
+
+	result, err := h.app.ListRedirects(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) CreateRedirect(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.RedirectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	redirect, err := h.app.CreateRedirect(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       redirect,
+		StatusCode: http.StatusCreated,
+	}, nil
+}
+
+func (h *Handler) UpdateRedirect(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	redirectID := vars["redirect_id"]
+
+	var req request.RedirectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	redirect, err := h.app.UpdateRedirect(r.Context(), redirectID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       redirect,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) DeleteRedirect(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	redirectID := vars["redirect_id"]
+
+	if err := h.app.DeleteRedirect(r.Context(), redirectID); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       nil,
+		StatusCode: http.StatusOK,
+		Message:    "Redirect deleted successfully",
+	}, nil
+}
+
+func (h *Handler) GetSlugHistory(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	entityType := r.URL.Query().Get("entity_type")
+	entityID := r.URL.Query().Get("entity_id")
+	if entityType == "" || entityID == "" {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "entity_type and entity_id are required", "entity_type and entity_id are required")
+	}
+
+	history, err := h.app.GetSlugHistory(r.Context(), entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       history,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ReleaseSlug(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	slug := vars["slug"]
+
+	if err := h.app.ReleaseSlug(r.Context(), slug); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       nil,
+		StatusCode: http.StatusOK,
+		Message:    "Slug released successfully",
+	}, nil
+}
+
+func (h *Handler) SyncSlugRegistry(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	result, err := h.app.SyncSlugRegistry(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetProjectReraRegistrations(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	projectID := vars["project_id"]
+
+	registrations, err := h.app.GetProjectReraRegistrations(r.Context(), projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       registrations,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) AddReraRegistration(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	projectID := vars["project_id"]
+
+	var req request.ReraRegistrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	registration, err := h.app.AddReraRegistration(r.Context(), projectID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       registration,
+		StatusCode: http.StatusCreated,
+	}, nil
+}
+
+func (h *Handler) UpdateReraRegistration(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	reraID := vars["rera_id"]
+
+	var req request.ReraRegistrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	registration, err := h.app.UpdateReraRegistration(r.Context(), reraID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       registration,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) DeleteReraRegistration(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	reraID := vars["rera_id"]
+
+	if err := h.app.DeleteReraRegistration(r.Context(), reraID); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Rera registration deleted successfully"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ListExpiringReraRegistrations(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	registrations, err := h.app.ListExpiringReraRegistrations(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       registrations,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RefreshReraStatuses(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	result, err := h.app.RefreshReraStatuses(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) ListRoles(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	roles, err := h.app.ListRoles(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       roles,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ListPermissions(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	return &imhttp.Response{
+		Data:       h.app.ListPermissions(),
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) UpdateRole(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.UpdateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	role, err := h.app.UpdateRole(r.Context(), mux.Vars(r)["role"], &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       role,
+		StatusCode: http.StatusOK,
+	}, nil
+}
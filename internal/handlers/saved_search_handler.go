@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) CreateSavedSearch(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.CreateSavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	saved, err := h.app.CreateSavedSearch(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       saved,
+		StatusCode: http.StatusCreated,
+		Message:    "Verification code sent",
+	}, nil
+}
+
+func (h *Handler) VerifySavedSearch(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	var req request.VerifySavedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	saved, err := h.app.VerifySavedSearch(r.Context(), vars["saved_search_id"], &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       saved,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) UnsubscribeSavedSearch(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	if err := h.app.UnsubscribeSavedSearch(r.Context(), r.URL.Query().Get("token")); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "You have been unsubscribed from this alert"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
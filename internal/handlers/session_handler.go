@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) ListUserSessions(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	sessions, err := h.app.ListUserSessions(r.Context(), mux.Vars(r)["user_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       sessions,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RevokeUserSession(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	vars := mux.Vars(r)
+	if err := h.app.RevokeUserSession(r.Context(), vars["user_id"], vars["session_id"]); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Session revoked"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
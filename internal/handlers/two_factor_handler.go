@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) GetTwoFactorStatus(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	status, err := h.app.GetTwoFactorStatus(r.Context(), userIDFromContext(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       status,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) SetupTwoFactor(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	setup, err := h.app.SetupTwoFactor(r.Context(), userIDFromContext(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       setup,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) EnableTwoFactor(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	codes, err := h.app.EnableTwoFactor(r.Context(), userIDFromContext(r), req.Code, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       codes,
+		StatusCode: http.StatusOK,
+		Message:    "Two-factor authentication enabled",
+	}, nil
+}
+
+func (h *Handler) DisableTwoFactor(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.DisableTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	if err := h.app.DisableTwoFactor(r.Context(), userIDFromContext(r), &req, actorFromRequest(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Two-factor authentication disabled"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) RegenerateRecoveryCodes(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	codes, err := h.app.RegenerateRecoveryCodes(r.Context(), userIDFromContext(r), req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       codes,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+// SetupTwoFactorChallenge returns a secret to a user whose role requires 2FA, during sign-in
+func (h *Handler) SetupTwoFactorChallenge(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.TwoFactorChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	setup, err := h.app.SetupTwoFactorChallenge(r.Context(), req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       setup,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+// VerifyTwoFactorChallenge completes a sign-in with a second factor
+func (h *Handler) VerifyTwoFactorChallenge(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.VerifyTwoFactorChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	resp, err := h.app.VerifyTwoFactorChallenge(r.Context(), &req, sessionMeta(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       resp,
+		StatusCode: http.StatusOK,
+		Cookies:    []*http.Cookie{authCookie(resp.AccessToken, time.Now().Add(config.GetConfig().ExpiresInDuration))},
+	}, nil
+}
+
+func (h *Handler) ResetUserTwoFactor(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	if err := h.app.ResetUserTwoFactor(r.Context(), mux.Vars(r)["user_id"], actorFromRequest(r)); err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       map[string]string{"message": "Two-factor authentication reset"},
+		StatusCode: http.StatusOK,
+	}, nil
+}
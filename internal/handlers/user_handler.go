@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/VI-IM/im_backend_go/request"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+func (h *Handler) ListUsers(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	req := request.ListUsersRequest{
+		Role:   query.Get("role"),
+		Search: query.Get("q"),
+	}
+	req.Page, _ = strconv.Atoi(query.Get("page"))
+	req.PageSize, _ = strconv.Atoi(query.Get("page_size"))
+	if isActive := query.Get("is_active"); isActive != "" {
+		active, err := strconv.ParseBool(isActive)
+		if err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid is_active", err.Error())
+		}
+		req.IsActive = &active
+	}
+
+	result, err := h.app.ListUsers(r.Context(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) GetUser(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	user, err := h.app.GetUser(r.Context(), mux.Vars(r)["user_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       user,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) CreateUser(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.CreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	result, err := h.app.CreateUser(r.Context(), &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusCreated,
+	}, nil
+}
+
+func (h *Handler) UpdateUser(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	user, err := h.app.UpdateUser(r.Context(), mux.Vars(r)["user_id"], &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       user,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ChangeUserRole(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.ChangeUserRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	user, err := h.app.ChangeUserRole(r.Context(), mux.Vars(r)["user_id"], &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       user,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) DeactivateUser(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	user, err := h.app.DeactivateUser(r.Context(), mux.Vars(r)["user_id"], actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       user,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ReactivateUser(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	user, err := h.app.ReactivateUser(r.Context(), mux.Vars(r)["user_id"], actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       user,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+// UnlockUser clears a lockout from failed sign-ins
+func (h *Handler) UnlockUser(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	user, err := h.app.UnlockUser(r.Context(), mux.Vars(r)["user_id"], actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       user,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ListLoginHistory(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	query := r.URL.Query()
+	var req request.GetAllAPIRequest
+	req.Page, _ = strconv.Atoi(query.Get("page"))
+	req.PageSize, _ = strconv.Atoi(query.Get("page_size"))
+
+	result, err := h.app.ListLoginHistory(r.Context(), mux.Vars(r)["user_id"], &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func (h *Handler) ResetUserPassword(r *http.Request) (*imhttp.Response, *imhttp.CustomError) {
+	var req request.ResetUserPasswordRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request body", err.Error())
+		}
+	}
+	if err := h.validate.Struct(req); err != nil {
+		return nil, imhttp.NewCustomErr(http.StatusBadRequest, "Invalid request", err.Error())
+	}
+
+	result, err := h.app.ResetUserPassword(r.Context(), mux.Vars(r)["user_id"], &req, actorFromRequest(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return &imhttp.Response{
+		Data:       result,
+		StatusCode: http.StatusOK,
+	}, nil
+}
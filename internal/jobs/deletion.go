@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// NewDeletionPurgeJob hard-deletes projects and properties whose restore window has closed.
+func NewDeletionPurgeJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "deletion-purge",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			result, customErr := app.PurgeExpiredDeletions(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			if result.Purged > 0 || result.Failed > 0 {
+				logger.Get().Info().
+					Int("purged", result.Purged).
+					Int("redirects", result.Redirects).
+					Int("failed", result.Failed).
+					Msg("Purged expired deletions")
+			}
+			return nil
+		},
+	}
+}
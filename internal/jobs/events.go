@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// NewEventFlushJob batch-writes buffered listing events.
+func NewEventFlushJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "event-flush",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			if _, customErr := app.FlushListingEvents(ctx); customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			return nil
+		},
+	}
+}
+
+// NewEventRollupJob refreshes the daily per-listing event rollups.
+func NewEventRollupJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "event-rollup",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			rows, customErr := app.RollupListingEvents(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			logger.Get().Info().Int("rollups", rows).Msg("Rolled up listing events")
+			return nil
+		},
+	}
+}
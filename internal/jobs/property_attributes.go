@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// NewPropertyAttributesSyncJob normalizes the filterable attributes of properties written
+// before those columns existed.
+func NewPropertyAttributesSyncJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "property-attributes-sync",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			synced, customErr := app.SyncPropertyAttributes(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			if synced > 0 {
+				logger.Get().Info().Int("synced", synced).Msg("Synced property attributes")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// NewReraExpiryJob backfills registrations from legacy RERA numbers and flags RERA
+// registrations that are close to or past their validity date.
+func NewReraExpiryJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "rera-expiry",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			backfill, customErr := app.BackfillReraRegistrations(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			if backfill.Registered > 0 || len(backfill.Skipped) > 0 {
+				logger.Get().Info().
+					Int("registered", backfill.Registered).
+					Int("skipped", len(backfill.Skipped)).
+					Msg("Backfilled rera registrations")
+			}
+
+			result, customErr := app.RefreshReraStatuses(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			logger.Get().Info().
+				Int("checked", result.Checked).
+				Int("updated", result.Updated).
+				Int("expiring", result.Expiring).
+				Int("expired", result.Expired).
+				Msg("Refreshed rera registration statuses")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// NewSavedSearchAlertJob sends digests of new and re-priced projects to saved searches.
+func NewSavedSearchAlertJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "saved-search-alerts",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			result, customErr := app.SendSavedSearchAlerts(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			if result.Checked > 0 {
+				logger.Get().Info().
+					Int("checked", result.Checked).
+					Int("sent", result.Sent).
+					Int("failed", result.Failed).
+					Msg("Sent saved search alerts")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// Job is a unit of background work that runs on a fixed interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs in their own goroutines until its context is cancelled.
+type Scheduler struct {
+	jobs []Job
+	wg   sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		logger.Get().Warn().Str("job", job.Name).Msg("Job has no interval, skipping")
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every job once immediately and then on its interval.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait blocks until all jobs have stopped.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	logger.Get().Info().Str("job", job.Name).Dur("interval", job.Interval).Msg("Starting job")
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			logger.Get().Info().Str("job", job.Name).Msg("Stopping job")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Get().Error().Str("job", job.Name).Interface("panic", r).Msg("Job panicked")
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		logger.Get().Error().Err(err).Str("job", job.Name).Msg("Job failed")
+		return
+	}
+	logger.Get().Debug().Str("job", job.Name).Dur("took", time.Since(start)).Msg("Job finished")
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// NewSlugSyncJob registers slugs that are missing from the slug registry, such as those
+// written before it existed.
+func NewSlugSyncJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "slug-sync",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			result, customErr := app.SyncSlugRegistry(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			if result.Registered > 0 || len(result.Conflicts) > 0 {
+				logger.Get().Info().
+					Int("registered", result.Registered).
+					Int("conflicts", len(result.Conflicts)).
+					Msg("Synced slug registry")
+			}
+			return nil
+		},
+	}
+}
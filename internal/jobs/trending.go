@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// NewTrendingRecomputeJob rebuilds the trending projects cache from recent leads and views.
+func NewTrendingRecomputeJob(app application.ApplicationInterface, interval time.Duration) Job {
+	return Job{
+		Name:     "trending-recompute",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			scored, customErr := app.RecomputeTrendingProjects(ctx)
+			if customErr != nil {
+				return fmt.Errorf("%s: %s", customErr.ErrorMessage, customErr.Message)
+			}
+			logger.Get().Info().Int("projects", scored).Msg("Recomputed trending projects")
+			return nil
+		},
+	}
+}
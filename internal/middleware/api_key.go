@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/utils"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+// APIKeyHeader carries an API key for server-to-server requests.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves an API key into claims and the key's permissions.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key, ipAddress string) (*auth.Claims, map[string]bool, *imhttp.CustomError)
+}
+
+var apiKeyAuthenticator APIKeyAuthenticator
+
+// SetAPIKeyAuthenticator sets where Auth looks up API keys.
+func SetAPIKeyAuthenticator(authenticator APIKeyAuthenticator) {
+	apiKeyAuthenticator = authenticator
+}
+
+// authenticateAPIKey adds the claims and permissions of an API key to the request context.
+func authenticateAPIKey(w http.ResponseWriter, r *http.Request, key string, next http.Handler) {
+	if apiKeyAuthenticator == nil {
+		http.Error(w, "API keys are not available", http.StatusServiceUnavailable)
+		return
+	}
+	claims, permissions, err := apiKeyAuthenticator.AuthenticateAPIKey(r.Context(), key, utils.ClientIP(r))
+	if err != nil {
+		http.Error(w, err.ErrorMessage, err.StatusCode)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), "user_claims", claims)
+	ctx = context.WithValue(ctx, "user_permissions", permissions)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
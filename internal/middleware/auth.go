@@ -3,12 +3,48 @@ package middleware
 import (
 	"context"
 	"github.com/VI-IM/im_backend_go/internal/auth"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
 	"net/http"
 	"strings"
 )
 
+// UserValidator checks that the user behind a token is still active and still has the
+// role the token was issued for.
+type UserValidator interface {
+	ValidateUserClaims(ctx context.Context, claims *auth.Claims) *imhttp.CustomError
+}
+
+var userValidator UserValidator
+
+// SetUserValidator sets where Auth checks the users behind tokens.
+func SetUserValidator(validator UserValidator) {
+	userValidator = validator
+}
+
+// Auth only accepts user tokens; API keys are rejected because these routes
+// act on behalf of the signed-in user.
 func Auth(next http.Handler) http.Handler {
+	return authenticate(next, false)
+}
+
+// AuthOrAPIKey also accepts an API key. It is only used behind Require, where
+// the key's own permissions are checked.
+func AuthOrAPIKey(next http.Handler) http.Handler {
+	return authenticate(next, true)
+}
+
+func authenticate(next http.Handler, allowAPIKey bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Integrations authenticate with an API key instead of a user token
+		if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+			if !allowAPIKey {
+				http.Error(w, "API keys are not accepted on this route", http.StatusForbidden)
+				return
+			}
+			authenticateAPIKey(w, r, apiKey, next)
+			return
+		}
+
 		var tokenString string
 
 		// First try to get token from Authorization header
@@ -40,82 +76,18 @@ func Auth(next http.Handler) http.Handler {
 			return
 		}
 
-		// TODO: validate if user exists, if not, return 401
-
-		// Add claims to request context for use in handlers
-		ctx := context.WithValue(r.Context(), "user_claims", claims)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
-}
-
-// RequireBusinessPartner ensures only business_partner or superadmin can access
-func RequireBusinessPartner(next http.Handler) http.Handler {
-	return Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		claims, ok := r.Context().Value("user_claims").(*auth.Claims)
-		if !ok {
-			http.Error(w, "Invalid user context", http.StatusUnauthorized)
+		// Deactivation and role changes apply to tokens that were already issued
+		if userValidator == nil {
+			http.Error(w, "Users cannot be validated", http.StatusServiceUnavailable)
 			return
 		}
-
-		if claims.Role != "business_partner" && claims.Role != "superadmin" {
-			http.Error(w, "Access denied: requires business_partner or superadmin role", http.StatusForbidden)
+		if err := userValidator.ValidateUserClaims(r.Context(), claims); err != nil {
+			http.Error(w, err.ErrorMessage, err.StatusCode)
 			return
 		}
 
-		next.ServeHTTP(w, r)
-	}))
-}
-
-// RequireSuperAdmin ensures only superadmin can access
-func RequireSuperAdmin(next http.Handler) http.Handler {
-	return Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		claims, ok := r.Context().Value("user_claims").(*auth.Claims)
-		if !ok {
-			http.Error(w, "Invalid user context", http.StatusUnauthorized)
-			return
-		}
-
-		if claims.Role != "superadmin" {
-			http.Error(w, "Access denied: requires superadmin role", http.StatusForbidden)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	}))
-}
-
-// RequireDM ensures only dm (data manager) role can access
-func RequireDM(next http.Handler) http.Handler {
-	return Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		claims, ok := r.Context().Value("user_claims").(*auth.Claims)
-		if !ok {
-			http.Error(w, "Invalid user context", http.StatusUnauthorized)
-			return
-		}
-
-		if claims.Role != "dm" {
-			http.Error(w, "Access denied: requires dm role", http.StatusForbidden)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	}))
-}
-
-// RequireLeadAccess ensures only business_partner, dm, or superadmin can access leads
-func RequireLeadAccess(next http.Handler) http.Handler {
-	return Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		claims, ok := r.Context().Value("user_claims").(*auth.Claims)
-		if !ok {
-			http.Error(w, "Invalid user context", http.StatusUnauthorized)
-			return
-		}
-
-		if claims.Role != "business_partner" && claims.Role != "dm" && claims.Role != "superadmin" {
-			http.Error(w, "Access denied: requires business_partner, dm, or superadmin role", http.StatusForbidden)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	}))
+		// Add claims to request context for use in handlers
+		ctx := context.WithValue(r.Context(), "user_claims", claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
 }
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/internal/auth"
+)
+
+// RequireBuyer authenticates a buyer token. Buyer tokens are signed with their own key and
+// carry BuyerClaims, so they are rejected by Auth and every internal role check, and
+// internal user tokens are rejected here.
+func RequireBuyer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tokenString string
+
+		authHeader := r.Header.Get("Authorization")
+		if authHeader != "" {
+			tokenString = strings.TrimPrefix(authHeader, "Bearer ")
+		} else if cookie, err := r.Cookie("buyerToken"); err == nil {
+			tokenString = cookie.Value
+		}
+
+		if tokenString == "" {
+			http.Error(w, "Missing or invalid Authorization header/cookie", http.StatusUnauthorized)
+			return
+		}
+		claims, err := auth.ValidateBuyerToken(tokenString)
+		if err != nil {
+			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "buyer_claims", claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
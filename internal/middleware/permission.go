@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+)
+
+// PermissionResolver resolves the permission set of a role.
+type PermissionResolver interface {
+	RolePermissions(ctx context.Context, role string) (map[string]bool, *imhttp.CustomError)
+}
+
+var permissionResolver PermissionResolver
+
+// SetPermissionResolver sets where Require looks up role definitions.
+func SetPermissionResolver(resolver PermissionResolver) {
+	permissionResolver = resolver
+}
+
+// Require authenticates the request and ensures the user's role, or the API key, grants
+// a permission. The permissions are added to the context for handlers that scope by them.
+func Require(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return AuthOrAPIKey(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := r.Context().Value("user_claims").(*auth.Claims)
+			if !ok {
+				http.Error(w, "Invalid user context", http.StatusUnauthorized)
+				return
+			}
+			// API keys carry their own permissions instead of a role's
+			if claims.APIKeyID != "" {
+				permissions, _ := r.Context().Value("user_permissions").(map[string]bool)
+				if !permissions[permission] {
+					http.Error(w, "Access denied: requires "+permission+" permission", http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			if permissionResolver == nil {
+				http.Error(w, "Permissions are not available", http.StatusServiceUnavailable)
+				return
+			}
+
+			permissions, err := permissionResolver.RolePermissions(r.Context(), claims.Role)
+			if err != nil {
+				http.Error(w, "Failed to resolve permissions", http.StatusInternalServerError)
+				return
+			}
+			if !permissions[permission] {
+				http.Error(w, "Access denied: requires "+permission+" permission", http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "user_permissions", permissions)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}))
+	}
+}
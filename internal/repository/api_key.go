@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/apikey"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// apiKeyUseInterval limits how often last-used details are written for a busy key.
+const apiKeyUseInterval = time.Minute
+
+func (r *repository) CreateAPIKey(ctx context.Context, input domain.NewAPIKey) (*ent.APIKey, error) {
+	k, err := r.db.APIKey.Create().
+		SetID(uuid.New().String()).
+		SetName(input.Name).
+		SetPrefix(input.Prefix).
+		SetKeyHash(input.KeyHash).
+		SetPermissions(input.Permissions).
+		SetAllowedIps(input.AllowedIPs).
+		SetCreatedByUserID(input.CreatedByUserID).
+		SetNillableExpiresAt(input.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("name", input.Name).Msg("Failed to create API key")
+		return nil, err
+	}
+	return k, nil
+}
+
+// ListAPIKeys returns every API key, revoked ones included, newest first.
+func (r *repository) ListAPIKeys(ctx context.Context) ([]*ent.APIKey, error) {
+	keys, err := r.db.APIKey.Query().Order(ent.Desc(apikey.FieldCreatedAt)).All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list API keys")
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByHash returns the key with a hash, or nil if there is none.
+func (r *repository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*ent.APIKey, error) {
+	k, err := r.db.APIKey.Query().Where(apikey.KeyHash(keyHash)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return k, nil
+}
+
+// RevokeAPIKey revokes a key. Revoking a revoked key keeps the first revocation.
+func (r *repository) RevokeAPIKey(ctx context.Context, id, revokedBy string) (*ent.APIKey, error) {
+	if _, err := r.db.APIKey.Update().
+		Where(apikey.ID(id), apikey.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		SetRevokedByUserID(revokedBy).
+		Save(ctx); err != nil {
+		return nil, err
+	}
+	k, err := r.db.APIKey.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("API key not found")
+		}
+		return nil, err
+	}
+	return k, nil
+}
+
+// TouchAPIKey records a use of a key, at most once per apiKeyUseInterval.
+func (r *repository) TouchAPIKey(ctx context.Context, id, ipAddress string) error {
+	now := time.Now()
+	_, err := r.db.APIKey.Update().
+		Where(
+			apikey.ID(id),
+			apikey.Or(apikey.LastUsedAtIsNil(), apikey.LastUsedAtLT(now.Add(-apiKeyUseInterval))),
+		).
+		SetLastUsedAt(now).
+		SetLastUsedIP(ipAddress).
+		Save(ctx)
+	return err
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/auditlog"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+func (r *repository) CreateAuditLog(ctx context.Context, entry domain.AuditEntry) error {
+	before, err := auditSnapshot(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := auditSnapshot(entry.After)
+	if err != nil {
+		return err
+	}
+
+	create := r.db.AuditLog.Create().
+		SetID(uuid.New().String()).
+		SetActorUserID(entry.Actor.UserID).
+		SetActorRole(entry.Actor.Role).
+		SetAPIKeyID(entry.Actor.APIKeyID).
+		SetIPAddress(entry.Actor.IPAddress).
+		SetAction(entry.Action).
+		SetEntityType(entry.EntityType).
+		SetEntityID(entry.EntityID)
+	if before != nil {
+		create.SetBefore(before)
+	}
+	if after != nil {
+		create.SetAfter(after)
+	}
+	if err := create.Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Str("entity_type", entry.EntityType).Str("entity_id", entry.EntityID).Msg("Failed to write audit log")
+		return err
+	}
+	return nil
+}
+
+// ListAuditLogs returns a page of audit log entries, newest first.
+func (r *repository) ListAuditLogs(ctx context.Context, filter domain.AuditLogFilter, offset, limit int) ([]*ent.AuditLog, int, error) {
+	query := r.db.AuditLog.Query()
+	if filter.ActorUserID != "" {
+		query = query.Where(auditlog.ActorUserID(filter.ActorUserID))
+	}
+	if filter.EntityType != "" {
+		query = query.Where(auditlog.EntityType(filter.EntityType))
+	}
+	if filter.EntityID != "" {
+		query = query.Where(auditlog.EntityID(filter.EntityID))
+	}
+	if filter.Action != "" {
+		query = query.Where(auditlog.Action(filter.Action))
+	}
+	if filter.From != nil {
+		query = query.Where(auditlog.CreatedAtGTE(*filter.From))
+	}
+	if filter.To != nil {
+		query = query.Where(auditlog.CreatedAtLTE(*filter.To))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count audit logs")
+		return nil, 0, err
+	}
+	logs, err := query.
+		Order(ent.Desc(auditlog.FieldCreatedAt), ent.Desc(auditlog.FieldID)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list audit logs")
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// auditSnapshot converts a snapshot to the JSON object stored in the log.
+func auditSnapshot(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
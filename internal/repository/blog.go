@@ -7,6 +7,7 @@ import (
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/blogs"
 	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/shared/logger"
 	"github.com/google/uuid"
 )
@@ -108,6 +109,9 @@ func (r *repository) CreateBlog(ctx context.Context, slug string, blogContent sc
 		logger.Get().Error().Err(err).Msg("Failed to create blog")
 		return nil, err
 	}
+	if err := registerSlug(ctx, r.db, domain.SlugEntityBlog, blog.ID, slug); err != nil {
+		return nil, err
+	}
 	return blog, nil
 }
 
@@ -144,11 +148,20 @@ func (r *repository) UpdateBlog(ctx context.Context, id string, blogURL *string,
 		return nil, nil // Blog doesn't exist
 	}
 
+	if blogURL != nil && *blogURL != blog.Slug {
+		err = r.ChangeSlug(ctx, domain.SlugChange{
+			EntityType: domain.SlugEntityBlog,
+			EntityID:   id,
+			OldSlug:    blog.Slug,
+			NewSlug:    *blogURL,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	update := r.db.Blogs.UpdateOneID(id)
 
-	if blogURL != nil {
-		update.SetSlug(*blogURL)
-	}
 	if blogContent != nil {
 		update.SetBlogContent(*blogContent)
 	}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/buyer"
+	"github.com/VI-IM/im_backend_go/ent/buyercomparison"
+	"github.com/VI-IM/im_backend_go/ent/buyershortlist"
+	"github.com/VI-IM/im_backend_go/ent/leads"
+	projectEnt "github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/ent/property"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// GetBuyerByPhone returns the buyer registered with a phone, or nil if there is none.
+func (r *repository) GetBuyerByPhone(ctx context.Context, phone string) (*ent.Buyer, error) {
+	b, err := r.db.Buyer.Query().Where(buyer.Phone(phone)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get buyer by phone")
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *repository) GetBuyerByID(ctx context.Context, id string) (*ent.Buyer, error) {
+	b, err := r.db.Buyer.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("buyer not found")
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *repository) CreateBuyer(ctx context.Context, phone string) (*ent.Buyer, error) {
+	b, err := r.db.Buyer.Create().
+		SetID(uuid.New().String()).
+		SetPhone(phone).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create buyer")
+		return nil, err
+	}
+	return b, nil
+}
+
+// SetBuyerOTP stores a new login code and resets the failed attempt counter.
+func (r *repository) SetBuyerOTP(ctx context.Context, id, otpHash string, expiresAt time.Time) error {
+	return r.db.Buyer.UpdateOneID(id).
+		SetOtpHash(otpHash).
+		SetOtpExpiresAt(expiresAt).
+		SetOtpSentAt(time.Now()).
+		SetOtpAttempts(0).
+		Exec(ctx)
+}
+
+// IncrementBuyerOTPAttempts records a wrong guess. The counter stops at maxAttempts, so
+// concurrent guesses cannot push it past the lock.
+func (r *repository) IncrementBuyerOTPAttempts(ctx context.Context, id string, maxAttempts int) error {
+	return r.db.Buyer.Update().
+		Where(buyer.ID(id), buyer.OtpAttemptsLT(maxAttempts)).
+		AddOtpAttempts(1).
+		Exec(ctx)
+}
+
+// CompleteBuyerLogin consumes the login code so it cannot be replayed. The code is checked
+// and cleared in one conditional update, so of two concurrent requests only one signs in.
+// It returns nil when the code is wrong, expired or locked.
+func (r *repository) CompleteBuyerLogin(ctx context.Context, id, otpHash string, maxAttempts int) (*ent.Buyer, error) {
+	now := time.Now()
+	n, err := r.db.Buyer.Update().
+		Where(
+			buyer.ID(id),
+			buyer.OtpHash(otpHash),
+			buyer.OtpAttemptsLT(maxAttempts),
+			buyer.OtpExpiresAtGT(now),
+		).
+		ClearOtpHash().
+		ClearOtpExpiresAt().
+		SetOtpAttempts(0).
+		SetLastLoginAt(now).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	if err := r.db.Buyer.Update().
+		Where(buyer.ID(id), buyer.PhoneVerifiedAtIsNil()).
+		SetPhoneVerifiedAt(now).
+		Exec(ctx); err != nil {
+		return nil, err
+	}
+	return r.db.Buyer.Get(ctx, id)
+}
+
+func (r *repository) UpdateBuyerProfile(ctx context.Context, id string, name, email *string) (*ent.Buyer, error) {
+	update := r.db.Buyer.UpdateOneID(id)
+	if name != nil {
+		update.SetName(*name)
+	}
+	if email != nil {
+		update.SetEmail(*email)
+	}
+	return update.Save(ctx)
+}
+
+// AddBuyerShortlist shortlists a listing. Shortlisting the same listing twice is a no-op.
+func (r *repository) AddBuyerShortlist(ctx context.Context, buyerID, entityType, entityID string) error {
+	err := r.db.BuyerShortlist.Create().
+		SetID(uuid.New().String()).
+		SetBuyerID(buyerID).
+		SetEntityType(buyershortlist.EntityType(entityType)).
+		SetEntityID(entityID).
+		Exec(ctx)
+	if err != nil && !ent.IsConstraintError(err) {
+		logger.Get().Error().Err(err).Str("buyer_id", buyerID).Msg("Failed to add shortlist entry")
+		return err
+	}
+	return nil
+}
+
+func (r *repository) RemoveBuyerShortlist(ctx context.Context, buyerID, entityType, entityID string) (bool, error) {
+	deleted, err := r.db.BuyerShortlist.Delete().
+		Where(
+			buyershortlist.BuyerID(buyerID),
+			buyershortlist.EntityTypeEQ(buyershortlist.EntityType(entityType)),
+			buyershortlist.EntityID(entityID),
+		).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+// GetBuyerShortlist returns a buyer's shortlist, newest first, with listing names resolved.
+func (r *repository) GetBuyerShortlist(ctx context.Context, buyerID string) ([]domain.ShortlistItem, error) {
+	entries, err := r.db.BuyerShortlist.Query().
+		Where(buyershortlist.BuyerID(buyerID)).
+		Order(ent.Desc(buyershortlist.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("buyer_id", buyerID).Msg("Failed to get shortlist")
+		return nil, err
+	}
+
+	var projectIDs, propertyIDs []string
+	for _, entry := range entries {
+		if entry.EntityType == buyershortlist.EntityTypeProject {
+			projectIDs = append(projectIDs, entry.EntityID)
+		} else {
+			propertyIDs = append(propertyIDs, entry.EntityID)
+		}
+	}
+
+	projects := make(map[string]*ent.Project)
+	if len(projectIDs) > 0 {
+		rows, err := r.db.Project.Query().
+			Where(projectEnt.IDIn(projectIDs...)).
+			Select(projectEnt.FieldID, projectEnt.FieldName, projectEnt.FieldSlug, projectEnt.FieldIsDeleted).
+			All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range rows {
+			projects[p.ID] = p
+		}
+	}
+	properties := make(map[string]*ent.Property)
+	if len(propertyIDs) > 0 {
+		rows, err := r.db.Property.Query().
+			Where(property.IDIn(propertyIDs...)).
+			Select(property.FieldID, property.FieldName, property.FieldSlug, property.FieldIsDeleted).
+			All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range rows {
+			properties[p.ID] = p
+		}
+	}
+
+	items := make([]domain.ShortlistItem, 0, len(entries))
+	for _, entry := range entries {
+		item := domain.ShortlistItem{
+			EntityType:    entry.EntityType.String(),
+			EntityID:      entry.EntityID,
+			ShortlistedAt: entry.CreatedAt,
+		}
+		if p, ok := projects[entry.EntityID]; ok && entry.EntityType == buyershortlist.EntityTypeProject {
+			item.Name, item.Slug, item.Available = p.Name, p.Slug, !p.IsDeleted
+		}
+		if p, ok := properties[entry.EntityID]; ok && entry.EntityType == buyershortlist.EntityTypeProperty {
+			item.Name, item.Slug, item.Available = p.Name, p.Slug, !p.IsDeleted
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// GetBuyerEnquiries returns the leads submitted with a buyer's phone, newest first.
+func (r *repository) GetBuyerEnquiries(ctx context.Context, phone string, offset, limit int) ([]*ent.Leads, int, error) {
+	query := r.db.Leads.Query().
+		Where(leads.Phone(phone), leads.DeletedAtIsNil())
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count buyer enquiries")
+		return nil, 0, err
+	}
+
+	leadsData, err := query.
+		WithProperty(func(q *ent.PropertyQuery) {
+			q.WithProject()
+		}).
+		WithProject().
+		Order(ent.Desc(leads.FieldCreatedAt)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get buyer enquiries")
+		return nil, 0, err
+	}
+	return leadsData, total, nil
+}
+
+func (r *repository) CreateBuyerComparison(ctx context.Context, buyerID, name string, projectIDs []string) (*ent.BuyerComparison, error) {
+	comparison, err := r.db.BuyerComparison.Create().
+		SetID(uuid.New().String()).
+		SetBuyerID(buyerID).
+		SetName(name).
+		SetProjectIds(projectIDs).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("buyer_id", buyerID).Msg("Failed to create comparison")
+		return nil, err
+	}
+	return comparison, nil
+}
+
+func (r *repository) ListBuyerComparisons(ctx context.Context, buyerID string) ([]*ent.BuyerComparison, error) {
+	return r.db.BuyerComparison.Query().
+		Where(buyercomparison.BuyerID(buyerID)).
+		Order(ent.Desc(buyercomparison.FieldUpdatedAt)).
+		All(ctx)
+}
+
+// GetBuyerComparison returns a comparison only if it belongs to the buyer.
+func (r *repository) GetBuyerComparison(ctx context.Context, buyerID, id string) (*ent.BuyerComparison, error) {
+	comparison, err := r.db.BuyerComparison.Query().
+		Where(buyercomparison.ID(id), buyercomparison.BuyerID(buyerID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("comparison not found")
+		}
+		return nil, err
+	}
+	return comparison, nil
+}
+
+func (r *repository) UpdateBuyerComparison(ctx context.Context, id string, name *string, projectIDs []string) (*ent.BuyerComparison, error) {
+	update := r.db.BuyerComparison.UpdateOneID(id)
+	if name != nil {
+		update.SetName(*name)
+	}
+	if projectIDs != nil {
+		update.SetProjectIds(projectIDs)
+	}
+	return update.Save(ctx)
+}
+
+func (r *repository) DeleteBuyerComparison(ctx context.Context, buyerID, id string) (bool, error) {
+	deleted, err := r.db.BuyerComparison.Delete().
+		Where(buyercomparison.ID(id), buyercomparison.BuyerID(buyerID)).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
@@ -17,6 +17,7 @@ func (r *repository) GetProjectByCanonicalURL(ctx context.Context, url string) (
 		).
 		WithLocation().
 		WithDeveloper().
+		WithReraRegistrations().
 		Only(ctx)
 }
 
@@ -25,6 +26,8 @@ func (r *repository) GetPropertyByCanonicalURL(ctx context.Context, url string)
 		Where(
 			// Correctly grouped SQL expression
 			property.SlugEQ(url),
+			property.ModerationStatusEQ(property.ModerationStatusApproved),
+			property.IsDeleted(false),
 		).
 		Only(ctx)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/schema"
@@ -20,6 +21,62 @@ type AppRepository interface {
 	CreateUser(ctx context.Context, user *ent.User) (*ent.User, error)
 	CheckIfUserExistsByEmail(ctx context.Context, email string) (bool, error)
 	CheckIfUserExistsByID(ctx context.Context, userID string) (bool, error)
+	GetUserByID(ctx context.Context, id string) (*ent.User, error)
+	UpdateUserProfile(ctx context.Context, id string, input domain.UserProfile) (*ent.User, error)
+
+	// User management
+	ListUsers(ctx context.Context, filter domain.UserFilter, offset, limit int) ([]*ent.User, int, error)
+	GetUserForAdmin(ctx context.Context, id string) (*ent.User, error)
+	GetUserAccess(ctx context.Context, id string) (*ent.User, error)
+	CreateUserByAdmin(ctx context.Context, input domain.NewUser) (*ent.User, error)
+	UpdateUserByAdmin(ctx context.Context, id string, input domain.UserUpdate, updatedBy string) (*ent.User, error)
+
+	// Emailed tokens
+	CreateUserToken(ctx context.Context, userID, purpose, tokenHash, email string, expiresAt time.Time) error
+	LastUserTokenAt(ctx context.Context, userID, purpose string) (*time.Time, error)
+	ConsumeUserToken(ctx context.Context, tokenHash, purpose string) (*ent.UserToken, error)
+	SetUserPassword(ctx context.Context, userID, passwordHash string) error
+	MarkUserEmailVerified(ctx context.Context, userID, email string) (bool, error)
+
+	// Audit log
+	CreateAuditLog(ctx context.Context, entry domain.AuditEntry) error
+	ListAuditLogs(ctx context.Context, filter domain.AuditLogFilter, offset, limit int) ([]*ent.AuditLog, int, error)
+
+	// API keys
+	CreateAPIKey(ctx context.Context, input domain.NewAPIKey) (*ent.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*ent.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*ent.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id, revokedBy string) (*ent.APIKey, error)
+	TouchAPIKey(ctx context.Context, id, ipAddress string) error
+
+	// Login history and throttling
+	CreateLoginAttempt(ctx context.Context, attempt domain.LoginAttempt) error
+	CountFailedLoginsFromNetwork(ctx context.Context, ipAddress string, since time.Time) (int, error)
+	ListLoginAttempts(ctx context.Context, userID string, offset, limit int) ([]*ent.LoginAttempt, int, error)
+	RecordFailedLogin(ctx context.Context, userID string, maxAttempts int, lockedUntil time.Time) (*ent.User, error)
+	RecordSuccessfulLogin(ctx context.Context, userID string) error
+
+	// Two-factor authentication
+	GetUserTwoFactor(ctx context.Context, userID string) (*ent.UserTwoFactor, error)
+	StartTwoFactorSetup(ctx context.Context, userID, encryptedSecret string) error
+	EnableTwoFactor(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) (bool, error)
+	UseTwoFactorStep(ctx context.Context, userID string, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodeHashes []string) error
+	DeleteUserTwoFactor(ctx context.Context, userID string) (bool, error)
+	CreateLoginChallenge(ctx context.Context, userID, purpose, tokenHash string, expiresAt time.Time) error
+	GetLoginChallenge(ctx context.Context, tokenHash string) (*ent.LoginChallenge, error)
+	FailLoginChallenge(ctx context.Context, id string, maxAttempts int) error
+	ConsumeLoginChallenge(ctx context.Context, id string) (bool, error)
+
+	// Sessions
+	CreateRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time, meta domain.SessionMeta) (*ent.RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*ent.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, current *ent.RefreshToken, tokenHash string, expiresAt time.Time, meta domain.SessionMeta) (*ent.RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID, reason string) error
+	RevokeUserSession(ctx context.Context, userID, familyID, reason string) (bool, error)
+	RevokeUserRefreshTokens(ctx context.Context, userID, reason string) error
+	ListActiveSessions(ctx context.Context, userID string) ([]*ent.RefreshToken, error)
 
 	// Project
 	GetProjectByID(id string) (*ent.Project, error)
@@ -47,10 +104,13 @@ type AppRepository interface {
 
 	// Property
 	GetPropertyByID(id string) (*ent.Property, error)
+	GetPublishedPropertyByID(ctx context.Context, id string) (*ent.Property, error)
 	UpdateProperty(input domain.Property) (*ent.Property, error)
 	GetPropertiesOfProject(projectID string) ([]*ent.Property, error)
 	AddProperty(input domain.Property) (*PropertyResult, error)
-	GetAllProperties(offset, limit int, filters map[string]interface{}) ([]*ent.Property, int, error)
+	GetAllProperties(offset, limit int, filters map[string]interface{}, sortBy string) ([]*ent.Property, int, error)
+	GetPropertyFacets(ctx context.Context, filters map[string]interface{}) (*response.PropertyFacets, error)
+	SyncPropertyAttributes(ctx context.Context) (int, error)
 	DeleteProperty(id string, hardDelete bool) error
 	IsPropertyDeleted(id string) (bool, error)
 	GetPropertyBySlug(ctx context.Context, slug string) (*ent.Property, error)
@@ -82,6 +142,7 @@ type AppRepository interface {
 
 	// Generic Search
 	GetCustomSearchPageFromSlug(ctx context.Context, slug string) (*ent.CustomSearchPage, error)
+	GetCustomSearchPageByID(ctx context.Context, id string) (*ent.CustomSearchPage, error)
 	GetAllCustomSearchPages(ctx context.Context) ([]*ent.CustomSearchPage, error)
 	AddCustomSearchPage(ctx context.Context, customSearchPage *ent.CustomSearchPage) (*ent.CustomSearchPage, error)
 	UpdateCustomSearchPage(ctx context.Context, customSearchPage *ent.CustomSearchPage) (*ent.CustomSearchPage, error)
@@ -95,6 +156,121 @@ type AppRepository interface {
 	UpdateLead(ctx context.Context, lead *ent.Leads) (*ent.Leads, error)
 	GetAllLeads(ctx context.Context, filters map[string]interface{}) ([]*ent.Leads, error)
 	GetLeadsByDate(ctx context.Context, date string) ([]*ent.Leads, error)
+
+	// Project import/export
+	GetProjectsBySlugs(ctx context.Context, slugs []string) ([]*ent.Project, error)
+	FindDeveloperByKey(ctx context.Context, key string) (*ent.Developer, error)
+	GetProjectsForExport(ctx context.Context, slugs []string, developerID string) ([]*ent.Project, error)
+	ImportProjectBundle(ctx context.Context, bundle domain.ProjectBundle) ([]domain.BundleRowResult, error)
+
+	// Deletion
+	GetProjectDeletionReport(ctx context.Context, projectID string) (*schema.DeletionReport, error)
+	GetPropertyDeletionReport(ctx context.Context, propertyID string) (*schema.DeletionReport, error)
+	SoftDeleteWithRecord(ctx context.Context, record domain.DeletionRecord, report *schema.DeletionReport) (*ent.DeletionRecord, error)
+	GetPendingDeletionRecord(ctx context.Context, entityType, entityID string) (*ent.DeletionRecord, error)
+	RestoreDeletion(ctx context.Context, record *ent.DeletionRecord) error
+	GetPurgeableDeletionRecords(ctx context.Context, now time.Time) ([]*ent.DeletionRecord, error)
+	PurgeDeletion(ctx context.Context, record *ent.DeletionRecord, redirects []domain.Redirect) error
+
+	// Slugs and redirects
+	GetSlugOwner(ctx context.Context, slug string) (*ent.SlugRecord, error)
+	GetSlugHistory(ctx context.Context, entityType, entityID string) ([]*ent.SlugRecord, error)
+	ChangeSlug(ctx context.Context, change domain.SlugChange) error
+	SyncSlugRegistry(ctx context.Context) (int, []error, error)
+	ReleaseSlug(ctx context.Context, slug string) error
+	GetRedirectByPath(ctx context.Context, path string) (*ent.Redirect, error)
+	GetRedirectByID(ctx context.Context, id string) (*ent.Redirect, error)
+	ListRedirects(ctx context.Context, search string, offset, limit int) ([]*ent.Redirect, int, error)
+	SaveRedirect(ctx context.Context, input domain.Redirect) (*ent.Redirect, error)
+	UpdateRedirect(ctx context.Context, id string, input domain.Redirect) (*ent.Redirect, error)
+	DeleteRedirect(ctx context.Context, id string) error
+
+	// Moderation
+	SubmitPropertyRevision(ctx context.Context, input domain.PropertyRevision) (*ent.PropertyRevision, error)
+	GetPropertyRevisionByID(ctx context.Context, id string) (*ent.PropertyRevision, error)
+	GetPendingPropertyRevision(ctx context.Context, propertyID string) (*ent.PropertyRevision, error)
+	ListPropertyRevisions(ctx context.Context, status, propertyID string, offset, limit int) ([]*ent.PropertyRevision, int, error)
+	ReviewPropertyRevision(ctx context.Context, revision *ent.PropertyRevision, status, reviewedBy, reason string, apply *domain.Property) (*ent.PropertyRevision, error)
+
+	// Notifications
+	CreateNotification(ctx context.Context, input domain.Notification) (*ent.Notification, error)
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, offset, limit int) ([]*ent.Notification, int, error)
+	MarkNotificationRead(ctx context.Context, id, userID string) error
+
+	// Partner portal
+	ListTeamMembers(ctx context.Context, ownerID string) ([]*ent.User, error)
+	CreatePartnerInvitation(ctx context.Context, input domain.PartnerInvitation, placeholderPassword string) (*ent.User, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*ent.UserInvitation, error)
+	AcceptInvitation(ctx context.Context, invitation *ent.UserInvitation, passwordHash string) (*ent.User, error)
+	DeactivateTeamMember(ctx context.Context, ownerID, memberID string) error
+	IncrementPropertyViews(ctx context.Context, id string, views int) error
+	GetPartnerLeads(ctx context.Context, ownerID string, filters map[string]interface{}, offset, limit int) ([]*ent.Leads, int, error)
+	GetPartnerListingStats(ctx context.Context, ownerID string, filters map[string]interface{}) ([]domain.ListingStats, error)
+
+	// Listing events
+	CreateListingEvents(ctx context.Context, events []domain.ListingEvent) error
+	RollupListingEvents(ctx context.Context, day time.Time) (int, error)
+	GetListingEventRollups(ctx context.Context, entityType, entityID, fromDay, toDay string) ([]*ent.ListingEventRollup, error)
+
+	// Trending
+	GetLeadSignals(ctx context.Context, since time.Time) ([]domain.LeadSignal, error)
+	GetViewSignals(ctx context.Context, since time.Time) ([]domain.ViewSignal, error)
+	ReplaceProjectPopularity(ctx context.Context, scores []domain.ProjectPopularity, computedAt time.Time) (int, error)
+	ListTrendingProjects(ctx context.Context, filters map[string]interface{}, limit int) ([]*ent.ProjectPopularity, error)
+
+	// Similar projects
+	GetSimilarProjectCandidates(ctx context.Context, excludeID, city, projectType string) ([]*ent.Project, error)
+
+	// Saved searches
+	CreateSavedSearch(ctx context.Context, input domain.SavedSearch) (*ent.SavedSearch, error)
+	CountActiveSavedSearches(ctx context.Context, phone, email string) (int, error)
+	GetSavedSearchByID(ctx context.Context, id string) (*ent.SavedSearch, error)
+	MarkSavedSearchVerified(ctx context.Context, id string) (*ent.SavedSearch, error)
+	IncrementSavedSearchVerificationAttempts(ctx context.Context, id string) error
+	UnsubscribeSavedSearch(ctx context.Context, token string) error
+	GetAlertingSavedSearches(ctx context.Context) ([]*ent.SavedSearch, error)
+	GetProjectsChangedSince(ctx context.Context, filters map[string]interface{}, since time.Time, limit int) ([]*ent.Project, error)
+	MarkSavedSearchChecked(ctx context.Context, id string, checkedAt time.Time, notified bool) error
+
+	// Price history
+	GetProjectPriceHistory(ctx context.Context, projectID string) ([]*ent.ProjectPricePoint, error)
+	GetOverallPriceSeries(ctx context.Context, projectIDs []string) (map[string][]domain.PricePoint, error)
+
+	// Roles
+	ListRoles(ctx context.Context) ([]*ent.Role, error)
+	GetRole(ctx context.Context, name string) (*ent.Role, error)
+	UpdateRolePermissions(ctx context.Context, name string, permissions []string, description *string, twoFactorRequired *bool, updatedBy string) (*ent.Role, error)
+	EnsureRoles(ctx context.Context, defaults map[string][]string) error
+
+	// Buyers
+	GetBuyerByPhone(ctx context.Context, phone string) (*ent.Buyer, error)
+	GetBuyerByID(ctx context.Context, id string) (*ent.Buyer, error)
+	CreateBuyer(ctx context.Context, phone string) (*ent.Buyer, error)
+	SetBuyerOTP(ctx context.Context, id, otpHash string, expiresAt time.Time) error
+	IncrementBuyerOTPAttempts(ctx context.Context, id string, maxAttempts int) error
+	CompleteBuyerLogin(ctx context.Context, id, otpHash string, maxAttempts int) (*ent.Buyer, error)
+	UpdateBuyerProfile(ctx context.Context, id string, name, email *string) (*ent.Buyer, error)
+	AddBuyerShortlist(ctx context.Context, buyerID, entityType, entityID string) error
+	RemoveBuyerShortlist(ctx context.Context, buyerID, entityType, entityID string) (bool, error)
+	GetBuyerShortlist(ctx context.Context, buyerID string) ([]domain.ShortlistItem, error)
+	GetBuyerEnquiries(ctx context.Context, phone string, offset, limit int) ([]*ent.Leads, int, error)
+	CreateBuyerComparison(ctx context.Context, buyerID, name string, projectIDs []string) (*ent.BuyerComparison, error)
+	ListBuyerComparisons(ctx context.Context, buyerID string) ([]*ent.BuyerComparison, error)
+	GetBuyerComparison(ctx context.Context, buyerID, id string) (*ent.BuyerComparison, error)
+	UpdateBuyerComparison(ctx context.Context, id string, name *string, projectIDs []string) (*ent.BuyerComparison, error)
+	DeleteBuyerComparison(ctx context.Context, buyerID, id string) (bool, error)
+
+	// RERA
+	GetReraRegistrationsOfProject(ctx context.Context, projectID string) ([]*ent.ReraRegistration, error)
+	GetReraRegistrationByID(ctx context.Context, id string) (*ent.ReraRegistration, error)
+	ExistReraRegistration(ctx context.Context, state, registrationNumber, excludeID string) (bool, error)
+	AddReraRegistration(ctx context.Context, input domain.ReraRegistration) (*ent.ReraRegistration, error)
+	UpdateReraRegistration(ctx context.Context, input domain.ReraRegistration) (*ent.ReraRegistration, error)
+	DeleteReraRegistration(ctx context.Context, id string) error
+	GetReraRegistrationsForStatusCheck(ctx context.Context) ([]*ent.ReraRegistration, error)
+	UpdateReraRegistrationStatus(ctx context.Context, id string, status string) error
+	GetReraRegistrationsByStatus(ctx context.Context, statuses []string) ([]*ent.ReraRegistration, error)
+	GetProjectsWithoutReraRegistrations(ctx context.Context) ([]*ent.Project, error)
 }
 
 func NewRepository(db *ent.Client) AppRepository {
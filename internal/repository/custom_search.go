@@ -6,11 +6,26 @@ import (
 	"github.com/VI-IM/im_backend_go/ent/blogs"
 	"github.com/VI-IM/im_backend_go/ent/customsearchpage"
 	"github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/ent/property"
 	"github.com/VI-IM/im_backend_go/response"
 )
 
 func (r *repository) CheckURLExists(ctx context.Context, url string) (*response.URLExistsResult, error) {
-	// Check blogs first
+	// The slug registry covers every entity type, including slugs kept for redirects
+	owner, err := r.GetSlugOwner(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if owner != nil {
+		return &response.URLExistsResult{
+			Exists:     true,
+			EntityType: string(owner.EntityType),
+			EntityID:   owner.EntityID,
+			Retired:    !owner.IsCurrent,
+		}, nil
+	}
+
+	// Check blogs
 	blog, err := r.db.Blogs.Query().
 		Where(blogs.Slug(url)).
 		First(ctx)
@@ -46,6 +61,18 @@ func (r *repository) CheckURLExists(ctx context.Context, url string) (*response.
 		}, nil
 	}
 
+	// Check properties
+	propertyEntity, err := r.db.Property.Query().
+		Where(property.Slug(url)).
+		First(ctx)
+	if err == nil && propertyEntity != nil {
+		return &response.URLExistsResult{
+			Exists:     true,
+			EntityType: "property",
+			EntityID:   propertyEntity.ID,
+		}, nil
+	}
+
 	// If we get here, nothing was found
 	return &response.URLExistsResult{
 		Exists:     false,
@@ -0,0 +1,377 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/customsearchpage"
+	"github.com/VI-IM/im_backend_go/ent/deletionrecord"
+	"github.com/VI-IM/im_backend_go/ent/leads"
+	projectEnt "github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/ent/property"
+	"github.com/VI-IM/im_backend_go/ent/reraregistration"
+	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// GetProjectDeletionReport lists everything that references a project.
+func (r *repository) GetProjectDeletionReport(ctx context.Context, projectID string) (*schema.DeletionReport, error) {
+	project, err := r.db.Project.Get(ctx, projectID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("project not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get project")
+		return nil, err
+	}
+
+	report := &schema.DeletionReport{
+		EntityType:        domain.DeletionEntityProject,
+		EntityID:          project.ID,
+		Name:              project.Name,
+		Slug:              project.Slug,
+		Properties:        []schema.DependentRecord{},
+		CustomSearchPages: []schema.DependentRecord{},
+	}
+
+	properties, err := r.db.Property.Query().
+		Where(
+			property.ProjectID(projectID),
+			property.IsDeleted(false),
+		).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get properties of project")
+		return nil, err
+	}
+	for _, p := range properties {
+		report.Properties = append(report.Properties, schema.DependentRecord{ID: p.ID, Name: p.Name, Slug: p.Slug})
+	}
+
+	report.LeadCount, err = r.db.Leads.Query().
+		Where(leads.Or(
+			leads.HasProjectWith(projectEnt.ID(projectID)),
+			leads.HasPropertyWith(property.ProjectID(projectID)),
+		)).
+		Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count leads of project")
+		return nil, err
+	}
+
+	report.ReraRegistrations, err = r.db.ReraRegistration.Query().
+		Where(reraregistration.ProjectID(projectID)).
+		Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count rera registrations of project")
+		return nil, err
+	}
+
+	// Custom search pages reference projects through their list filters.
+	pages, err := r.db.CustomSearchPage.Query().
+		Where(
+			customsearchpage.IsDeleted(false),
+			func(s *sql.Selector) {
+				s.Where(sql.Or(
+					sql.ExprP("filters->>'project_id' = ?", project.ID),
+					sql.ExprP("LOWER(filters->>'name') = ?", strings.ToLower(project.Name)),
+				))
+			},
+		).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get custom search pages of project")
+		return nil, err
+	}
+	for _, page := range pages {
+		report.CustomSearchPages = append(report.CustomSearchPages, schema.DependentRecord{ID: page.ID, Name: page.Title, Slug: page.Slug})
+	}
+
+	return report, nil
+}
+
+// GetPropertyDeletionReport lists everything that references a property.
+func (r *repository) GetPropertyDeletionReport(ctx context.Context, propertyID string) (*schema.DeletionReport, error) {
+	p, err := r.db.Property.Get(ctx, propertyID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("property not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get property")
+		return nil, err
+	}
+
+	report := &schema.DeletionReport{
+		EntityType:        domain.DeletionEntityProperty,
+		EntityID:          p.ID,
+		Name:              p.Name,
+		Slug:              p.Slug,
+		Properties:        []schema.DependentRecord{},
+		CustomSearchPages: []schema.DependentRecord{},
+	}
+
+	report.LeadCount, err = r.db.Leads.Query().
+		Where(leads.HasPropertyWith(property.ID(propertyID))).
+		Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count leads of property")
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// SoftDeleteWithRecord marks a project or property, and any cascaded properties, as deleted
+// and stores the deletion record that allows it to be restored until RestoreUntil.
+func (r *repository) SoftDeleteWithRecord(ctx context.Context, record domain.DeletionRecord, report *schema.DeletionReport) (*ent.DeletionRecord, error) {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	switch record.EntityType {
+	case domain.DeletionEntityProject:
+		err = tx.Project.UpdateOneID(record.EntityID).
+			SetIsDeleted(true).
+			SetDeletedAt(now).
+			Exec(ctx)
+	case domain.DeletionEntityProperty:
+		err = tx.Property.UpdateOneID(record.EntityID).
+			SetIsDeleted(true).
+			SetDeletedAt(now).
+			Exec(ctx)
+	default:
+		err = fmt.Errorf("unknown entity type %s", record.EntityType)
+	}
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to soft delete entity")
+		return nil, err
+	}
+
+	if len(record.CascadedPropertyIDs) > 0 {
+		err = tx.Property.Update().
+			Where(property.IDIn(record.CascadedPropertyIDs...)).
+			SetIsDeleted(true).
+			SetDeletedAt(now).
+			Exec(ctx)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to soft delete cascaded properties")
+			return nil, err
+		}
+	}
+
+	create := tx.DeletionRecord.Create().
+		SetID(uuid.New().String()).
+		SetEntityType(deletionrecord.EntityType(record.EntityType)).
+		SetEntityID(record.EntityID).
+		SetSlug(record.Slug).
+		SetRedirectTo(record.RedirectTo).
+		SetCascadedPropertyIds(record.CascadedPropertyIDs).
+		SetDeletedBy(record.DeletedBy).
+		SetDeletedAt(now).
+		SetRestoreUntil(record.RestoreUntil)
+	if report != nil {
+		create.SetReport(*report)
+	}
+
+	deletion, err := create.Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create deletion record")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// GetPendingDeletionRecord returns the latest deletion of an entity that has been neither
+// restored nor purged, or nil if there is none.
+func (r *repository) GetPendingDeletionRecord(ctx context.Context, entityType, entityID string) (*ent.DeletionRecord, error) {
+	record, err := r.db.DeletionRecord.Query().
+		Where(
+			deletionrecord.EntityTypeEQ(deletionrecord.EntityType(entityType)),
+			deletionrecord.EntityID(entityID),
+			deletionrecord.RestoredAtIsNil(),
+			deletionrecord.PurgedAtIsNil(),
+		).
+		Order(ent.Desc(deletionrecord.FieldDeletedAt)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get deletion record")
+		return nil, err
+	}
+	return record, nil
+}
+
+// RestoreDeletion undoes a soft delete, including any properties deleted with it.
+func (r *repository) RestoreDeletion(ctx context.Context, record *ent.DeletionRecord) error {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	switch record.EntityType {
+	case deletionrecord.EntityTypeProject:
+		err = tx.Project.UpdateOneID(record.EntityID).
+			SetIsDeleted(false).
+			ClearDeletedAt().
+			Exec(ctx)
+	case deletionrecord.EntityTypeProperty:
+		err = tx.Property.UpdateOneID(record.EntityID).
+			SetIsDeleted(false).
+			ClearDeletedAt().
+			Exec(ctx)
+	}
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to restore entity")
+		return err
+	}
+
+	if len(record.CascadedPropertyIds) > 0 {
+		err = tx.Property.Update().
+			Where(property.IDIn(record.CascadedPropertyIds...)).
+			SetIsDeleted(false).
+			ClearDeletedAt().
+			Exec(ctx)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to restore cascaded properties")
+			return err
+		}
+	}
+
+	if err := tx.DeletionRecord.UpdateOneID(record.ID).SetRestoredAt(time.Now()).Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to update deletion record")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to commit transaction")
+		return err
+	}
+	return nil
+}
+
+// GetPurgeableDeletionRecords returns deletions whose restore window closed before now.
+func (r *repository) GetPurgeableDeletionRecords(ctx context.Context, now time.Time) ([]*ent.DeletionRecord, error) {
+	records, err := r.db.DeletionRecord.Query().
+		Where(
+			deletionrecord.RestoreUntilLT(now),
+			deletionrecord.RestoredAtIsNil(),
+			deletionrecord.PurgedAtIsNil(),
+		).
+		Order(ent.Asc(deletionrecord.FieldRestoreUntil)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get purgeable deletion records")
+		return nil, err
+	}
+	return records, nil
+}
+
+// PurgeDeletion hard-deletes a soft-deleted entity and writes the redirects for its
+// removed pages. Leads are kept, with their reference to the removed entity cleared.
+func (r *repository) PurgeDeletion(ctx context.Context, record *ent.DeletionRecord, redirects []domain.Redirect) error {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	propertyIDs := append([]string{}, record.CascadedPropertyIds...)
+	if record.EntityType == deletionrecord.EntityTypeProperty {
+		propertyIDs = append(propertyIDs, record.EntityID)
+	}
+
+	if len(propertyIDs) > 0 {
+		if err := tx.Leads.Update().
+			Where(leads.HasPropertyWith(property.IDIn(propertyIDs...))).
+			ClearProperty().
+			Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to detach leads from properties")
+			return err
+		}
+		if _, err := tx.Property.Delete().
+			Where(property.IDIn(propertyIDs...)).
+			Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to purge properties")
+			return err
+		}
+	}
+
+	if record.EntityType == deletionrecord.EntityTypeProject {
+		if err := tx.Leads.Update().
+			Where(leads.HasProjectWith(projectEnt.ID(record.EntityID))).
+			ClearProject().
+			Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to detach leads from project")
+			return err
+		}
+		// Properties deleted separately keep their own deletion record.
+		if err := tx.Property.Update().
+			Where(property.ProjectID(record.EntityID)).
+			ClearProjectID().
+			Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to detach properties from project")
+			return err
+		}
+		if _, err := tx.ReraRegistration.Delete().
+			Where(reraregistration.ProjectID(record.EntityID)).
+			Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to purge rera registrations")
+			return err
+		}
+		if err := tx.Project.DeleteOneID(record.EntityID).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+			logger.Get().Error().Err(err).Msg("Failed to purge project")
+			return err
+		}
+	}
+
+	switch record.EntityType {
+	case deletionrecord.EntityTypeProject:
+		err = retireEntitySlugs(ctx, tx.Client(), domain.SlugEntityProject, record.EntityID)
+	case deletionrecord.EntityTypeProperty:
+		err = retireEntitySlugs(ctx, tx.Client(), domain.SlugEntityProperty, record.EntityID)
+	}
+	if err == nil {
+		err = retireEntitySlugs(ctx, tx.Client(), domain.SlugEntityProperty, record.CascadedPropertyIds...)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, rd := range redirects {
+		if err := upsertRedirect(ctx, tx.Client(), rd); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.DeletionRecord.UpdateOneID(record.ID).SetPurgedAt(time.Now()).Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to update deletion record")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to commit transaction")
+		return err
+	}
+	return nil
+}
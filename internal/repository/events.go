@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/listingevent"
+	"github.com/VI-IM/im_backend_go/ent/listingeventrollup"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// eventInsertChunk keeps bulk inserts well below the placeholder limit of the driver.
+const eventInsertChunk = 1000
+
+// CreateListingEvents batch-writes buffered events in one transaction, so a failed batch
+// can be retried without double counting.
+func (r *repository) CreateListingEvents(ctx context.Context, events []domain.ListingEvent) error {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	for start := 0; start < len(events); start += eventInsertChunk {
+		end := min(start+eventInsertChunk, len(events))
+		builders := make([]*ent.ListingEventCreate, 0, end-start)
+		for _, e := range events[start:end] {
+			builders = append(builders, client.ListingEvent.Create().
+				SetID(uuid.New().String()).
+				SetEntityType(listingevent.EntityType(e.EntityType)).
+				SetEntityID(e.EntityID).
+				SetEventType(listingevent.EventType(e.EventType)).
+				SetSessionID(e.SessionID).
+				SetSource(e.Source).
+				SetOccurredAt(e.OccurredAt))
+		}
+		if err := client.ListingEvent.CreateBulk(builders...).Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Int("events", len(events)).Msg("Failed to write listing events")
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// istDay returns midnight IST of the IST calendar day containing t.
+func istDay(t time.Time) time.Time {
+	t = t.In(istLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, istLocation)
+}
+
+// RollupListingEvents recounts the events of the IST day containing day and replaces
+// that day's rollups. It returns the number of rollup rows written.
+func (r *repository) RollupListingEvents(ctx context.Context, day time.Time) (int, error) {
+	dayStart := istDay(day)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	date := dayStart.Format(time.DateOnly)
+
+	var counts []struct {
+		EntityType string `json:"entity_type"`
+		EntityID   string `json:"entity_id"`
+		EventType  string `json:"event_type"`
+		Count      int    `json:"count"`
+	}
+	err := r.db.ListingEvent.Query().
+		Where(listingevent.OccurredAtGTE(dayStart), listingevent.OccurredAtLT(dayEnd)).
+		GroupBy(listingevent.FieldEntityType, listingevent.FieldEntityID, listingevent.FieldEventType).
+		Aggregate(ent.Count()).
+		Scan(ctx, &counts)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("day", date).Msg("Failed to count listing events")
+		return 0, err
+	}
+
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	if _, err := client.ListingEventRollup.Delete().
+		Where(listingeventrollup.DateEQ(date)).
+		Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	for start := 0; start < len(counts); start += eventInsertChunk {
+		end := min(start+eventInsertChunk, len(counts))
+		builders := make([]*ent.ListingEventRollupCreate, 0, end-start)
+		for _, c := range counts[start:end] {
+			builders = append(builders, client.ListingEventRollup.Create().
+				SetID(uuid.New().String()).
+				SetEntityType(listingeventrollup.EntityType(c.EntityType)).
+				SetEntityID(c.EntityID).
+				SetEventType(listingeventrollup.EventType(c.EventType)).
+				SetDate(date).
+				SetCount(c.Count))
+		}
+		if err := client.ListingEventRollup.CreateBulk(builders...).Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Str("day", date).Msg("Failed to write listing event rollups")
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(counts), nil
+}
+
+// GetListingEventRollups returns the daily rollups of a listing between two YYYY-MM-DD
+// days, inclusive.
+func (r *repository) GetListingEventRollups(ctx context.Context, entityType, entityID, fromDay, toDay string) ([]*ent.ListingEventRollup, error) {
+	rollups, err := r.db.ListingEventRollup.Query().
+		Where(
+			listingeventrollup.EntityTypeEQ(listingeventrollup.EntityType(entityType)),
+			listingeventrollup.EntityID(entityID),
+			listingeventrollup.DateGTE(fromDay),
+			listingeventrollup.DateLTE(toDay),
+		).
+		Order(ent.Asc(listingeventrollup.FieldDate), ent.Asc(listingeventrollup.FieldEventType)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("entity_id", entityID).Msg("Failed to get listing event rollups")
+		return nil, err
+	}
+	return rollups, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/customsearchpage"
 	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/shared/logger"
 	"github.com/google/uuid"
 )
@@ -23,6 +24,10 @@ func (r *repository) GetCustomSearchPageFromSlug(ctx context.Context, slug strin
 	return customSearchPage, nil
 }
 
+func (r *repository) GetCustomSearchPageByID(ctx context.Context, id string) (*ent.CustomSearchPage, error) {
+	return r.db.CustomSearchPage.Get(ctx, id)
+}
+
 func (r *repository) GetAllCustomSearchPages(ctx context.Context) ([]*ent.CustomSearchPage, error) {
 	customSearchPages, err := r.db.CustomSearchPage.Query().All(ctx)
 	if err != nil {
@@ -47,10 +52,32 @@ func (r *repository) AddCustomSearchPage(ctx context.Context, customSearchPage *
 		logger.Get().Error().Err(err).Msg(err.Error())
 		return nil, err
 	}
+	if err := registerSlug(ctx, r.db, domain.SlugEntityCustomSearchPage, customSearchPage.ID, customSearchPage.Slug); err != nil {
+		return nil, err
+	}
 	return customSearchPage, nil
 }
 
 func (r *repository) UpdateCustomSearchPage(ctx context.Context, customSearchPage *ent.CustomSearchPage) (*ent.CustomSearchPage, error) {
+	if customSearchPage.Slug != "" {
+		existing, err := r.db.CustomSearchPage.Get(ctx, customSearchPage.ID)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to get custom search page")
+			return nil, err
+		}
+		if existing.Slug != customSearchPage.Slug {
+			err = r.ChangeSlug(ctx, domain.SlugChange{
+				EntityType: domain.SlugEntityCustomSearchPage,
+				EntityID:   customSearchPage.ID,
+				OldSlug:    existing.Slug,
+				NewSlug:    customSearchPage.Slug,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	update := r.db.CustomSearchPage.UpdateOneID(customSearchPage.ID)
 
 	if customSearchPage.Title != "" {
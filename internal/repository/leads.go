@@ -202,6 +202,10 @@ func (r *repository) GetAllLeads(ctx context.Context, filters map[string]interfa
 		query = query.Where(leads.HasPropertyWith(property.ID(propertyID)))
 	}
 
+	if ownerID, ok := filters["owner_user_id"].(string); ok && ownerID != "" {
+		query = query.Where(leads.HasPropertyWith(property.CreatedByUserID(ownerID)))
+	}
+
 	if phone, ok := filters["phone"].(string); ok && phone != "" {
 		query = query.Where(leads.Phone(phone))
 	}
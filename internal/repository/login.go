@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/loginattempt"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (r *repository) CreateLoginAttempt(ctx context.Context, attempt domain.LoginAttempt) error {
+	return r.db.LoginAttempt.Create().
+		SetID(uuid.New().String()).
+		SetUserID(attempt.UserID).
+		SetEmail(attempt.Email).
+		SetIPAddress(attempt.Meta.IPAddress).
+		SetIPNetwork(domain.LoginNetwork(attempt.Meta.IPAddress)).
+		SetUserAgent(attempt.Meta.UserAgent).
+		SetSuccess(attempt.FailureReason == "").
+		SetFailureReason(attempt.FailureReason).
+		Exec(ctx)
+}
+
+// CountFailedLoginsFromNetwork counts failed sign-ins from an address, or from its IPv6
+// /64, since a time.
+func (r *repository) CountFailedLoginsFromNetwork(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	return r.db.LoginAttempt.Query().
+		Where(
+			loginattempt.IPNetwork(domain.LoginNetwork(ipAddress)),
+			loginattempt.Success(false),
+			loginattempt.CreatedAtGT(since),
+		).
+		Count(ctx)
+}
+
+func (r *repository) ListLoginAttempts(ctx context.Context, userID string, offset, limit int) ([]*ent.LoginAttempt, int, error) {
+	query := r.db.LoginAttempt.Query().Where(loginattempt.UserID(userID))
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	attempts, err := query.
+		Order(ent.Desc(loginattempt.FieldCreatedAt)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return attempts, total, nil
+}
+
+// RecordFailedLogin counts a failed sign-in of a user. Reaching maxAttempts locks the
+// account until lockedUntil and starts the count again.
+func (r *repository) RecordFailedLogin(ctx context.Context, userID string, maxAttempts int, lockedUntil time.Time) (*ent.User, error) {
+	u, err := r.db.User.UpdateOneID(userID).
+		AddFailedLoginCount(1).
+		SetLastFailedLoginAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.FailedLoginCount < maxAttempts {
+		return u, nil
+	}
+	return r.db.User.UpdateOneID(userID).
+		SetFailedLoginCount(0).
+		SetLockedUntil(lockedUntil).
+		Save(ctx)
+}
+
+// RecordSuccessfulLogin sets the last login time and clears failed sign-ins.
+func (r *repository) RecordSuccessfulLogin(ctx context.Context, userID string) error {
+	return r.db.User.UpdateOneID(userID).
+		SetLastLoginTime(time.Now()).
+		SetFailedLoginCount(0).
+		ClearLastFailedLoginAt().
+		ClearLockedUntil().
+		Exec(ctx)
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/property"
+	"github.com/VI-IM/im_backend_go/ent/propertyrevision"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// SubmitPropertyRevision records a submission for review. Pending revisions of the same
+// property are superseded, so a property has at most one submission under review.
+func (r *repository) SubmitPropertyRevision(ctx context.Context, input domain.PropertyRevision) (*ent.PropertyRevision, error) {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	if err := client.PropertyRevision.Update().
+		Where(
+			propertyrevision.PropertyID(input.PropertyID),
+			propertyrevision.StatusEQ(propertyrevision.StatusPending),
+		).
+		SetStatus(propertyrevision.StatusSuperseded).
+		Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Str("property_id", input.PropertyID).Msg("Failed to supersede property revisions")
+		return nil, err
+	}
+
+	revision, err := client.PropertyRevision.Create().
+		SetID(uuid.New().String()).
+		SetPropertyID(input.PropertyID).
+		SetAction(propertyrevision.Action(input.Action)).
+		SetSnapshot(input.Snapshot).
+		SetSubmittedByUserID(input.SubmittedByUserID).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("property_id", input.PropertyID).Msg("Failed to create property revision")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (r *repository) GetPropertyRevisionByID(ctx context.Context, id string) (*ent.PropertyRevision, error) {
+	revision, err := r.db.PropertyRevision.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("property revision not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get property revision")
+		return nil, err
+	}
+	return revision, nil
+}
+
+// GetPendingPropertyRevision returns the submission under review for a property, or nil.
+func (r *repository) GetPendingPropertyRevision(ctx context.Context, propertyID string) (*ent.PropertyRevision, error) {
+	revision, err := r.db.PropertyRevision.Query().
+		Where(
+			propertyrevision.PropertyID(propertyID),
+			propertyrevision.StatusEQ(propertyrevision.StatusPending),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get pending property revision")
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (r *repository) ListPropertyRevisions(ctx context.Context, status, propertyID string, offset, limit int) ([]*ent.PropertyRevision, int, error) {
+	query := r.db.PropertyRevision.Query()
+	if status != "" {
+		query = query.Where(propertyrevision.StatusEQ(propertyrevision.Status(status)))
+	}
+	if propertyID != "" {
+		query = query.Where(propertyrevision.PropertyID(propertyID))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count property revisions")
+		return nil, 0, err
+	}
+
+	revisions, err := query.
+		Order(ent.Asc(propertyrevision.FieldCreatedAt)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list property revisions")
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+// ReviewPropertyRevision records the outcome of a review. Reviewing a create revision also
+// moves the property itself to the same moderation status. When apply is set it is written
+// to the live property in the same transaction, so an approved edit goes live only if the
+// revision was still pending.
+func (r *repository) ReviewPropertyRevision(ctx context.Context, revision *ent.PropertyRevision, status, reviewedBy, reason string, apply *domain.Property) (*ent.PropertyRevision, error) {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	reviewed, err := client.PropertyRevision.UpdateOneID(revision.ID).
+		Where(propertyrevision.StatusEQ(propertyrevision.StatusPending)).
+		SetStatus(propertyrevision.Status(status)).
+		SetReviewedByUserID(reviewedBy).
+		SetReviewReason(reason).
+		SetReviewedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("property revision is no longer pending")
+		}
+		logger.Get().Error().Err(err).Str("revision_id", revision.ID).Msg("Failed to review property revision")
+		return nil, err
+	}
+
+	if revision.Action == propertyrevision.ActionCreate {
+		if err := client.Property.UpdateOneID(revision.PropertyID).
+			SetModerationStatus(property.ModerationStatus(status)).
+			Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Str("property_id", revision.PropertyID).Msg("Failed to update property moderation status")
+			return nil, err
+		}
+	}
+
+	if apply != nil {
+		if _, err := updateProperty(ctx, client, *apply); err != nil {
+			logger.Get().Error().Err(err).Str("revision_id", revision.ID).Msg("Failed to apply property revision")
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return reviewed, nil
+}
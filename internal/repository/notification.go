@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/notification"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+func (r *repository) CreateNotification(ctx context.Context, input domain.Notification) (*ent.Notification, error) {
+	n, err := r.db.Notification.Create().
+		SetID(uuid.New().String()).
+		SetUserID(input.UserID).
+		SetKind(input.Kind).
+		SetTitle(input.Title).
+		SetMessage(input.Message).
+		SetEntityType(input.EntityType).
+		SetEntityID(input.EntityID).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", input.UserID).Msg("Failed to create notification")
+		return nil, err
+	}
+	return n, nil
+}
+
+func (r *repository) ListNotifications(ctx context.Context, userID string, unreadOnly bool, offset, limit int) ([]*ent.Notification, int, error) {
+	query := r.db.Notification.Query().Where(notification.UserID(userID))
+	if unreadOnly {
+		query = query.Where(notification.ReadAtIsNil())
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count notifications")
+		return nil, 0, err
+	}
+
+	notifications, err := query.
+		Order(ent.Desc(notification.FieldCreatedAt)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list notifications")
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications as read.
+func (r *repository) MarkNotificationRead(ctx context.Context, id, userID string) error {
+	updated, err := r.db.Notification.Update().
+		Where(notification.ID(id), notification.UserID(userID), notification.ReadAtIsNil()).
+		SetReadAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("notification_id", id).Msg("Failed to mark notification read")
+		return err
+	}
+	if updated == 0 {
+		exists, err := r.db.Notification.Query().Where(notification.ID(id), notification.UserID(userID)).Exist(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errors.New("notification not found")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/leads"
+	"github.com/VI-IM/im_backend_go/ent/property"
+	"github.com/VI-IM/im_backend_go/ent/user"
+	"github.com/VI-IM/im_backend_go/ent/userinvitation"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+func (r *repository) GetUserByID(ctx context.Context, id string) (*ent.User, error) {
+	u, err := r.db.User.Query().
+		Where(user.ID(id)).
+		WithCreatedByUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("user not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get user")
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *repository) UpdateUserProfile(ctx context.Context, id string, input domain.UserProfile) (*ent.User, error) {
+	update := r.db.User.UpdateOneID(id)
+	if input.Name != "" {
+		update.SetName(input.Name)
+	}
+	if input.PhoneNumber != "" {
+		update.SetPhoneNumber(input.PhoneNumber)
+	}
+	if input.Gender != "" {
+		update.SetGender(input.Gender)
+	}
+	if input.CurrentAddress != "" {
+		update.SetCurrentAddress(input.CurrentAddress)
+	}
+	if input.PermanentAddress != "" {
+		update.SetPermanentAddress(input.PermanentAddress)
+	}
+	if input.DateOfBirth != nil {
+		update.SetDateOfBirth(*input.DateOfBirth)
+	}
+	if err := update.Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", id).Msg("Failed to update user profile")
+		return nil, err
+	}
+	return r.GetUserByID(ctx, id)
+}
+
+// ListTeamMembers returns the partner_member users created by a partner.
+func (r *repository) ListTeamMembers(ctx context.Context, ownerID string) ([]*ent.User, error) {
+	members, err := r.db.User.Query().
+		Where(
+			user.HasCreatedByUserWith(user.ID(ownerID)),
+			user.RoleEQ(user.RolePartnerMember),
+			user.DeletedAtIsNil(),
+		).
+		Order(ent.Asc(user.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("owner_id", ownerID).Msg("Failed to list team members")
+		return nil, err
+	}
+	return members, nil
+}
+
+// CreatePartnerInvitation creates an inactive team member and their invitation. The
+// member cannot sign in until the invitation is accepted.
+func (r *repository) CreatePartnerInvitation(ctx context.Context, input domain.PartnerInvitation, placeholderPassword string) (*ent.User, error) {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	member, err := client.User.Create().
+		SetID(uuid.New().String()).
+		SetUsername(input.Username).
+		SetPassword(placeholderPassword).
+		SetEmail(input.Email).
+		SetName(input.Name).
+		SetPhoneNumber(input.PhoneNumber).
+		SetRole(user.RolePartnerMember).
+		SetIsActive(false).
+		SetCreatedByUserID(input.InvitedByUserID).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create team member")
+		return nil, err
+	}
+
+	if err := client.UserInvitation.Create().
+		SetID(uuid.New().String()).
+		SetUserID(member.ID).
+		SetInvitedByUserID(input.InvitedByUserID).
+		SetTokenHash(input.TokenHash).
+		SetExpiresAt(input.ExpiresAt).
+		Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create invitation")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// GetInvitationByTokenHash returns the unaccepted invitation for a token, or nil.
+func (r *repository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*ent.UserInvitation, error) {
+	invitation, err := r.db.UserInvitation.Query().
+		Where(userinvitation.TokenHash(tokenHash), userinvitation.AcceptedAtIsNil()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get invitation")
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation sets the member's password and activates the account.
+func (r *repository) AcceptInvitation(ctx context.Context, invitation *ent.UserInvitation, passwordHash string) (*ent.User, error) {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	updated, err := client.UserInvitation.Update().
+		Where(userinvitation.ID(invitation.ID), userinvitation.AcceptedAtIsNil()).
+		SetAcceptedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if updated == 0 {
+		return nil, errors.New("invitation already accepted")
+	}
+
+	member, err := client.User.UpdateOneID(invitation.UserID).
+		SetPassword(passwordHash).
+		SetIsActive(true).
+		SetIsEmailVerified(true).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", invitation.UserID).Msg("Failed to activate team member")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// DeactivateTeamMember disables a member of the owner's team.
+func (r *repository) DeactivateTeamMember(ctx context.Context, ownerID, memberID string) error {
+	updated, err := r.db.User.Update().
+		Where(
+			user.ID(memberID),
+			user.RoleEQ(user.RolePartnerMember),
+			user.HasCreatedByUserWith(user.ID(ownerID)),
+			user.DeletedAtIsNil(),
+		).
+		SetIsActive(false).
+		SetDeletedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", memberID).Msg("Failed to deactivate team member")
+		return err
+	}
+	if updated == 0 {
+		return errors.New("team member not found")
+	}
+	return nil
+}
+
+// IncrementPropertyViews adds views of a property page to its lifetime counter.
+func (r *repository) IncrementPropertyViews(ctx context.Context, id string, views int) error {
+	return r.db.Property.Update().
+		Where(property.ID(id)).
+		AddViewCount(views).
+		Exec(ctx)
+}
+
+// partnerLeadsQuery selects the leads on an owner's properties, filtered by property_id,
+// start_date and end_date (IST calendar days).
+func partnerLeadsQuery(client *ent.Client, ownerID string, filters map[string]interface{}) *ent.LeadsQuery {
+	query := client.Leads.Query().
+		Where(
+			leads.HasPropertyWith(property.CreatedByUserID(ownerID)),
+			leads.DeletedAtIsNil(),
+		)
+	if propertyID, ok := filters["property_id"].(string); ok && propertyID != "" {
+		query = query.Where(leads.HasPropertyWith(property.ID(propertyID)))
+	}
+	if startDate, ok := filters["start_date"].(string); ok && startDate != "" {
+		if startTime, err := time.Parse(time.DateOnly, startDate); err == nil {
+			query = query.Where(leads.CreatedAtGTE(time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, istLocation)))
+		}
+	}
+	if endDate, ok := filters["end_date"].(string); ok && endDate != "" {
+		if endTime, err := time.Parse(time.DateOnly, endDate); err == nil {
+			query = query.Where(leads.CreatedAtLTE(time.Date(endTime.Year(), endTime.Month(), endTime.Day(), 23, 59, 59, 999999999, istLocation)))
+		}
+	}
+	return query
+}
+
+func (r *repository) GetPartnerLeads(ctx context.Context, ownerID string, filters map[string]interface{}, offset, limit int) ([]*ent.Leads, int, error) {
+	query := partnerLeadsQuery(r.db, ownerID, filters)
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count partner leads")
+		return nil, 0, err
+	}
+
+	leadsData, err := query.
+		WithProperty(func(q *ent.PropertyQuery) {
+			q.WithProject()
+		}).
+		WithProject().
+		Order(ent.Desc(leads.FieldCreatedAt)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get partner leads")
+		return nil, 0, err
+	}
+	return leadsData, total, nil
+}
+
+// GetPartnerListingStats returns views and lead counts for each live property of an owner.
+func (r *repository) GetPartnerListingStats(ctx context.Context, ownerID string, filters map[string]interface{}) ([]domain.ListingStats, error) {
+	properties, err := r.db.Property.Query().
+		Where(property.CreatedByUserID(ownerID), property.IsDeletedEQ(false)).
+		Order(ent.Desc(property.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("owner_id", ownerID).Msg("Failed to get partner properties")
+		return nil, err
+	}
+
+	leadsData, err := partnerLeadsQuery(r.db, ownerID, filters).
+		WithProperty(func(q *ent.PropertyQuery) {
+			q.Select(property.FieldID)
+		}).
+		Select(leads.FieldID, leads.FieldOtpVerified, leads.FieldCreatedAt).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("owner_id", ownerID).Msg("Failed to get partner lead counts")
+		return nil, err
+	}
+
+	stats := make([]domain.ListingStats, len(properties))
+	byID := make(map[string]*domain.ListingStats, len(properties))
+	for i, p := range properties {
+		stats[i] = domain.ListingStats{
+			PropertyID:       p.ID,
+			Name:             p.Name,
+			Slug:             p.Slug,
+			ModerationStatus: p.ModerationStatus.String(),
+			Views:            p.ViewCount,
+		}
+		byID[p.ID] = &stats[i]
+	}
+	for _, lead := range leadsData {
+		if lead.Edges.Property == nil {
+			continue
+		}
+		s, ok := byID[lead.Edges.Property.ID]
+		if !ok {
+			continue
+		}
+		s.Leads++
+		if lead.OtpVerified {
+			s.VerifiedLeads++
+		}
+		if s.LastLeadAt == nil || lead.CreatedAt.After(*s.LastLeadAt) {
+			createdAt := lead.CreatedAt
+			s.LastLeadAt = &createdAt
+		}
+	}
+	return stats, nil
+}
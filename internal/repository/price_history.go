@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/projectpricepoint"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// recordPriceChanges appends a price point for every configuration whose price changed.
+// The first time a configuration changes, its previous price is stored too, stamped
+// previousAt, so the series does not start at the new price.
+func recordPriceChanges(ctx context.Context, client *ent.Client, projectID string, previous []domain.PricePoint, previousAt time.Time, current []domain.PricePoint) error {
+	changed := domain.ChangedPricePoints(previous, current)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	recorded, err := client.ProjectPricePoint.Query().
+		Where(projectpricepoint.ProjectID(projectID)).
+		Unique(true).
+		Select(projectpricepoint.FieldConfiguration).
+		Strings(ctx)
+	if err != nil {
+		return err
+	}
+	hasHistory := make(map[string]bool, len(recorded))
+	for _, configuration := range recorded {
+		hasHistory[configuration] = true
+	}
+	before := make(map[string]domain.PricePoint, len(previous))
+	for _, point := range previous {
+		before[point.Configuration] = point
+	}
+
+	now := time.Now()
+	var creates []*ent.ProjectPricePointCreate
+	for _, point := range changed {
+		if old, ok := before[point.Configuration]; ok && !hasHistory[point.Configuration] {
+			creates = append(creates, newPricePoint(client, projectID, old, previousAt))
+		}
+		creates = append(creates, newPricePoint(client, projectID, point, now))
+	}
+	return client.ProjectPricePoint.CreateBulk(creates...).Exec(ctx)
+}
+
+func newPricePoint(client *ent.Client, projectID string, point domain.PricePoint, recordedAt time.Time) *ent.ProjectPricePointCreate {
+	return client.ProjectPricePoint.Create().
+		SetID(uuid.New().String()).
+		SetProjectID(projectID).
+		SetConfiguration(point.Configuration).
+		SetSize(point.Size).
+		SetMinPrice(point.MinPrice).
+		SetMaxPrice(point.MaxPrice).
+		SetRecordedAt(recordedAt)
+}
+
+// GetProjectPriceHistory returns all price points of a project, oldest first.
+func (r *repository) GetProjectPriceHistory(ctx context.Context, projectID string) ([]*ent.ProjectPricePoint, error) {
+	points, err := r.db.ProjectPricePoint.Query().
+		Where(projectpricepoint.ProjectID(projectID)).
+		Order(ent.Asc(projectpricepoint.FieldRecordedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("project_id", projectID).Msg("Failed to get price history")
+		return nil, err
+	}
+	return points, nil
+}
+
+// GetOverallPriceSeries returns the overall price range series of each project, oldest
+// first. Projects without history are absent from the map.
+func (r *repository) GetOverallPriceSeries(ctx context.Context, projectIDs []string) (map[string][]domain.PricePoint, error) {
+	series := make(map[string][]domain.PricePoint)
+	if len(projectIDs) == 0 {
+		return series, nil
+	}
+
+	points, err := r.db.ProjectPricePoint.Query().
+		Where(
+			projectpricepoint.ProjectIDIn(projectIDs...),
+			projectpricepoint.Configuration(domain.PriceConfigurationOverall),
+		).
+		Order(ent.Asc(projectpricepoint.FieldRecordedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get price series")
+		return nil, err
+	}
+	for _, point := range points {
+		series[point.ProjectID] = append(series[point.ProjectID], domain.PricePoint{
+			Configuration: point.Configuration,
+			Size:          point.Size,
+			MinPrice:      point.MinPrice,
+			MaxPrice:      point.MaxPrice,
+			RecordedAt:    point.RecordedAt,
+		})
+	}
+	return series, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/VI-IM/im_backend_go/ent"
@@ -23,6 +24,7 @@ func (r *repository) GetProjectByID(id string) (*ent.Project, error) {
 		Where(projectEnt.ID(id)).
 		WithDeveloper().
 		WithLocation().
+		WithReraRegistrations().
 		Only(context.Background())
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -70,6 +72,9 @@ func (r *repository) AddProject(input domain.Project) (string, error) {
 		logger.Get().Error().Err(err).Msg("Failed to add project")
 		return "", err
 	}
+	if err := registerSlug(context.Background(), r.db, domain.SlugEntityProject, input.ProjectID, input.Slug); err != nil {
+		return "", err
+	}
 	return input.ProjectID, nil
 }
 
@@ -106,13 +111,25 @@ func (r *repository) UpdateProject(input domain.Project) (*ent.Project, error) {
 		return nil, err
 	}
 
-	project := r.db.Project.UpdateOneID(input.ProjectID)
+	if input.Slug != "" && input.Slug != oldProject.Slug {
+		err = r.ChangeSlug(context.Background(), domain.SlugChange{
+			EntityType: domain.SlugEntityProject,
+			EntityID:   input.ProjectID,
+			OldSlug:    oldProject.Slug,
+			NewSlug:    input.Slug,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := r.db.Tx(context.Background())
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to create transaction")
 		return nil, err
 	}
 	defer tx.Rollback()
+	project := tx.Project.UpdateOneID(input.ProjectID)
 
 	if input.MaxPrice != "" {
 		project.SetMaxPrice(input.MaxPrice)
@@ -122,6 +139,11 @@ func (r *repository) UpdateProject(input domain.Project) (*ent.Project, error) {
 		project.SetMinPrice(input.MinPrice)
 	}
 
+	if (input.MinPrice != "" && input.MinPrice != oldProject.MinPrice) ||
+		(input.MaxPrice != "" && input.MaxPrice != oldProject.MaxPrice) {
+		project.SetPriceChangedAt(time.Now())
+	}
+
 	if input.ProjectName != "" {
 		project.SetName(input.ProjectName)
 	}
@@ -395,11 +417,24 @@ func (r *repository) UpdateProject(input domain.Project) (*ent.Project, error) {
 		project.SetIsDeleted(input.IsDeleted)
 	}
 
-	if _, err := project.Save(context.Background()); err != nil {
+	updated, err := project.Save(context.Background())
+	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to update project")
 		return nil, err
 	}
 
+	priceAt := oldProject.CreatedAt
+	if oldProject.PriceChangedAt != nil {
+		priceAt = *oldProject.PriceChangedAt
+	}
+	err = recordPriceChanges(context.Background(), tx.Client(), input.ProjectID,
+		domain.ProjectPricePoints(oldProject.MinPrice, oldProject.MaxPrice, oldProject.WebCards.PriceList), priceAt,
+		domain.ProjectPricePoints(updated.MinPrice, updated.MaxPrice, updated.WebCards.PriceList))
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to record price history")
+		return nil, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to commit transaction")
 		return nil, err
@@ -410,6 +445,7 @@ func (r *repository) UpdateProject(input domain.Project) (*ent.Project, error) {
 		Where(projectEnt.ID(input.ProjectID)).
 		WithDeveloper().
 		WithLocation().
+		WithReraRegistrations().
 		Only(context.Background())
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to fetch updated project with edges")
@@ -450,60 +486,14 @@ func (r *repository) GetAllProjects(filters map[string]interface{}) ([]*ent.Proj
 	ctx := context.Background()
 
 	// Start building the query
-	query := r.db.Project.Query().Where(projectEnt.IsDeletedEQ(false))
-
-	// Apply filters if any are set
-	if len(filters) > 0 {
-		predicates := []predicateEnt.Project{}
-
-		// Apply boolean filters
-		if isPremium, ok := filters["is_premium"].(bool); ok && isPremium {
-			predicates = append(predicates, projectEnt.IsPremiumEQ(true))
-		}
-		if isPriority, ok := filters["is_priority"].(bool); ok && isPriority {
-			predicates = append(predicates, projectEnt.IsPriorityEQ(true))
-		}
-		if isFeatured, ok := filters["is_featured"].(bool); ok && isFeatured {
-			predicates = append(predicates, projectEnt.IsFeaturedEQ(true))
-		}
-
-		// Apply location filter
-		if locationID, ok := filters["location_id"].(string); ok && locationID != "" {
-			query = query.Where(projectEnt.HasLocationWith(locationEnt.ID(locationID)))
-		}
-
-		// Apply developer filter
-		if developerID, ok := filters["developer_id"].(string); ok && developerID != "" {
-			query = query.Where(projectEnt.HasDeveloperWith(developerEnt.ID(developerID)))
-		}
-
-		// Apply name filter
-		if name, ok := filters["name"].(string); ok && name != "" {
-			query = query.Where(projectEnt.NameContainsFold(name))
-		}
-
-		if city, ok := filters["city"].(string); ok && city != "" {
-			// Remove quotes if present
-			city = strings.Trim(city, "\"")
-			// Filter projects that have a location with matching city
-			query = query.Where(projectEnt.HasLocationWith(locationEnt.CityEQ(city)))
-		}
-
-		// Apply type filter
-		if projectType, ok := filters["type"].(string); ok && projectType != "" {
-			query = query.Where(projectEnt.ProjectTypeEQ(projectEnt.ProjectType(projectType)))
-		}
-
-		if len(predicates) > 0 {
-			query = query.Where(projectEnt.Or(predicates...))
-		}
-	}
+	query := applyProjectFilters(r.db.Project.Query().Where(projectEnt.IsDeletedEQ(false)), filters)
 
 	// Execute the query with eager loading of related entities
 	projects, err := query.
 		Order(ent.Desc(projectEnt.FieldID)).
 		WithDeveloper().
 		WithLocation().
+		WithReraRegistrations().
 		All(ctx)
 	if err != nil {
 		return nil, err
@@ -512,6 +502,57 @@ func (r *repository) GetAllProjects(filters map[string]interface{}) ([]*ent.Proj
 	return projects, nil
 }
 
+// applyProjectFilters applies the ListProjects filter map. Saved searches store the same map.
+func applyProjectFilters(query *ent.ProjectQuery, filters map[string]interface{}) *ent.ProjectQuery {
+	if len(filters) == 0 {
+		return query
+	}
+	predicates := []predicateEnt.Project{}
+
+	// Apply boolean filters
+	if isPremium, ok := filters["is_premium"].(bool); ok && isPremium {
+		predicates = append(predicates, projectEnt.IsPremiumEQ(true))
+	}
+	if isPriority, ok := filters["is_priority"].(bool); ok && isPriority {
+		predicates = append(predicates, projectEnt.IsPriorityEQ(true))
+	}
+	if isFeatured, ok := filters["is_featured"].(bool); ok && isFeatured {
+		predicates = append(predicates, projectEnt.IsFeaturedEQ(true))
+	}
+
+	// Apply location filter
+	if locationID, ok := filters["location_id"].(string); ok && locationID != "" {
+		query = query.Where(projectEnt.HasLocationWith(locationEnt.ID(locationID)))
+	}
+
+	// Apply developer filter
+	if developerID, ok := filters["developer_id"].(string); ok && developerID != "" {
+		query = query.Where(projectEnt.HasDeveloperWith(developerEnt.ID(developerID)))
+	}
+
+	// Apply name filter
+	if name, ok := filters["name"].(string); ok && name != "" {
+		query = query.Where(projectEnt.NameContainsFold(name))
+	}
+
+	if city, ok := filters["city"].(string); ok && city != "" {
+		// Remove quotes if present
+		city = strings.Trim(city, "\"")
+		// Filter projects that have a location with matching city
+		query = query.Where(projectEnt.HasLocationWith(locationEnt.CityEQ(city)))
+	}
+
+	// Apply type filter
+	if projectType, ok := filters["type"].(string); ok && projectType != "" {
+		query = query.Where(projectEnt.ProjectTypeEQ(projectEnt.ProjectType(projectType)))
+	}
+
+	if len(predicates) > 0 {
+		query = query.Where(projectEnt.Or(predicates...))
+	}
+	return query
+}
+
 func (r *repository) GetProjectByURL(url string) (*ent.Project, error) {
 
 	project, err := r.db.Project.Query().
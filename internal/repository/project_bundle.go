@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	developerEnt "github.com/VI-IM/im_backend_go/ent/developer"
+	locationEnt "github.com/VI-IM/im_backend_go/ent/location"
+	projectEnt "github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+func (r *repository) GetProjectsBySlugs(ctx context.Context, slugs []string) ([]*ent.Project, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	projects, err := r.db.Project.Query().
+		Where(
+			projectEnt.IsDeletedEQ(false),
+			projectEnt.SlugIn(slugs...),
+		).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get projects by slugs")
+		return nil, err
+	}
+	return projects, nil
+}
+
+// FindDeveloperByKey looks a developer up by identifier, then by case-insensitive name.
+// It returns nil when no developer matches.
+func (r *repository) FindDeveloperByKey(ctx context.Context, key string) (*ent.Developer, error) {
+	return findDeveloperByKey(ctx, r.db, key)
+}
+
+// GetProjectsForExport returns the projects to export with their developer and location.
+// Both filters are optional; without them every live project is returned.
+func (r *repository) GetProjectsForExport(ctx context.Context, slugs []string, developerID string) ([]*ent.Project, error) {
+	query := r.db.Project.Query().Where(projectEnt.IsDeletedEQ(false))
+	if len(slugs) > 0 {
+		query = query.Where(projectEnt.SlugIn(slugs...))
+	}
+	if developerID != "" {
+		query = query.Where(projectEnt.HasDeveloperWith(developerEnt.ID(developerID)))
+	}
+
+	projects, err := query.
+		WithDeveloper().
+		WithLocation().
+		Order(ent.Asc(projectEnt.FieldSlug)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get projects for export")
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ImportProjectBundle upserts the developers and projects of a bundle in a single
+// transaction. Developers are matched by identifier or name, locations by locality
+// and city, and projects by slug. The bundle must already be validated.
+func (r *repository) ImportProjectBundle(ctx context.Context, bundle domain.ProjectBundle) ([]domain.BundleRowResult, error) {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	client := tx.Client()
+	results := make([]domain.BundleRowResult, 0, len(bundle.Developers)+len(bundle.Projects))
+	// Keyed by the lowercased developer key; projects may reference it in any case
+	developerIDs := make(map[string]string)
+
+	for _, input := range bundle.Developers {
+		existing, err := findDeveloperByKey(ctx, client, input.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		result := domain.BundleRowResult{Source: input.Source, Key: input.Key()}
+		if existing != nil {
+			err = client.Developer.UpdateOneID(existing.ID).
+				SetName(input.Name).
+				SetLegalName(input.LegalName).
+				SetIdentifier(input.Identifier).
+				SetEstablishedYear(input.EstablishedYear).
+				SetMediaContent(input.MediaContent).
+				Exec(ctx)
+			result.ID = existing.ID
+			result.Action = domain.BundleActionUpdate
+		} else {
+			result.ID = uuid.New().String()
+			err = client.Developer.Create().
+				SetID(result.ID).
+				SetName(input.Name).
+				SetLegalName(input.LegalName).
+				SetIdentifier(input.Identifier).
+				SetEstablishedYear(input.EstablishedYear).
+				SetMediaContent(input.MediaContent).
+				Exec(ctx)
+			result.Action = domain.BundleActionCreate
+		}
+		if err != nil {
+			logger.Get().Error().Err(err).Str("developer", input.Key()).Msg("Failed to import developer")
+			return nil, fmt.Errorf("%s: %w", input.Source, err)
+		}
+
+		developerIDs[strings.ToLower(input.Key())] = result.ID
+		results = append(results, result)
+	}
+
+	for _, input := range bundle.Projects {
+		developerID, ok := developerIDs[strings.ToLower(input.Developer)]
+		if !ok {
+			developer, err := findDeveloperByKey(ctx, client, input.Developer)
+			if err != nil {
+				return nil, err
+			}
+			if developer == nil {
+				return nil, fmt.Errorf("%s: developer %q not found", input.Source, input.Developer)
+			}
+			developerID = developer.ID
+			developerIDs[strings.ToLower(input.Developer)] = developerID
+		}
+
+		locationID, err := upsertBundleLocation(ctx, client, input.Location)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Source, err)
+		}
+
+		existing, err := client.Project.Query().
+			Where(
+				projectEnt.IsDeletedEQ(false),
+				projectEnt.SlugEQ(input.Slug),
+			).
+			First(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			logger.Get().Error().Err(err).Str("slug", input.Slug).Msg("Failed to get project by slug")
+			return nil, err
+		}
+
+		result := domain.BundleRowResult{Source: input.Source, Key: input.Slug}
+		if existing != nil {
+			err = client.Project.UpdateOneID(existing.ID).
+				SetName(input.Name).
+				SetProjectType(projectEnt.ProjectType(input.ProjectType)).
+				SetStatus(input.Status).
+				SetDescription(input.Description).
+				SetMinPrice(input.MinPrice).
+				SetMaxPrice(input.MaxPrice).
+				SetTimelineInfo(input.TimelineInfo).
+				SetMetaInfo(input.MetaInfo).
+				SetWebCards(input.WebCards).
+				SetLocationInfo(input.LocationInfo).
+				SetIsFeatured(input.IsFeatured).
+				SetIsPremium(input.IsPremium).
+				SetIsPriority(input.IsPriority).
+				SetDeveloperID(developerID).
+				SetLocationID(locationID).
+				Exec(ctx)
+			result.ID = existing.ID
+			result.Action = domain.BundleActionUpdate
+		} else {
+			result.ID = newBundleProjectID(input.Slug)
+			err = client.Project.Create().
+				SetID(result.ID).
+				SetSlug(input.Slug).
+				SetName(input.Name).
+				SetProjectType(projectEnt.ProjectType(input.ProjectType)).
+				SetStatus(input.Status).
+				SetDescription(input.Description).
+				SetMinPrice(input.MinPrice).
+				SetMaxPrice(input.MaxPrice).
+				SetTimelineInfo(input.TimelineInfo).
+				SetMetaInfo(input.MetaInfo).
+				SetWebCards(input.WebCards).
+				SetLocationInfo(input.LocationInfo).
+				SetIsFeatured(input.IsFeatured).
+				SetIsPremium(input.IsPremium).
+				SetIsPriority(input.IsPriority).
+				SetDeveloperID(developerID).
+				SetLocationID(locationID).
+				Exec(ctx)
+			result.Action = domain.BundleActionCreate
+		}
+		if err != nil {
+			logger.Get().Error().Err(err).Str("slug", input.Slug).Msg("Failed to import project")
+			return nil, fmt.Errorf("%s: %w", input.Source, err)
+		}
+		if err := registerSlug(ctx, client, domain.SlugEntityProject, result.ID, input.Slug); err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Source, err)
+		}
+
+		var previous []domain.PricePoint
+		previousAt := time.Now()
+		if existing != nil {
+			previous = domain.ProjectPricePoints(existing.MinPrice, existing.MaxPrice, existing.WebCards.PriceList)
+			previousAt = existing.CreatedAt
+			if existing.PriceChangedAt != nil {
+				previousAt = *existing.PriceChangedAt
+			}
+		}
+		current := domain.ProjectPricePoints(input.MinPrice, input.MaxPrice, input.WebCards.PriceList)
+		if err := recordPriceChanges(ctx, client, result.ID, previous, previousAt, current); err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Source, err)
+		}
+
+		results = append(results, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	return results, nil
+}
+
+func findDeveloperByKey(ctx context.Context, client *ent.Client, key string) (*ent.Developer, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, nil
+	}
+
+	developer, err := client.Developer.Query().
+		Where(developerEnt.Or(
+			developerEnt.IdentifierEqualFold(key),
+			developerEnt.NameEqualFold(key),
+		)).
+		Order(ent.Asc(developerEnt.FieldCreatedAt)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to find developer")
+		return nil, err
+	}
+	return developer, nil
+}
+
+func upsertBundleLocation(ctx context.Context, client *ent.Client, input domain.BundleLocation) (string, error) {
+	existing, err := client.Location.Query().
+		Where(
+			locationEnt.LocalityNameEqualFold(input.LocalityName),
+			locationEnt.CityEqualFold(input.City),
+		).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		logger.Get().Error().Err(err).Msg("Failed to find location")
+		return "", err
+	}
+
+	if existing != nil {
+		update := client.Location.UpdateOneID(existing.ID)
+		if input.State != "" {
+			update.SetState(input.State)
+		}
+		if input.Pincode != "" {
+			update.SetPincode(input.Pincode)
+		}
+		if input.PhoneNumber != "" {
+			update.SetPhoneNumber(input.PhoneNumber)
+		}
+		if err := update.Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to update location")
+			return "", err
+		}
+		return existing.ID, nil
+	}
+
+	id := uuid.New().String()
+	err = client.Location.Create().
+		SetID(id).
+		SetLocalityName(input.LocalityName).
+		SetCity(input.City).
+		SetState(input.State).
+		SetPincode(input.Pincode).
+		SetPhoneNumber(input.PhoneNumber).
+		Exec(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create location")
+		return "", err
+	}
+	return id, nil
+}
+
+// newBundleProjectID follows the 16 character hex format used for project IDs. The
+// slug is mixed in because a bulk import creates many projects within the same second.
+func newBundleProjectID(slug string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s-%d", slug, time.Now().UnixNano()))))[:16]
+}
@@ -91,9 +91,28 @@ func (r *repository) GetPropertyByID(id string) (*ent.Property, error) {
 	return property, nil
 }
 
+// GetPublishedPropertyByID is GetPropertyByID restricted to approved listings that are
+// not deleted, for public reads. It returns nil when there is no such listing.
+func (r *repository) GetPublishedPropertyByID(ctx context.Context, id string) (*ent.Property, error) {
+	property, err := r.db.Property.Query().
+		Where(property.ID(id), property.ModerationStatusEQ(property.ModerationStatusApproved), property.IsDeleted(false)).
+		WithDeveloper().
+		WithProject().
+		WithLocation().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get property")
+		return nil, err
+	}
+	return property, nil
+}
+
 func (r *repository) GetPropertyBySlug(ctx context.Context, slug string) (*ent.Property, error) {
 	property, err := r.db.Property.Query().
-		Where(property.Slug(slug)).
+		Where(property.Slug(slug), property.ModerationStatusEQ(property.ModerationStatusApproved), property.IsDeleted(false)).
 		WithDeveloper().
 		WithProject().
 		Only(ctx)
@@ -108,148 +127,85 @@ func (r *repository) GetPropertyBySlug(ctx context.Context, slug string) (*ent.P
 }
 
 func (r *repository) UpdateProperty(input domain.Property) (*ent.Property, error) {
+	ctx := context.Background()
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
 
-	oldProperty, err := r.GetPropertyByID(input.PropertyID)
+	updated, err := updateProperty(ctx, tx.Client(), input)
 	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	return updated, nil
+}
+
+// updateProperty applies a property update, slug change included, on a client that is
+// already inside a transaction.
+func updateProperty(ctx context.Context, client *ent.Client, input domain.Property) (*ent.Property, error) {
+	oldProperty, err := client.Property.Get(ctx, input.PropertyID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("property not found")
+		}
 		logger.Get().Error().Err(err).Msg("Failed to get property")
 		return nil, err
 	}
 
-	propertyUpdate := r.db.Property.UpdateOneID(input.PropertyID)
+	// An explicit slug wins; otherwise the slug is regenerated when the name changes.
+	newSlug := input.Slug
+	if newSlug == "" && input.Name != "" && input.Name != oldProperty.Name {
+		newSlug = generateSlug(input.Name, input.PropertyID)
+	}
+	if newSlug != "" && newSlug != oldProperty.Slug {
+		err = changeSlug(ctx, client, domain.SlugChange{
+			EntityType: domain.SlugEntityProperty,
+			EntityID:   input.PropertyID,
+			OldSlug:    oldProperty.Slug,
+			NewSlug:    newSlug,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	propertyUpdate := client.Property.UpdateOneID(input.PropertyID)
 
 	if input.Name != "" {
 		propertyUpdate.SetName(input.Name)
-		// Regenerate slug when property name changes
-		if input.Name != oldProperty.Name {
-			newSlug := generateSlug(input.Name, input.PropertyID)
-			propertyUpdate.SetSlug(newSlug)
-		}
 	}
 	if len(input.PropertyImages) > 0 {
 		propertyUpdate.SetPropertyImages(input.PropertyImages)
 	}
 
-	newWebCards := oldProperty.WebCards
-	hasWebCardChanges := false
-
-	// property details
-	if input.WebCards.PropertyDetails != (oldProperty.WebCards.PropertyDetails) {
-		if input.WebCards.PropertyDetails.BuiltUpArea.Value != "" {
-			newWebCards.PropertyDetails.BuiltUpArea.Value = input.WebCards.PropertyDetails.BuiltUpArea.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.Sizes.Value != "" {
-			newWebCards.PropertyDetails.Sizes.Value = input.WebCards.PropertyDetails.Sizes.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.FloorNumber.Value != "" {
-			newWebCards.PropertyDetails.FloorNumber.Value = input.WebCards.PropertyDetails.FloorNumber.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.Configuration.Value != "" {
-			newWebCards.PropertyDetails.Configuration.Value = input.WebCards.PropertyDetails.Configuration.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.PossessionStatus.Value != "" {
-			newWebCards.PropertyDetails.PossessionStatus.Value = input.WebCards.PropertyDetails.PossessionStatus.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.Balconies.Value != "" {
-			newWebCards.PropertyDetails.Balconies.Value = input.WebCards.PropertyDetails.Balconies.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.CoveredParking.Value != "" {
-			newWebCards.PropertyDetails.CoveredParking.Value = input.WebCards.PropertyDetails.CoveredParking.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.Bedrooms.Value != "" {
-			newWebCards.PropertyDetails.Bedrooms.Value = input.WebCards.PropertyDetails.Bedrooms.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.PropertyType.Value != "" {
-			newWebCards.PropertyDetails.PropertyType.Value = input.WebCards.PropertyDetails.PropertyType.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.AgeOfProperty.Value != "" {
-			newWebCards.PropertyDetails.AgeOfProperty.Value = input.WebCards.PropertyDetails.AgeOfProperty.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.FurnishingType.Value != "" {
-			newWebCards.PropertyDetails.FurnishingType.Value = input.WebCards.PropertyDetails.FurnishingType.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.ReraNumber.Value != "" {
-			newWebCards.PropertyDetails.ReraNumber.Value = input.WebCards.PropertyDetails.ReraNumber.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.Facing.Value != "" {
-			newWebCards.PropertyDetails.Facing.Value = input.WebCards.PropertyDetails.Facing.Value
-			hasWebCardChanges = true
-		}
-		if input.WebCards.PropertyDetails.Bathrooms.Value != "" {
-			newWebCards.PropertyDetails.Bathrooms.Value = input.WebCards.PropertyDetails.Bathrooms.Value
-			hasWebCardChanges = true
-		}
+	newWebCards, hasWebCardChanges := domain.MergePropertyWebCards(oldProperty.WebCards, input.WebCards)
+	if hasWebCardChanges {
+		propertyUpdate.SetWebCards(newWebCards)
 	}
 
-	// why choose us
-	// if len(input.WebCards.WhyChooseUs.ImageUrls) > 0 || len(input.WebCards.WhyChooseUs.USP_List) > 0 {
-	// 	if len(input.WebCards.WhyChooseUs.ImageUrls) > 0 {
-	// 		newWebCards.WhyChooseUs.ImageUrls = input.WebCards.WhyChooseUs.ImageUrls
-	// 	}
-	// 	if len(input.WebCards.WhyChooseUs.USP_List) > 0 {
-	// 		newWebCards.WhyChooseUs.USP_List = input.WebCards.WhyChooseUs.USP_List
-	// 	}
-	// 	hasWebCardChanges = true
-	// }
-
-	// // know about
-	if input.WebCards.KnowAbout.Description != "" {
-		newWebCards.KnowAbout.Description = input.WebCards.KnowAbout.Description
-		hasWebCardChanges = true
-	}
-
-	// // video presentation
-	// if input.WebCards.VideoPresentation.Title != "" || input.WebCards.VideoPresentation.VideoUrl != "" {
-	// 	if input.WebCards.VideoPresentation.Title != "" {
-	// 		newWebCards.VideoPresentation.Title = input.WebCards.VideoPresentation.Title
-	// 	}
-	// 	if input.WebCards.VideoPresentation.VideoUrl != "" {
-	// 		newWebCards.VideoPresentation.VideoUrl = input.WebCards.VideoPresentation.VideoUrl
-	// 	}
-	// 	hasWebCardChanges = true
-	// }
-
-	// location map
-	if input.WebCards.LocationMap.Description != "" || input.WebCards.LocationMap.GoogleMapLink != "" {
-		if input.WebCards.LocationMap.Description != "" {
-			newWebCards.LocationMap.Description = input.WebCards.LocationMap.Description
-		}
-		if input.WebCards.LocationMap.GoogleMapLink != "" {
-			newWebCards.LocationMap.GoogleMapLink = input.WebCards.LocationMap.GoogleMapLink
-		}
-		hasWebCardChanges = true
+	if input.ListingType != "" {
+		propertyUpdate.SetListingType(property.ListingType(input.ListingType))
 	}
-
-	// property floor plan
-	if input.WebCards.PropertyFloorPlan.Title != "" || len(input.WebCards.PropertyFloorPlan.Plans) > 0 {
-		if input.WebCards.PropertyFloorPlan.Title != "" {
-			newWebCards.PropertyFloorPlan.Title = input.WebCards.PropertyFloorPlan.Title
-		}
-		if len(input.WebCards.PropertyFloorPlan.Plans) > 0 {
-			newWebCards.PropertyFloorPlan.Plans = input.WebCards.PropertyFloorPlan.Plans
-		}
-		hasWebCardChanges = true
+	if input.AvailableFrom != nil {
+		propertyUpdate.SetAvailableFrom(*input.AvailableFrom)
 	}
-
-	if hasWebCardChanges {
-		propertyUpdate.SetWebCards(newWebCards)
+	if input.ModerationStatus != "" {
+		propertyUpdate.SetModerationStatus(property.ModerationStatus(input.ModerationStatus))
 	}
 
-	// Only update PricingInfo if it contains actual data (non-empty Price field)
-	if input.PricingInfo.Price != "" && input.PricingInfo != (oldProperty.PricingInfo) {
+	// Only update PricingInfo if it contains actual data
+	newPricingInfo := oldProperty.PricingInfo
+	if input.PricingInfo != (schema.PropertyPricingInfo{}) && input.PricingInfo != (oldProperty.PricingInfo) {
 		propertyUpdate.SetPricingInfo(input.PricingInfo)
+		newPricingInfo = input.PricingInfo
 	}
+	setPropertyAttributes(propertyUpdate.Mutation(), domain.NormalizePropertyAttributes(newWebCards.PropertyDetails, newPricingInfo))
 	if input.PropertyReraInfo != (oldProperty.PropertyReraInfo) {
 		propertyUpdate.SetPropertyReraInfo(input.PropertyReraInfo)
 	}
@@ -266,17 +222,17 @@ func (r *repository) UpdateProperty(input domain.Property) (*ent.Property, error
 		propertyUpdate.SetProjectID(input.ProjectID)
 	}
 
-	updatedProperty, err := propertyUpdate.Save(context.Background())
+	updatedProperty, err := propertyUpdate.Save(ctx)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to update property")
 		return nil, err
 	}
-	propertyWithRelations, err := r.db.Property.Query().
+	propertyWithRelations, err := client.Property.Query().
 		Where(property.ID(updatedProperty.ID)).
 		WithDeveloper().
 		WithProject().
 		WithLocation().
-		Only(context.Background())
+		Only(ctx)
 	if err != nil {
 		logger.Get().Error().Err(err).Msg("Failed to get updated property with relations")
 		return nil, err
@@ -291,7 +247,7 @@ func (r *repository) GetPropertiesOfProject(projectID string) ([]*ent.Property,
 	}
 
 	properties, err := r.db.Property.Query().
-		Where(property.ProjectID(projectID)).
+		Where(property.ProjectID(projectID), property.ModerationStatusEQ(property.ModerationStatusApproved), property.IsDeleted(false)).
 		WithDeveloper().
 		WithProject().
 		All(context.Background())
@@ -331,6 +287,14 @@ func (r *repository) AddProperty(input domain.Property) (*PropertyResult, error)
 
 	// Create default values for required JSON fields
 	defaultPricingInfo := schema.PropertyPricingInfo{Price: ""}
+	listingType := property.DefaultListingType
+	if input.ListingType != "" {
+		listingType = property.ListingType(input.ListingType)
+	}
+	moderationStatus := property.DefaultModerationStatus
+	if input.ModerationStatus != "" {
+		moderationStatus = property.ModerationStatus(input.ModerationStatus)
+	}
 
 	property := r.db.Property.Create().
 		SetID(propertyID).
@@ -340,7 +304,11 @@ func (r *repository) AddProperty(input domain.Property) (*PropertyResult, error)
 		SetPropertyType(input.PropertyType).
 		SetWebCards(input.WebCards).
 		SetPricingInfo(defaultPricingInfo).
-		SetPropertyReraInfo(input.PropertyReraInfo)
+		SetPropertyReraInfo(input.PropertyReraInfo).
+		SetListingType(listingType).
+		SetModerationStatus(moderationStatus).
+		SetNillableAvailableFrom(input.AvailableFrom)
+	setPropertyAttributes(property.Mutation(), domain.NormalizePropertyAttributes(input.WebCards.PropertyDetails, defaultPricingInfo))
 	if project.Edges.Developer != nil && project.Edges.Developer.ID != "" {
 		property.SetDeveloperID(project.Edges.Developer.ID)
 	}
@@ -355,13 +323,16 @@ func (r *repository) AddProperty(input domain.Property) (*PropertyResult, error)
 		logger.Get().Error().Err(err).Msg("Failed to add property")
 		return nil, err
 	}
+	if err := registerSlug(context.Background(), r.db, domain.SlugEntityProperty, propertyID, slug); err != nil {
+		return nil, err
+	}
 	return &PropertyResult{
 		PropertyID: propertyID,
 		Slug:       slug,
 	}, nil
 }
 
-func (r *repository) GetAllProperties(offset, limit int, filters map[string]interface{}) ([]*ent.Property, int, error) {
+func (r *repository) GetAllProperties(offset, limit int, filters map[string]interface{}, sortBy string) ([]*ent.Property, int, error) {
 	ctx := context.Background()
 
 	// Start building the base query
@@ -379,7 +350,7 @@ func (r *repository) GetAllProperties(offset, limit int, filters map[string]inte
 
 	// Apply pagination and fetch results
 	properties, err := query.
-		Order(ent.Desc(property.FieldCreatedAt)).
+		Order(propertyOrder(sortBy, filters)...).
 		WithDeveloper().
 		WithLocation().
 		WithProject().
@@ -433,12 +404,17 @@ func (r *repository) applyPropertyFilters(query *ent.PropertyQuery, filters map[
 		query = query.Where(property.IsFeaturedEQ(isFeatured))
 	}
 
+	// Filter by moderation_status
+	if moderationStatus, ok := filters["moderation_status"].(string); ok && moderationStatus != "" {
+		query = query.Where(property.ModerationStatusEQ(property.ModerationStatus(moderationStatus)))
+	}
+
 	// Filter by name (partial match)
 	if name, ok := filters["name"].(string); ok && name != "" {
 		query = query.Where(property.NameContainsFold(name))
 	}
 
-	return query
+	return applyPropertyAttributeFilters(query, filters)
 }
 
 func (r *repository) DeleteProperty(id string, hardDelete bool) error {
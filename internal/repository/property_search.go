@@ -0,0 +1,397 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/predicate"
+	"github.com/VI-IM/im_backend_go/ent/property"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/response"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// propertyRangeBucket is a facet bucket over a numeric column. min is inclusive, max is
+// exclusive and a nil bound is open.
+type propertyRangeBucket struct {
+	value string
+	label string
+	min   *float64
+	max   *float64
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+var (
+	budgetBuckets = []propertyRangeBucket{
+		{value: "0-5000000", label: "Under 50 Lakh", max: floatPtr(5e6)},
+		{value: "5000000-10000000", label: "50 Lakh - 1 Cr", min: floatPtr(5e6), max: floatPtr(1e7)},
+		{value: "10000000-20000000", label: "1 Cr - 2 Cr", min: floatPtr(1e7), max: floatPtr(2e7)},
+		{value: "20000000-50000000", label: "2 Cr - 5 Cr", min: floatPtr(2e7), max: floatPtr(5e7)},
+		{value: "50000000-", label: "Above 5 Cr", min: floatPtr(5e7)},
+	}
+	rentBuckets = []propertyRangeBucket{
+		{value: "0-15000", label: "Under 15K", max: floatPtr(15e3)},
+		{value: "15000-30000", label: "15K - 30K", min: floatPtr(15e3), max: floatPtr(3e4)},
+		{value: "30000-60000", label: "30K - 60K", min: floatPtr(3e4), max: floatPtr(6e4)},
+		{value: "60000-100000", label: "60K - 1 Lakh", min: floatPtr(6e4), max: floatPtr(1e5)},
+		{value: "100000-", label: "Above 1 Lakh", min: floatPtr(1e5)},
+	}
+	floorBuckets = []propertyRangeBucket{
+		{value: "0-0", label: "Ground", min: floatPtr(0), max: floatPtr(1)},
+		{value: "1-5", label: "1 - 5", min: floatPtr(1), max: floatPtr(6)},
+		{value: "6-10", label: "6 - 10", min: floatPtr(6), max: floatPtr(11)},
+		{value: "11-20", label: "11 - 20", min: floatPtr(11), max: floatPtr(21)},
+		{value: "21-", label: "Above 20", min: floatPtr(21)},
+	}
+	// Like floors, age values are whole numbers and the value of a bucket is the inclusive
+	// min_age-max_age range it counts.
+	ageBuckets = []propertyRangeBucket{
+		{value: "0-0", label: "Under 1 year", min: floatPtr(0), max: floatPtr(1)},
+		{value: "1-4", label: "1 - 4 years", min: floatPtr(1), max: floatPtr(5)},
+		{value: "5-9", label: "5 - 9 years", min: floatPtr(5), max: floatPtr(10)},
+		{value: "10-", label: "10 years and above", min: floatPtr(10)},
+	}
+)
+
+// Filter keys owned by each facet. A facet is counted with every other filter applied,
+// so selecting a value does not hide the alternatives.
+var propertyFacetFilterKeys = map[string][]string{
+	"listing_type":      {"listing_type"},
+	"budget":            {"min_price", "max_price"},
+	"rent":              {"min_rent", "max_rent"},
+	"bedrooms":          {"bedrooms"},
+	"bathrooms":         {"bathrooms"},
+	"furnishing":        {"furnishing"},
+	"facing":            {"facing"},
+	"floor":             {"min_floor", "max_floor"},
+	"possession_status": {"possession_status"},
+	"age":               {"min_age", "max_age"},
+}
+
+// setPropertyAttributes writes the normalized attribute columns of a property.
+func setPropertyAttributes(m *ent.PropertyMutation, attrs domain.PropertyAttributes) {
+	if attrs.Price != nil {
+		m.SetPrice(*attrs.Price)
+	} else {
+		m.ClearPrice()
+	}
+	if attrs.BuiltUpAreaSqft != nil {
+		m.SetBuiltUpAreaSqft(*attrs.BuiltUpAreaSqft)
+	} else {
+		m.ClearBuiltUpAreaSqft()
+	}
+	if attrs.PricePerSqft != nil {
+		m.SetPricePerSqft(*attrs.PricePerSqft)
+	} else {
+		m.ClearPricePerSqft()
+	}
+	if attrs.MonthlyRent != nil {
+		m.SetMonthlyRent(*attrs.MonthlyRent)
+	} else {
+		m.ClearMonthlyRent()
+	}
+	if attrs.Bedrooms != nil {
+		m.SetBedrooms(*attrs.Bedrooms)
+	} else {
+		m.ClearBedrooms()
+	}
+	if attrs.Bathrooms != nil {
+		m.SetBathrooms(*attrs.Bathrooms)
+	} else {
+		m.ClearBathrooms()
+	}
+	if attrs.Furnishing != nil {
+		m.SetFurnishing(*attrs.Furnishing)
+	} else {
+		m.ClearFurnishing()
+	}
+	if attrs.Facing != nil {
+		m.SetFacing(*attrs.Facing)
+	} else {
+		m.ClearFacing()
+	}
+	if attrs.FloorNumber != nil {
+		m.SetFloorNumber(*attrs.FloorNumber)
+	} else {
+		m.ClearFloorNumber()
+	}
+	if attrs.Possession != nil {
+		m.SetPossession(*attrs.Possession)
+	} else {
+		m.ClearPossession()
+	}
+	if attrs.AgeYears != nil {
+		m.SetAgeYears(*attrs.AgeYears)
+	} else {
+		m.ClearAgeYears()
+	}
+	m.SetAttributesSyncedAt(time.Now())
+}
+
+// SyncPropertyAttributes fills the normalized columns of properties that have never been
+// synced, such as those created before the columns existed.
+func (r *repository) SyncPropertyAttributes(ctx context.Context) (int, error) {
+	properties, err := r.db.Property.Query().
+		Where(property.AttributesSyncedAtIsNil()).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get unsynced properties")
+		return 0, err
+	}
+
+	for i, p := range properties {
+		update := r.db.Property.UpdateOneID(p.ID).
+			// Keep updated_at, the content itself did not change.
+			SetUpdatedAt(p.UpdatedAt)
+		setPropertyAttributes(update.Mutation(), domain.NormalizePropertyAttributes(p.WebCards.PropertyDetails, p.PricingInfo))
+		if err := update.Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Str("property_id", p.ID).Msg("Failed to sync property attributes")
+			return i, err
+		}
+	}
+	return len(properties), nil
+}
+
+// propertyOrder returns the ordering for a sort option. Properties missing the sorted
+// value come last, with recency as the tie-breaker. Price sorts use the monthly rent when
+// the filters select only rent and lease listings.
+func propertyOrder(sortBy string, filters map[string]interface{}) []property.OrderOption {
+	newest := property.ByCreatedAt(sql.OrderDesc())
+	byPrice := property.ByPrice
+	if isRentalOnly(filters) {
+		byPrice = property.ByMonthlyRent
+	}
+	switch sortBy {
+	case domain.PropertySortPriceAsc:
+		return []property.OrderOption{byPrice(sql.OrderAsc(), sql.OrderNullsLast()), newest}
+	case domain.PropertySortPriceDesc:
+		return []property.OrderOption{byPrice(sql.OrderDesc(), sql.OrderNullsLast()), newest}
+	case domain.PropertySortAreaAsc:
+		return []property.OrderOption{property.ByBuiltUpAreaSqft(sql.OrderAsc(), sql.OrderNullsLast()), newest}
+	case domain.PropertySortAreaDesc:
+		return []property.OrderOption{property.ByBuiltUpAreaSqft(sql.OrderDesc(), sql.OrderNullsLast()), newest}
+	case domain.PropertySortPricePerSqftAsc:
+		return []property.OrderOption{property.ByPricePerSqft(sql.OrderAsc(), sql.OrderNullsLast()), newest}
+	case domain.PropertySortPricePerSqftDesc:
+		return []property.OrderOption{property.ByPricePerSqft(sql.OrderDesc(), sql.OrderNullsLast()), newest}
+	default:
+		return []property.OrderOption{newest}
+	}
+}
+
+// isRentalOnly reports whether the listing type filter selects only rent and lease listings.
+func isRentalOnly(filters map[string]interface{}) bool {
+	types, ok := filters["listing_type"].([]string)
+	if !ok || len(types) == 0 {
+		return false
+	}
+	for _, t := range types {
+		if !domain.IsRentalListing(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyPropertyAttributeFilters applies the listing type, budget, rent, availability,
+// room, furnishing, facing, floor, possession and age filters on the normalized columns.
+func applyPropertyAttributeFilters(query *ent.PropertyQuery, filters map[string]interface{}) *ent.PropertyQuery {
+	if v, ok := filters["listing_type"].([]string); ok && len(v) > 0 {
+		types := make([]property.ListingType, len(v))
+		for i, t := range v {
+			types[i] = property.ListingType(t)
+		}
+		query = query.Where(property.ListingTypeIn(types...))
+	}
+	if v, ok := filters["min_rent"].(float64); ok {
+		query = query.Where(property.MonthlyRentGTE(v))
+	}
+	if v, ok := filters["max_rent"].(float64); ok {
+		query = query.Where(property.MonthlyRentLTE(v))
+	}
+	// Listings without an availability date are available now.
+	if v, ok := filters["available_by"].(time.Time); ok {
+		query = query.Where(property.Or(property.AvailableFromIsNil(), property.AvailableFromLTE(v)))
+	}
+	if v, ok := filters["min_price"].(float64); ok {
+		query = query.Where(property.PriceGTE(v))
+	}
+	if v, ok := filters["max_price"].(float64); ok {
+		query = query.Where(property.PriceLTE(v))
+	}
+	if v, ok := filters["bedrooms"].([]int); ok && len(v) > 0 {
+		query = query.Where(property.BedroomsIn(v...))
+	}
+	if v, ok := filters["bathrooms"].([]int); ok && len(v) > 0 {
+		query = query.Where(property.BathroomsIn(v...))
+	}
+	if v, ok := filters["furnishing"].([]string); ok && len(v) > 0 {
+		query = query.Where(property.FurnishingIn(v...))
+	}
+	if v, ok := filters["facing"].([]string); ok && len(v) > 0 {
+		query = query.Where(property.FacingIn(v...))
+	}
+	if v, ok := filters["min_floor"].(int); ok {
+		query = query.Where(property.FloorNumberGTE(v))
+	}
+	if v, ok := filters["max_floor"].(int); ok {
+		query = query.Where(property.FloorNumberLTE(v))
+	}
+	if v, ok := filters["possession_status"].([]string); ok && len(v) > 0 {
+		query = query.Where(property.PossessionIn(v...))
+	}
+	if v, ok := filters["min_age"].(int); ok {
+		query = query.Where(property.AgeYearsGTE(v))
+	}
+	if v, ok := filters["max_age"].(int); ok {
+		query = query.Where(property.AgeYearsLTE(v))
+	}
+	return query
+}
+
+// propertyFacetRow receives the result of grouping by any one facet column.
+type propertyFacetRow struct {
+	ListingType *string `json:"listing_type"`
+	Bedrooms    *int    `json:"bedrooms"`
+	Bathrooms   *int    `json:"bathrooms"`
+	Furnishing  *string `json:"furnishing"`
+	Facing      *string `json:"facing"`
+	Possession  *string `json:"possession"`
+	Count       int     `json:"count"`
+}
+
+// GetPropertyFacets counts the live properties for each value of every filter.
+func (r *repository) GetPropertyFacets(ctx context.Context, filters map[string]interface{}) (*response.PropertyFacets, error) {
+	facetQuery := func(facet string) *ent.PropertyQuery {
+		scoped := make(map[string]interface{}, len(filters))
+		for k, v := range filters {
+			scoped[k] = v
+		}
+		for _, k := range propertyFacetFilterKeys[facet] {
+			delete(scoped, k)
+		}
+		return r.applyPropertyFilters(r.db.Property.Query().Where(property.IsDeletedEQ(false)), scoped)
+	}
+
+	facets := &response.PropertyFacets{}
+	var err error
+
+	if facets.Budget, err = countPropertyRanges(ctx, facetQuery("budget"), budgetBuckets, func(b propertyRangeBucket) predicate.Property {
+		return rangePredicate(b, property.PriceGTE, property.PriceLT)
+	}); err != nil {
+		return nil, err
+	}
+	if facets.Rent, err = countPropertyRanges(ctx, facetQuery("rent"), rentBuckets, func(b propertyRangeBucket) predicate.Property {
+		return rangePredicate(b, property.MonthlyRentGTE, property.MonthlyRentLT)
+	}); err != nil {
+		return nil, err
+	}
+	if facets.Floor, err = countPropertyRanges(ctx, facetQuery("floor"), floorBuckets, func(b propertyRangeBucket) predicate.Property {
+		return rangePredicate(b, intBound(property.FloorNumberGTE), intBound(property.FloorNumberLT))
+	}); err != nil {
+		return nil, err
+	}
+	if facets.Age, err = countPropertyRanges(ctx, facetQuery("age"), ageBuckets, func(b propertyRangeBucket) predicate.Property {
+		return rangePredicate(b, intBound(property.AgeYearsGTE), intBound(property.AgeYearsLT))
+	}); err != nil {
+		return nil, err
+	}
+
+	groups := []struct {
+		facet  string
+		field  string
+		target *[]response.FacetCount
+	}{
+		{"listing_type", property.FieldListingType, &facets.ListingType},
+		{"bedrooms", property.FieldBedrooms, &facets.Bedrooms},
+		{"bathrooms", property.FieldBathrooms, &facets.Bathrooms},
+		{"furnishing", property.FieldFurnishing, &facets.Furnishing},
+		{"facing", property.FieldFacing, &facets.Facing},
+		{"possession_status", property.FieldPossession, &facets.PossessionStatus},
+	}
+	for _, g := range groups {
+		var rows []propertyFacetRow
+		if err := facetQuery(g.facet).
+			Where(predicate.Property(sql.FieldNotNull(g.field))).
+			GroupBy(g.field).
+			Aggregate(ent.Count()).
+			Scan(ctx, &rows); err != nil {
+			logger.Get().Error().Err(err).Str("facet", g.facet).Msg("Failed to count property facet")
+			return nil, err
+		}
+
+		counts := make([]response.FacetCount, 0, len(rows))
+		for _, row := range rows {
+			var value string
+			switch {
+			case row.ListingType != nil:
+				value = *row.ListingType
+			case row.Bedrooms != nil:
+				value = strconv.Itoa(*row.Bedrooms)
+			case row.Bathrooms != nil:
+				value = strconv.Itoa(*row.Bathrooms)
+			case row.Furnishing != nil:
+				value = *row.Furnishing
+			case row.Facing != nil:
+				value = *row.Facing
+			case row.Possession != nil:
+				value = *row.Possession
+			default:
+				continue
+			}
+			counts = append(counts, response.FacetCount{Value: value, Count: row.Count})
+		}
+		sortFacetCounts(counts)
+		*g.target = counts
+	}
+
+	return facets, nil
+}
+
+func countPropertyRanges(ctx context.Context, query *ent.PropertyQuery, buckets []propertyRangeBucket, where func(propertyRangeBucket) predicate.Property) ([]response.FacetCount, error) {
+	counts := make([]response.FacetCount, 0, len(buckets))
+	for _, b := range buckets {
+		count, err := query.Clone().Where(where(b)).Count(ctx)
+		if err != nil {
+			logger.Get().Error().Err(err).Str("bucket", b.value).Msg("Failed to count property range")
+			return nil, err
+		}
+		counts = append(counts, response.FacetCount{Value: b.value, Label: b.label, Count: count})
+	}
+	return counts, nil
+}
+
+func rangePredicate(b propertyRangeBucket, gte, lt func(float64) predicate.Property) predicate.Property {
+	var preds []predicate.Property
+	if b.min != nil {
+		preds = append(preds, gte(*b.min))
+	}
+	if b.max != nil {
+		preds = append(preds, lt(*b.max))
+	}
+	return property.And(preds...)
+}
+
+func intBound(fn func(int) predicate.Property) func(float64) predicate.Property {
+	return func(v float64) predicate.Property { return fn(int(v)) }
+}
+
+// sortFacetCounts orders numeric values numerically and everything else by count.
+func sortFacetCounts(counts []response.FacetCount) {
+	sort.SliceStable(counts, func(i, j int) bool {
+		a, aErr := strconv.Atoi(counts[i].Value)
+		b, bErr := strconv.Atoi(counts[j].Value)
+		if aErr == nil && bErr == nil {
+			return a < b
+		}
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+}
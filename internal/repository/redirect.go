@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/redirect"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+func (r *repository) GetRedirectByPath(ctx context.Context, path string) (*ent.Redirect, error) {
+	rd, err := r.db.Redirect.Query().
+		Where(redirect.FromPath(path)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get redirect")
+		return nil, err
+	}
+	return rd, nil
+}
+
+func (r *repository) GetRedirectByID(ctx context.Context, id string) (*ent.Redirect, error) {
+	rd, err := r.db.Redirect.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("redirect not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get redirect")
+		return nil, err
+	}
+	return rd, nil
+}
+
+func (r *repository) ListRedirects(ctx context.Context, search string, offset, limit int) ([]*ent.Redirect, int, error) {
+	query := r.db.Redirect.Query()
+	if search != "" {
+		query = query.Where(redirect.Or(
+			redirect.FromPathContainsFold(search),
+			redirect.ToPathContainsFold(search),
+		))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count redirects")
+		return nil, 0, err
+	}
+
+	redirects, err := query.
+		Order(ent.Desc(redirect.FieldUpdatedAt)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list redirects")
+		return nil, 0, err
+	}
+	return redirects, total, nil
+}
+
+// SaveRedirect creates the redirect for a path, replacing any existing one.
+func (r *repository) SaveRedirect(ctx context.Context, input domain.Redirect) (*ent.Redirect, error) {
+	if err := upsertRedirect(ctx, r.db, input); err != nil {
+		return nil, err
+	}
+	return r.GetRedirectByPath(ctx, input.FromPath)
+}
+
+func (r *repository) UpdateRedirect(ctx context.Context, id string, input domain.Redirect) (*ent.Redirect, error) {
+	rd, err := r.db.Redirect.UpdateOneID(id).
+		SetFromPath(input.FromPath).
+		SetToPath(input.ToPath).
+		SetStatusCode(input.StatusCode).
+		SetReason(input.Reason).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to update redirect")
+		return nil, err
+	}
+	return rd, nil
+}
+
+func (r *repository) DeleteRedirect(ctx context.Context, id string) error {
+	if err := r.db.Redirect.DeleteOneID(id).Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to delete redirect")
+		return err
+	}
+	return nil
+}
+
+func upsertRedirect(ctx context.Context, client *ent.Client, input domain.Redirect) error {
+	statusCode := input.StatusCode
+	if statusCode == 0 {
+		statusCode = 301
+	}
+
+	existing, err := client.Redirect.Query().
+		Where(redirect.FromPath(input.FromPath)).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		logger.Get().Error().Err(err).Msg("Failed to get redirect")
+		return err
+	}
+
+	if existing != nil {
+		err = client.Redirect.UpdateOneID(existing.ID).
+			SetToPath(input.ToPath).
+			SetStatusCode(statusCode).
+			SetReason(input.Reason).
+			Exec(ctx)
+	} else {
+		err = client.Redirect.Create().
+			SetID(uuid.New().String()).
+			SetFromPath(input.FromPath).
+			SetToPath(input.ToPath).
+			SetStatusCode(statusCode).
+			SetReason(input.Reason).
+			Exec(ctx)
+	}
+	if err != nil {
+		logger.Get().Error().Err(err).Str("from_path", input.FromPath).Msg("Failed to save redirect")
+		return err
+	}
+	return nil
+}
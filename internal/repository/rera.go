@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/ent/reraregistration"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+func (r *repository) GetReraRegistrationsOfProject(ctx context.Context, projectID string) ([]*ent.ReraRegistration, error) {
+	if projectID == "" {
+		return nil, errors.New("projectID is required")
+	}
+
+	registrations, err := r.db.ReraRegistration.Query().
+		Where(reraregistration.ProjectID(projectID)).
+		Order(ent.Asc(reraregistration.FieldPhase), ent.Asc(reraregistration.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("project_id", projectID).Msg("Failed to get rera registrations of project")
+		return nil, err
+	}
+	return registrations, nil
+}
+
+func (r *repository) GetReraRegistrationByID(ctx context.Context, id string) (*ent.ReraRegistration, error) {
+	registration, err := r.db.ReraRegistration.Query().
+		Where(reraregistration.ID(id)).
+		WithProject().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("rera registration not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get rera registration")
+		return nil, err
+	}
+	return registration, nil
+}
+
+func (r *repository) ExistReraRegistration(ctx context.Context, state, registrationNumber, excludeID string) (bool, error) {
+	query := r.db.ReraRegistration.Query().
+		Where(
+			reraregistration.State(state),
+			reraregistration.RegistrationNumber(registrationNumber),
+		)
+	if excludeID != "" {
+		query = query.Where(reraregistration.IDNEQ(excludeID))
+	}
+
+	exist, err := query.Exist(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to check if rera registration exists")
+		return false, err
+	}
+	return exist, nil
+}
+
+func (r *repository) AddReraRegistration(ctx context.Context, input domain.ReraRegistration) (*ent.ReraRegistration, error) {
+	create := r.db.ReraRegistration.Create().
+		SetID(uuid.New().String()).
+		SetProjectID(input.ProjectID).
+		SetPhase(input.Phase).
+		SetState(input.State).
+		SetAuthority(input.Authority).
+		SetRegistrationNumber(input.RegistrationNumber).
+		SetStatus(reraregistration.Status(input.Status)).
+		SetQrImage(input.QRImage).
+		SetWebsiteLink(input.WebsiteLink).
+		SetLastCheckedAt(time.Now())
+
+	if input.ValidFrom != nil {
+		create.SetValidFrom(*input.ValidFrom)
+	}
+	if input.ValidUntil != nil {
+		create.SetValidUntil(*input.ValidUntil)
+	}
+
+	registration, err := create.Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to add rera registration")
+		return nil, err
+	}
+	return registration, nil
+}
+
+func (r *repository) UpdateReraRegistration(ctx context.Context, input domain.ReraRegistration) (*ent.ReraRegistration, error) {
+	update := r.db.ReraRegistration.UpdateOneID(input.ID).
+		SetPhase(input.Phase).
+		SetState(input.State).
+		SetAuthority(input.Authority).
+		SetRegistrationNumber(input.RegistrationNumber).
+		SetStatus(reraregistration.Status(input.Status)).
+		SetQrImage(input.QRImage).
+		SetWebsiteLink(input.WebsiteLink).
+		SetLastCheckedAt(time.Now())
+
+	if input.ValidFrom != nil {
+		update.SetValidFrom(*input.ValidFrom)
+	} else {
+		update.ClearValidFrom()
+	}
+	if input.ValidUntil != nil {
+		update.SetValidUntil(*input.ValidUntil)
+	} else {
+		update.ClearValidUntil()
+	}
+
+	registration, err := update.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("rera registration not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to update rera registration")
+		return nil, err
+	}
+	return registration, nil
+}
+
+func (r *repository) DeleteReraRegistration(ctx context.Context, id string) error {
+	err := r.db.ReraRegistration.DeleteOneID(id).Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return errors.New("rera registration not found")
+		}
+		logger.Get().Error().Err(err).Msg("Failed to delete rera registration")
+		return err
+	}
+	return nil
+}
+
+// GetReraRegistrationsForStatusCheck returns every registration whose status can change with time.
+func (r *repository) GetReraRegistrationsForStatusCheck(ctx context.Context) ([]*ent.ReraRegistration, error) {
+	registrations, err := r.db.ReraRegistration.Query().
+		Where(reraregistration.StatusNEQ(reraregistration.StatusRevoked)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get rera registrations for status check")
+		return nil, err
+	}
+	return registrations, nil
+}
+
+func (r *repository) UpdateReraRegistrationStatus(ctx context.Context, id string, status string) error {
+	err := r.db.ReraRegistration.UpdateOneID(id).
+		SetStatus(reraregistration.Status(status)).
+		SetLastCheckedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("id", id).Msg("Failed to update rera registration status")
+		return err
+	}
+	return nil
+}
+
+func (r *repository) GetReraRegistrationsByStatus(ctx context.Context, statuses []string) ([]*ent.ReraRegistration, error) {
+	values := make([]reraregistration.Status, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, reraregistration.Status(status))
+	}
+
+	registrations, err := r.db.ReraRegistration.Query().
+		Where(reraregistration.StatusIn(values...)).
+		WithProject().
+		Order(ent.Asc(reraregistration.FieldValidUntil)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get rera registrations by status")
+		return nil, err
+	}
+	return registrations, nil
+}
+
+// GetProjectsWithoutReraRegistrations returns live projects that have no registrations yet,
+// with their location loaded so legacy RERA numbers can be attributed to a state.
+func (r *repository) GetProjectsWithoutReraRegistrations(ctx context.Context) ([]*ent.Project, error) {
+	projects, err := r.db.Project.Query().
+		Where(
+			project.IsDeletedEQ(false),
+			project.Not(project.HasReraRegistrations()),
+		).
+		WithLocation().
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get projects without rera registrations")
+		return nil, err
+	}
+	return projects, nil
+}
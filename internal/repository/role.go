@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/role"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+func (r *repository) ListRoles(ctx context.Context) ([]*ent.Role, error) {
+	roles, err := r.db.Role.Query().Order(ent.Asc(role.FieldID)).All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list roles")
+		return nil, err
+	}
+	return roles, nil
+}
+
+// GetRole returns a role definition, or nil if the role has none.
+func (r *repository) GetRole(ctx context.Context, name string) (*ent.Role, error) {
+	ro, err := r.db.Role.Get(ctx, name)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Str("role", name).Msg("Failed to get role")
+		return nil, err
+	}
+	return ro, nil
+}
+
+// UpdateRolePermissions edits a role definition. Nil permissions are left unchanged.
+func (r *repository) UpdateRolePermissions(ctx context.Context, name string, permissions []string, description *string, twoFactorRequired *bool, updatedBy string) (*ent.Role, error) {
+	update := r.db.Role.UpdateOneID(name).
+		SetUpdatedByUserID(updatedBy)
+	if permissions != nil {
+		update.SetPermissions(permissions)
+	}
+	if description != nil {
+		update.SetDescription(*description)
+	}
+	if twoFactorRequired != nil {
+		update.SetTwoFactorRequired(*twoFactorRequired)
+	}
+	ro, err := update.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("role not found")
+		}
+		logger.Get().Error().Err(err).Str("role", name).Msg("Failed to update role")
+		return nil, err
+	}
+	return ro, nil
+}
+
+// EnsureRoles creates the roles that have no definition yet. Existing definitions are
+// left as edited.
+func (r *repository) EnsureRoles(ctx context.Context, defaults map[string][]string) error {
+	for name, permissions := range defaults {
+		exists, err := r.db.Role.Query().Where(role.ID(name)).Exist(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		err = r.db.Role.Create().
+			SetID(name).
+			SetPermissions(permissions).
+			Exec(ctx)
+		if err != nil && !ent.IsConstraintError(err) {
+			logger.Get().Error().Err(err).Str("role", name).Msg("Failed to create role")
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	projectEnt "github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/ent/savedsearch"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+func (r *repository) CreateSavedSearch(ctx context.Context, input domain.SavedSearch) (*ent.SavedSearch, error) {
+	create := r.db.SavedSearch.Create().
+		SetID(uuid.New().String()).
+		SetName(input.Name).
+		SetChannel(savedsearch.Channel(input.Channel)).
+		SetFilters(input.Filters).
+		SetVerificationCodeHash(input.VerificationCodeHash).
+		SetVerificationExpiresAt(input.VerificationExpiresAt).
+		SetUnsubscribeToken(input.UnsubscribeToken)
+	if input.Phone != "" {
+		create.SetPhone(input.Phone)
+	}
+	if input.Email != "" {
+		create.SetEmail(input.Email)
+	}
+	if input.Frequency != "" {
+		create.SetFrequency(savedsearch.Frequency(input.Frequency))
+	}
+
+	saved, err := create.Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create saved search")
+		return nil, err
+	}
+	return saved, nil
+}
+
+// CountActiveSavedSearches counts the subscribed saved searches of a phone or email.
+// Unverified searches only hold a slot until their code expires.
+func (r *repository) CountActiveSavedSearches(ctx context.Context, phone, email string) (int, error) {
+	query := r.db.SavedSearch.Query().Where(
+		savedsearch.UnsubscribedAtIsNil(),
+		savedsearch.Or(
+			savedsearch.VerifiedAtNotNil(),
+			savedsearch.VerificationExpiresAtGT(time.Now()),
+		),
+	)
+	if phone != "" {
+		query = query.Where(savedsearch.Phone(phone))
+	} else {
+		query = query.Where(savedsearch.Email(email))
+	}
+	return query.Count(ctx)
+}
+
+func (r *repository) GetSavedSearchByID(ctx context.Context, id string) (*ent.SavedSearch, error) {
+	saved, err := r.db.SavedSearch.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("saved search not found")
+		}
+		return nil, err
+	}
+	return saved, nil
+}
+
+// MarkSavedSearchVerified starts alerts for a saved search. Only projects published or
+// re-priced after verification are matched.
+func (r *repository) MarkSavedSearchVerified(ctx context.Context, id string) (*ent.SavedSearch, error) {
+	now := time.Now()
+	return r.db.SavedSearch.UpdateOneID(id).
+		SetVerifiedAt(now).
+		SetLastCheckedAt(now).
+		ClearVerificationCodeHash().
+		ClearVerificationExpiresAt().
+		SetVerificationAttempts(0).
+		Save(ctx)
+}
+
+func (r *repository) IncrementSavedSearchVerificationAttempts(ctx context.Context, id string) error {
+	return r.db.SavedSearch.UpdateOneID(id).AddVerificationAttempts(1).Exec(ctx)
+}
+
+func (r *repository) UnsubscribeSavedSearch(ctx context.Context, token string) error {
+	updated, err := r.db.SavedSearch.Update().
+		Where(savedsearch.UnsubscribeToken(token), savedsearch.UnsubscribedAtIsNil()).
+		SetUnsubscribedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to unsubscribe saved search")
+		return err
+	}
+	if updated == 0 {
+		return errors.New("saved search not found or already unsubscribed")
+	}
+	return nil
+}
+
+// GetAlertingSavedSearches returns the verified, subscribed saved searches.
+func (r *repository) GetAlertingSavedSearches(ctx context.Context) ([]*ent.SavedSearch, error) {
+	searches, err := r.db.SavedSearch.Query().
+		Where(savedsearch.VerifiedAtNotNil(), savedsearch.UnsubscribedAtIsNil()).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get saved searches")
+		return nil, err
+	}
+	return searches, nil
+}
+
+// GetProjectsChangedSince returns live projects matching a ListProjects filter map that
+// were created or re-priced after since, newest first.
+func (r *repository) GetProjectsChangedSince(ctx context.Context, filters map[string]interface{}, since time.Time, limit int) ([]*ent.Project, error) {
+	query := applyProjectFilters(r.db.Project.Query().Where(projectEnt.IsDeletedEQ(false)), filters).
+		Where(projectEnt.Or(
+			projectEnt.CreatedAtGT(since),
+			projectEnt.PriceChangedAtGT(since),
+		))
+
+	projects, err := query.
+		WithLocation().
+		Order(ent.Desc(projectEnt.FieldUpdatedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get changed projects")
+		return nil, err
+	}
+	return projects, nil
+}
+
+// MarkSavedSearchChecked records the end of a matched window and, when a digest was sent,
+// when it was sent.
+func (r *repository) MarkSavedSearchChecked(ctx context.Context, id string, checkedAt time.Time, notified bool) error {
+	update := r.db.SavedSearch.UpdateOneID(id).SetLastCheckedAt(checkedAt)
+	if notified {
+		update.SetLastNotifiedAt(checkedAt)
+	}
+	return update.Exec(ctx)
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/refreshtoken"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// CreateRefreshToken stores a refresh token that starts a new session.
+func (r *repository) CreateRefreshToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time, meta domain.SessionMeta) (*ent.RefreshToken, error) {
+	now := time.Now()
+	t, err := r.db.RefreshToken.Create().
+		SetID(uuid.New().String()).
+		SetUserID(userID).
+		SetFamilyID(uuid.New().String()).
+		SetTokenHash(tokenHash).
+		SetUserAgent(meta.UserAgent).
+		SetIPAddress(meta.IPAddress).
+		SetSessionStartedAt(now).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to create refresh token")
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetRefreshTokenByHash returns the refresh token with a hash, or nil if there is none.
+func (r *repository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*ent.RefreshToken, error) {
+	t, err := r.db.RefreshToken.Query().Where(refreshtoken.TokenHash(tokenHash)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get refresh token")
+		return nil, err
+	}
+	return t, nil
+}
+
+// RotateRefreshToken marks a token used and issues its successor in the same family. It
+// returns nil without an error if the token was used or revoked concurrently.
+func (r *repository) RotateRefreshToken(ctx context.Context, current *ent.RefreshToken, tokenHash string, expiresAt time.Time, meta domain.SessionMeta) (*ent.RefreshToken, error) {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	nextID := uuid.New().String()
+	n, err := tx.RefreshToken.Update().
+		Where(
+			refreshtoken.ID(current.ID),
+			refreshtoken.UsedAtIsNil(),
+			refreshtoken.RevokedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		SetReplacedByID(nextID).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	next, err := tx.RefreshToken.Create().
+		SetID(nextID).
+		SetUserID(current.UserID).
+		SetFamilyID(current.FamilyID).
+		SetTokenHash(tokenHash).
+		SetUserAgent(meta.UserAgent).
+		SetIPAddress(meta.IPAddress).
+		SetSessionStartedAt(current.SessionStartedAt).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("family_id", current.FamilyID).Msg("Failed to rotate refresh token")
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// RevokeRefreshTokenFamily revokes every token of a session.
+func (r *repository) RevokeRefreshTokenFamily(ctx context.Context, familyID, reason string) error {
+	_, err := r.db.RefreshToken.Update().
+		Where(refreshtoken.FamilyID(familyID), refreshtoken.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		SetRevokedReason(reason).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("family_id", familyID).Msg("Failed to revoke refresh tokens")
+	}
+	return err
+}
+
+// RevokeUserSession revokes one session of a user. It reports whether the session existed.
+func (r *repository) RevokeUserSession(ctx context.Context, userID, familyID, reason string) (bool, error) {
+	n, err := r.db.RefreshToken.Update().
+		Where(
+			refreshtoken.UserID(userID),
+			refreshtoken.FamilyID(familyID),
+			refreshtoken.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		SetRevokedReason(reason).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Str("family_id", familyID).Msg("Failed to revoke session")
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// RevokeUserRefreshTokens revokes every session of a user.
+func (r *repository) RevokeUserRefreshTokens(ctx context.Context, userID, reason string) error {
+	_, err := r.db.RefreshToken.Update().
+		Where(refreshtoken.UserID(userID), refreshtoken.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		SetRevokedReason(reason).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to revoke refresh tokens")
+	}
+	return err
+}
+
+// ListActiveSessions returns the current token of every live session of a user, newest
+// session first.
+func (r *repository) ListActiveSessions(ctx context.Context, userID string) ([]*ent.RefreshToken, error) {
+	tokens, err := r.db.RefreshToken.Query().
+		Where(
+			refreshtoken.UserID(userID),
+			refreshtoken.UsedAtIsNil(),
+			refreshtoken.RevokedAtIsNil(),
+			refreshtoken.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(refreshtoken.FieldSessionStartedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to list sessions")
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/developer"
+	"github.com/VI-IM/im_backend_go/ent/location"
+	"github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+)
+
+// GetSimilarProjectCandidates returns the live projects that may be compared with a
+// project: those in the same city, or of the same project type when the city is unknown.
+func (r *repository) GetSimilarProjectCandidates(ctx context.Context, excludeID, city, projectType string) ([]*ent.Project, error) {
+	query := r.db.Project.Query().
+		Where(project.IDNEQ(excludeID), project.IsDeletedEQ(false))
+	if city != "" {
+		query = query.Where(project.HasLocationWith(location.CityEqualFold(city)))
+	} else {
+		query = query.Where(project.ProjectTypeEQ(project.ProjectType(projectType)))
+	}
+
+	candidates, err := query.
+		WithDeveloper(func(q *ent.DeveloperQuery) {
+			q.Select(developer.FieldID)
+		}).
+		WithLocation().
+		WithReraRegistrations().
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("project_id", excludeID).Msg("Failed to get similar project candidates")
+		return nil, err
+	}
+	return candidates, nil
+}
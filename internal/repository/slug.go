@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/blogs"
+	"github.com/VI-IM/im_backend_go/ent/customsearchpage"
+	projectEnt "github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/ent/property"
+	"github.com/VI-IM/im_backend_go/ent/redirect"
+	"github.com/VI-IM/im_backend_go/ent/slugrecord"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// GetSlugOwner returns the registry entry reserving a slug, or nil if it is free.
+func (r *repository) GetSlugOwner(ctx context.Context, slug string) (*ent.SlugRecord, error) {
+	record, err := r.db.SlugRecord.Query().
+		Where(slugrecord.Slug(slug)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Msg("Failed to get slug owner")
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetSlugHistory returns every slug an entity has used, the current one first.
+func (r *repository) GetSlugHistory(ctx context.Context, entityType, entityID string) ([]*ent.SlugRecord, error) {
+	records, err := r.db.SlugRecord.Query().
+		Where(
+			slugrecord.EntityTypeEQ(slugrecord.EntityType(entityType)),
+			slugrecord.EntityID(entityID),
+		).
+		Order(ent.Desc(slugrecord.FieldIsCurrent), ent.Desc(slugrecord.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get slug history")
+		return nil, err
+	}
+	return records, nil
+}
+
+// ChangeSlug moves an entity to a new slug. The old slug stays reserved, its page
+// redirects permanently to the new one, and redirects that pointed at the old page are
+// repointed so they never chain.
+func (r *repository) ChangeSlug(ctx context.Context, change domain.SlugChange) error {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := changeSlug(ctx, tx.Client(), change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to commit transaction")
+		return err
+	}
+	return nil
+}
+
+// changeSlug is ChangeSlug on a client that is already inside a transaction.
+func changeSlug(ctx context.Context, client *ent.Client, change domain.SlugChange) error {
+	if err := registerSlug(ctx, client, change.EntityType, change.EntityID, change.NewSlug); err != nil {
+		return err
+	}
+
+	var err error
+	switch change.EntityType {
+	case domain.SlugEntityProject:
+		err = client.Project.UpdateOneID(change.EntityID).SetSlug(change.NewSlug).Exec(ctx)
+	case domain.SlugEntityProperty:
+		err = client.Property.UpdateOneID(change.EntityID).SetSlug(change.NewSlug).Exec(ctx)
+	case domain.SlugEntityBlog:
+		err = client.Blogs.UpdateOneID(change.EntityID).SetSlug(change.NewSlug).Exec(ctx)
+	case domain.SlugEntityCustomSearchPage:
+		err = client.CustomSearchPage.UpdateOneID(change.EntityID).SetSlug(change.NewSlug).Exec(ctx)
+	default:
+		err = fmt.Errorf("unknown entity type %s", change.EntityType)
+	}
+	if err != nil {
+		logger.Get().Error().Err(err).Str("entity_type", change.EntityType).Msg("Failed to update slug")
+		return err
+	}
+
+	if change.OldSlug != "" && change.OldSlug != change.NewSlug {
+		if err := retireSlug(ctx, client, change.EntityType, change.EntityID, change.OldSlug); err != nil {
+			return err
+		}
+
+		oldPath := domain.SlugPath(change.EntityType, change.OldSlug)
+		newPath := domain.SlugPath(change.EntityType, change.NewSlug)
+		if err := client.Redirect.Update().
+			Where(redirect.ToPath(oldPath)).
+			SetToPath(newPath).
+			Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to repoint redirects")
+			return err
+		}
+		if err := upsertRedirect(ctx, client, domain.Redirect{
+			FromPath:   oldPath,
+			ToPath:     newPath,
+			StatusCode: 301,
+			Reason:     domain.RedirectReasonSlugChange,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The new page is live, so it must not redirect anywhere.
+	if _, err := client.Redirect.Delete().
+		Where(redirect.FromPath(domain.SlugPath(change.EntityType, change.NewSlug))).
+		Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to clear redirect")
+		return err
+	}
+	return nil
+}
+
+// SyncSlugRegistry registers the current slug of every entity that is missing from the
+// registry. Slugs that are already reserved by another entity are returned as conflicts.
+func (r *repository) SyncSlugRegistry(ctx context.Context) (int, []error, error) {
+	type entry struct{ entityType, id, slug string }
+	var entries []entry
+
+	projects, err := r.db.Project.Query().Where(projectEnt.SlugNEQ("")).All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get projects")
+		return 0, nil, err
+	}
+	for _, p := range projects {
+		entries = append(entries, entry{domain.SlugEntityProject, p.ID, p.Slug})
+	}
+
+	properties, err := r.db.Property.Query().Where(property.SlugNEQ("")).All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get properties")
+		return 0, nil, err
+	}
+	for _, p := range properties {
+		entries = append(entries, entry{domain.SlugEntityProperty, p.ID, p.Slug})
+	}
+
+	blogList, err := r.db.Blogs.Query().Where(blogs.SlugNEQ("")).All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get blogs")
+		return 0, nil, err
+	}
+	for _, b := range blogList {
+		entries = append(entries, entry{domain.SlugEntityBlog, b.ID, b.Slug})
+	}
+
+	pages, err := r.db.CustomSearchPage.Query().Where(customsearchpage.SlugNEQ("")).All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get custom search pages")
+		return 0, nil, err
+	}
+	for _, p := range pages {
+		entries = append(entries, entry{domain.SlugEntityCustomSearchPage, p.ID, p.Slug})
+	}
+
+	registered := make(map[string]bool)
+	existing, err := r.db.SlugRecord.Query().All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get slug records")
+		return 0, nil, err
+	}
+	for _, record := range existing {
+		registered[string(record.EntityType)+"/"+record.EntityID+"/"+record.Slug] = true
+	}
+
+	var added int
+	var conflicts []error
+	for _, e := range entries {
+		if registered[e.entityType+"/"+e.id+"/"+e.slug] {
+			continue
+		}
+		if err := registerSlug(ctx, r.db, e.entityType, e.id, e.slug); err != nil {
+			if _, ok := err.(*domain.SlugTakenError); ok {
+				conflicts = append(conflicts, fmt.Errorf("%s %s: %w", e.entityType, e.id, err))
+				continue
+			}
+			return added, conflicts, err
+		}
+		added++
+	}
+	return added, conflicts, nil
+}
+
+// registerSlug makes slug the current slug of an entity. An entity may take back one of
+// its own retired slugs, but never a slug reserved by another entity.
+func registerSlug(ctx context.Context, client *ent.Client, entityType, entityID, slug string) error {
+	existing, err := client.SlugRecord.Query().
+		Where(slugrecord.Slug(slug)).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		logger.Get().Error().Err(err).Msg("Failed to get slug record")
+		return err
+	}
+
+	if existing != nil {
+		if string(existing.EntityType) != entityType || existing.EntityID != entityID {
+			return &domain.SlugTakenError{
+				Slug:       slug,
+				EntityType: string(existing.EntityType),
+				EntityID:   existing.EntityID,
+				Retired:    !existing.IsCurrent,
+			}
+		}
+		if existing.IsCurrent {
+			return nil
+		}
+		err = client.SlugRecord.UpdateOneID(existing.ID).
+			SetIsCurrent(true).
+			ClearRetiredAt().
+			Exec(ctx)
+	} else {
+		err = client.SlugRecord.Create().
+			SetID(uuid.New().String()).
+			SetSlug(slug).
+			SetEntityType(slugrecord.EntityType(entityType)).
+			SetEntityID(entityID).
+			Exec(ctx)
+	}
+	if err != nil {
+		logger.Get().Error().Err(err).Str("slug", slug).Msg("Failed to register slug")
+		return err
+	}
+	return nil
+}
+
+// retireSlug keeps a slug reserved for an entity that no longer uses it.
+func retireSlug(ctx context.Context, client *ent.Client, entityType, entityID, slug string) error {
+	updated, err := client.SlugRecord.Update().
+		Where(
+			slugrecord.Slug(slug),
+			slugrecord.EntityTypeEQ(slugrecord.EntityType(entityType)),
+			slugrecord.EntityID(entityID),
+		).
+		SetIsCurrent(false).
+		SetRetiredAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("slug", slug).Msg("Failed to retire slug")
+		return err
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	// The slug predates the registry, so reserve it now unless someone else holds it.
+	exists, err := client.SlugRecord.Query().Where(slugrecord.Slug(slug)).Exist(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get slug record")
+		return err
+	}
+	if exists {
+		return nil
+	}
+	err = client.SlugRecord.Create().
+		SetID(uuid.New().String()).
+		SetSlug(slug).
+		SetEntityType(slugrecord.EntityType(entityType)).
+		SetEntityID(entityID).
+		SetIsCurrent(false).
+		SetRetiredAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("slug", slug).Msg("Failed to retire slug")
+		return err
+	}
+	return nil
+}
+
+// retireEntitySlugs retires every slug of an entity, e.g. when it is purged.
+func retireEntitySlugs(ctx context.Context, client *ent.Client, entityType string, entityIDs ...string) error {
+	if len(entityIDs) == 0 {
+		return nil
+	}
+	err := client.SlugRecord.Update().
+		Where(
+			slugrecord.EntityTypeEQ(slugrecord.EntityType(entityType)),
+			slugrecord.EntityIDIn(entityIDs...),
+			slugrecord.IsCurrent(true),
+		).
+		SetIsCurrent(false).
+		SetRetiredAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to retire slugs")
+		return err
+	}
+	return nil
+}
+
+// ReleaseSlug frees a retired slug so another entity can use it.
+func (r *repository) ReleaseSlug(ctx context.Context, slug string) error {
+	deleted, err := r.db.SlugRecord.Delete().
+		Where(
+			slugrecord.Slug(slug),
+			slugrecord.IsCurrent(false),
+		).
+		Exec(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to release slug")
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("slug %q is not retired", slug)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/leads"
+	"github.com/VI-IM/im_backend_go/ent/listingeventrollup"
+	"github.com/VI-IM/im_backend_go/ent/location"
+	"github.com/VI-IM/im_backend_go/ent/project"
+	"github.com/VI-IM/im_backend_go/ent/projectpopularity"
+	"github.com/VI-IM/im_backend_go/ent/property"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// GetLeadSignals returns the non-duplicate leads created since a time, attributed to a
+// project directly or through the lead's property.
+func (r *repository) GetLeadSignals(ctx context.Context, since time.Time) ([]domain.LeadSignal, error) {
+	leadsData, err := r.db.Leads.Query().
+		Where(
+			leads.CreatedAtGTE(since),
+			leads.DeletedAtIsNil(),
+			leads.Or(leads.IsDuplicateEQ(false), leads.IsDuplicateIsNil()),
+		).
+		WithProject(func(q *ent.ProjectQuery) {
+			q.Select(project.FieldID)
+		}).
+		WithProperty(func(q *ent.PropertyQuery) {
+			q.Select(property.FieldID, property.FieldProjectID)
+		}).
+		Select(leads.FieldID, leads.FieldOtpVerified, leads.FieldCreatedAt).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get lead signals")
+		return nil, err
+	}
+
+	signals := make([]domain.LeadSignal, 0, len(leadsData))
+	for _, lead := range leadsData {
+		projectID := ""
+		if lead.Edges.Project != nil {
+			projectID = lead.Edges.Project.ID
+		} else if lead.Edges.Property != nil {
+			projectID = lead.Edges.Property.ProjectID
+		}
+		if projectID == "" {
+			continue
+		}
+		signals = append(signals, domain.LeadSignal{
+			ProjectID:   projectID,
+			OtpVerified: lead.OtpVerified,
+			CreatedAt:   lead.CreatedAt,
+		})
+	}
+	return signals, nil
+}
+
+// GetViewSignals returns the daily page views since a time of projects and of their
+// properties, attributed to the project.
+func (r *repository) GetViewSignals(ctx context.Context, since time.Time) ([]domain.ViewSignal, error) {
+	rollups, err := r.db.ListingEventRollup.Query().
+		Where(
+			listingeventrollup.EventTypeEQ(listingeventrollup.EventTypePageView),
+			listingeventrollup.DateGTE(istDay(since).Format(time.DateOnly)),
+		).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get view signals")
+		return nil, err
+	}
+
+	var propertyIDs []string
+	for _, rollup := range rollups {
+		if rollup.EntityType == listingeventrollup.EntityTypeProperty {
+			propertyIDs = append(propertyIDs, rollup.EntityID)
+		}
+	}
+	projectOfProperty := make(map[string]string, len(propertyIDs))
+	if len(propertyIDs) > 0 {
+		properties, err := r.db.Property.Query().
+			Where(property.IDIn(propertyIDs...), property.ProjectIDNEQ("")).
+			Select(property.FieldID, property.FieldProjectID).
+			All(ctx)
+		if err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to get projects of viewed properties")
+			return nil, err
+		}
+		for _, p := range properties {
+			projectOfProperty[p.ID] = p.ProjectID
+		}
+	}
+
+	signals := make([]domain.ViewSignal, 0, len(rollups))
+	for _, rollup := range rollups {
+		projectID := rollup.EntityID
+		if rollup.EntityType == listingeventrollup.EntityTypeProperty {
+			projectID = projectOfProperty[rollup.EntityID]
+		}
+		day, err := time.ParseInLocation(time.DateOnly, rollup.Date, istLocation)
+		if projectID == "" || err != nil {
+			continue
+		}
+		signals = append(signals, domain.ViewSignal{
+			ProjectID: projectID,
+			Day:       day,
+			Views:     rollup.Count,
+		})
+	}
+	return signals, nil
+}
+
+// ReplaceProjectPopularity rebuilds the trending cache from freshly computed scores.
+// Deleted projects are left out. It returns the number of rows written.
+func (r *repository) ReplaceProjectPopularity(ctx context.Context, scores []domain.ProjectPopularity, computedAt time.Time) (int, error) {
+	ids := make([]string, 0, len(scores))
+	for _, s := range scores {
+		ids = append(ids, s.ProjectID)
+	}
+	projects, err := r.db.Project.Query().
+		Where(project.IDIn(ids...), project.IsDeletedEQ(false)).
+		WithLocation().
+		Select(project.FieldID).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to get projects for popularity")
+		return 0, err
+	}
+	locations := make(map[string]*ent.Location, len(projects))
+	for _, p := range projects {
+		locations[p.ID] = p.Edges.Location
+	}
+
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	client := tx.Client()
+
+	if _, err := client.ProjectPopularity.Delete().Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	builders := make([]*ent.ProjectPopularityCreate, 0, len(projects))
+	for _, s := range scores {
+		loc, ok := locations[s.ProjectID]
+		if !ok {
+			continue
+		}
+		create := client.ProjectPopularity.Create().
+			SetID(uuid.New().String()).
+			SetProjectID(s.ProjectID).
+			SetScore(s.Score).
+			SetLeads(s.Leads).
+			SetVerifiedLeads(s.VerifiedLeads).
+			SetViews(s.Views).
+			SetComputedAt(computedAt)
+		if loc != nil {
+			create.SetCity(loc.City).SetLocality(loc.LocalityName)
+		}
+		builders = append(builders, create)
+	}
+	for start := 0; start < len(builders); start += eventInsertChunk {
+		end := min(start+eventInsertChunk, len(builders))
+		if err := client.ProjectPopularity.CreateBulk(builders[start:end]...).Exec(ctx); err != nil {
+			logger.Get().Error().Err(err).Msg("Failed to write project popularity")
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(builders), nil
+}
+
+// ListTrendingProjects returns the highest scoring live projects, filtered by city,
+// locality and project_type.
+func (r *repository) ListTrendingProjects(ctx context.Context, filters map[string]interface{}, limit int) ([]*ent.ProjectPopularity, error) {
+	query := r.db.ProjectPopularity.Query().
+		Where(
+			projectpopularity.ScoreGT(0),
+			projectpopularity.HasProjectWith(project.IsDeletedEQ(false)),
+		)
+	if city, ok := filters["city"].(string); ok && city != "" {
+		query = query.Where(projectpopularity.CityEqualFold(city))
+	}
+	if locality, ok := filters["locality"].(string); ok && locality != "" {
+		query = query.Where(projectpopularity.LocalityEqualFold(locality))
+	}
+	if projectType, ok := filters["project_type"].(string); ok && projectType != "" {
+		query = query.Where(projectpopularity.HasProjectWith(project.ProjectTypeEQ(project.ProjectType(projectType))))
+	}
+
+	popularity, err := query.
+		WithProject(func(q *ent.ProjectQuery) {
+			q.WithLocation(func(lq *ent.LocationQuery) {
+				lq.Select(location.FieldID, location.FieldCity, location.FieldLocalityName)
+			})
+			q.WithReraRegistrations()
+		}).
+		Order(ent.Desc(projectpopularity.FieldScore)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list trending projects")
+		return nil, err
+	}
+	return popularity, nil
+}
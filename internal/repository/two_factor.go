@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/loginchallenge"
+	"github.com/VI-IM/im_backend_go/ent/usertwofactor"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// GetUserTwoFactor returns the user's 2FA enrollment, or nil if there is none.
+func (r *repository) GetUserTwoFactor(ctx context.Context, userID string) (*ent.UserTwoFactor, error) {
+	tf, err := r.db.UserTwoFactor.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to get two-factor enrollment")
+		return nil, err
+	}
+	return tf, nil
+}
+
+// StartTwoFactorSetup stores a new secret awaiting confirmation, replacing an unconfirmed
+// one. It returns a constraint error if 2FA is already enabled.
+func (r *repository) StartTwoFactorSetup(ctx context.Context, userID, encryptedSecret string) error {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.UserTwoFactor.Delete().
+		Where(usertwofactor.ID(userID), usertwofactor.EnabledAtIsNil()).
+		Exec(ctx); err != nil {
+		return err
+	}
+	if err := tx.UserTwoFactor.Create().
+		SetID(userID).
+		SetSecretEncrypted(encryptedSecret).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// EnableTwoFactor confirms a pending setup. It returns false if there is no pending setup
+// or the code's step was already used.
+func (r *repository) EnableTwoFactor(ctx context.Context, userID string, step int64, recoveryCodeHashes []string) (bool, error) {
+	n, err := r.db.UserTwoFactor.Update().
+		Where(
+			usertwofactor.ID(userID),
+			usertwofactor.EnabledAtIsNil(),
+			usertwofactor.LastUsedStepLT(step),
+		).
+		SetEnabledAt(time.Now()).
+		SetLastUsedStep(step).
+		SetRecoveryCodeHashes(recoveryCodeHashes).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to enable two-factor authentication")
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// UseTwoFactorStep records the step of an accepted code. It returns false if that step or
+// a later one was already used, so concurrent requests cannot both accept one code.
+func (r *repository) UseTwoFactorStep(ctx context.Context, userID string, step int64) (bool, error) {
+	n, err := r.db.UserTwoFactor.Update().
+		Where(usertwofactor.ID(userID), usertwofactor.LastUsedStepLT(step)).
+		SetLastUsedStep(step).
+		Save(ctx)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// UseRecoveryCode removes a recovery code. It returns false if the code is not one of
+// the user's unused codes.
+func (r *repository) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	tf, err := r.db.UserTwoFactor.Query().
+		Where(usertwofactor.ID(userID), usertwofactor.EnabledAtNotNil()).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	remaining := make([]string, 0, len(tf.RecoveryCodeHashes))
+	found := false
+	for _, h := range tf.RecoveryCodeHashes {
+		if h == codeHash && !found {
+			found = true
+			continue
+		}
+		remaining = append(remaining, h)
+	}
+	if !found {
+		return false, nil
+	}
+	// Only update the row as read, so two requests cannot both spend the same code
+	n, err := r.db.UserTwoFactor.Update().
+		Where(usertwofactor.ID(userID), usertwofactor.UpdatedAt(tf.UpdatedAt)).
+		SetRecoveryCodeHashes(remaining).
+		Save(ctx)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *repository) ReplaceRecoveryCodes(ctx context.Context, userID string, recoveryCodeHashes []string) error {
+	return r.db.UserTwoFactor.UpdateOneID(userID).SetRecoveryCodeHashes(recoveryCodeHashes).Exec(ctx)
+}
+
+// DeleteUserTwoFactor removes the user's enrollment. It returns false if there was none.
+func (r *repository) DeleteUserTwoFactor(ctx context.Context, userID string) (bool, error) {
+	n, err := r.db.UserTwoFactor.Delete().Where(usertwofactor.ID(userID)).Exec(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to delete two-factor enrollment")
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *repository) CreateLoginChallenge(ctx context.Context, userID, purpose, tokenHash string, expiresAt time.Time) error {
+	err := r.db.LoginChallenge.Create().
+		SetID(uuid.New().String()).
+		SetUserID(userID).
+		SetPurpose(loginchallenge.Purpose(purpose)).
+		SetTokenHash(tokenHash).
+		SetExpiresAt(expiresAt).
+		Exec(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Msg("Failed to create login challenge")
+	}
+	return err
+}
+
+// GetLoginChallenge returns an unexpired, unused challenge, or nil.
+func (r *repository) GetLoginChallenge(ctx context.Context, tokenHash string) (*ent.LoginChallenge, error) {
+	c, err := r.db.LoginChallenge.Query().
+		Where(
+			loginchallenge.TokenHash(tokenHash),
+			loginchallenge.UsedAtIsNil(),
+			loginchallenge.ExpiresAtGT(time.Now()),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// FailLoginChallenge counts a wrong answer and uses up the challenge once maxAttempts
+// is reached.
+func (r *repository) FailLoginChallenge(ctx context.Context, id string, maxAttempts int) error {
+	c, err := r.db.LoginChallenge.UpdateOneID(id).AddAttempts(1).Save(ctx)
+	if err != nil {
+		return err
+	}
+	if c.Attempts >= maxAttempts {
+		return r.db.LoginChallenge.UpdateOneID(id).SetUsedAt(time.Now()).Exec(ctx)
+	}
+	return nil
+}
+
+// ConsumeLoginChallenge marks a challenge used. It returns false if it was already used.
+func (r *repository) ConsumeLoginChallenge(ctx context.Context, id string) (bool, error) {
+	n, err := r.db.LoginChallenge.Update().
+		Where(loginchallenge.ID(id), loginchallenge.UsedAtIsNil()).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
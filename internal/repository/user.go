@@ -8,6 +8,8 @@ import (
 
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/user"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/shared/logger"
 )
 
 func (r *repository) GetUserDetailsByEmail(ctx context.Context, email string) (*ent.User, error) {
@@ -63,3 +65,144 @@ func (r *repository) CheckIfUserExistsByID(ctx context.Context, userID string) (
 	}
 	return exists, nil
 }
+
+// ListUsers returns a page of users that are not deleted, newest first.
+func (r *repository) ListUsers(ctx context.Context, filter domain.UserFilter, offset, limit int) ([]*ent.User, int, error) {
+	query := r.db.User.Query().Where(user.DeletedAtIsNil())
+	if filter.Role != "" {
+		query = query.Where(user.RoleEQ(user.Role(filter.Role)))
+	}
+	if filter.IsActive != nil {
+		query = query.Where(user.IsActive(*filter.IsActive))
+	}
+	if filter.Search != "" {
+		query = query.Where(user.Or(
+			user.NameContainsFold(filter.Search),
+			user.EmailContainsFold(filter.Search),
+			user.UsernameContainsFold(filter.Search),
+		))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to count users")
+		return nil, 0, err
+	}
+	users, err := query.
+		WithCreatedByUser().
+		WithUpdatedByUser().
+		Order(ent.Desc(user.FieldCreatedAt)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to list users")
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// GetUserForAdmin returns a user that is not deleted, with who created and last updated it.
+func (r *repository) GetUserForAdmin(ctx context.Context, id string) (*ent.User, error) {
+	u, err := r.db.User.Query().
+		Where(user.ID(id), user.DeletedAtIsNil()).
+		WithCreatedByUser().
+		WithUpdatedByUser().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("user not found")
+		}
+		logger.Get().Error().Err(err).Str("user_id", id).Msg("Failed to get user")
+		return nil, err
+	}
+	return u, nil
+}
+
+// GetUserAccess returns what decides whether a user's tokens are still honoured, or nil
+// when the user no longer exists.
+func (r *repository) GetUserAccess(ctx context.Context, id string) (*ent.User, error) {
+	u, err := r.db.User.Query().
+		Where(user.ID(id), user.DeletedAtIsNil()).
+		Select(user.FieldID, user.FieldRole, user.FieldIsActive).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		logger.Get().Error().Err(err).Str("user_id", id).Msg("Failed to get user access")
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *repository) CreateUserByAdmin(ctx context.Context, input domain.NewUser) (*ent.User, error) {
+	create := r.db.User.Create().
+		SetID(fmt.Sprintf("%x", sha256.Sum256([]byte(input.Email)))[:16]).
+		SetUsername(input.Username).
+		SetPassword(input.PasswordHash).
+		SetEmail(input.Email).
+		SetName(input.Name).
+		SetPhoneNumber(input.PhoneNumber).
+		SetRole(user.Role(input.Role)).
+		SetIsActive(true).
+		SetIsVerified(input.IsVerified).
+		SetCreatedByUserID(input.CreatedByUserID).
+		SetUpdatedByUserID(input.CreatedByUserID)
+	if input.ParentID != nil {
+		create.SetParentID(*input.ParentID)
+	}
+	u, err := create.Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Msg("Failed to create user")
+		return nil, err
+	}
+	return r.GetUserForAdmin(ctx, u.ID)
+}
+
+func (r *repository) UpdateUserByAdmin(ctx context.Context, id string, input domain.UserUpdate, updatedBy string) (*ent.User, error) {
+	update := r.db.User.UpdateOneID(id).
+		Where(user.DeletedAtIsNil()).
+		SetUpdatedByUserID(updatedBy)
+	if input.Username != nil {
+		update.SetUsername(*input.Username)
+	}
+	if input.Email != nil {
+		update.SetEmail(*input.Email)
+	}
+	if input.Name != nil {
+		update.SetName(*input.Name)
+	}
+	if input.PhoneNumber != nil {
+		update.SetPhoneNumber(*input.PhoneNumber)
+	}
+	if input.Role != nil {
+		update.SetRole(user.Role(*input.Role))
+	}
+	if input.IsActive != nil {
+		update.SetIsActive(*input.IsActive)
+	}
+	if input.IsVerified != nil {
+		update.SetIsVerified(*input.IsVerified)
+	}
+	if input.ParentID != nil {
+		update.SetParentID(*input.ParentID)
+	}
+	if input.PasswordHash != nil {
+		update.SetPassword(*input.PasswordHash)
+	}
+	if input.IsEmailVerified != nil {
+		update.SetIsEmailVerified(*input.IsEmailVerified)
+	}
+	if input.Unlock {
+		update.SetFailedLoginCount(0).ClearLastFailedLoginAt().ClearLockedUntil()
+	}
+	if err := update.Exec(ctx); err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("user not found")
+		}
+		logger.Get().Error().Err(err).Str("user_id", id).Msg("Failed to update user")
+		return nil, err
+	}
+	return r.GetUserForAdmin(ctx, id)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/user"
+	"github.com/VI-IM/im_backend_go/ent/usertoken"
+	"github.com/VI-IM/im_backend_go/shared/logger"
+	"github.com/google/uuid"
+)
+
+// CreateUserToken stores a new emailed token and retires the user's earlier unused tokens
+// of the same purpose, so only the latest email works.
+func (r *repository) CreateUserToken(ctx context.Context, userID, purpose, tokenHash, email string, expiresAt time.Time) error {
+	tx, err := r.db.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if _, err := tx.UserToken.Update().
+		Where(
+			usertoken.UserID(userID),
+			usertoken.PurposeEQ(usertoken.Purpose(purpose)),
+			usertoken.UsedAtIsNil(),
+		).
+		SetUsedAt(now).
+		Save(ctx); err != nil {
+		return err
+	}
+
+	if err := tx.UserToken.Create().
+		SetID(uuid.New().String()).
+		SetUserID(userID).
+		SetPurpose(usertoken.Purpose(purpose)).
+		SetTokenHash(tokenHash).
+		SetEmail(email).
+		SetExpiresAt(expiresAt).
+		Exec(ctx); err != nil {
+		logger.Get().Error().Err(err).Str("user_id", userID).Str("purpose", purpose).Msg("Failed to create user token")
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// LastUserTokenAt returns when the user was last sent a token of a purpose, or nil.
+func (r *repository) LastUserTokenAt(ctx context.Context, userID, purpose string) (*time.Time, error) {
+	t, err := r.db.UserToken.Query().
+		Where(usertoken.UserID(userID), usertoken.PurposeEQ(usertoken.Purpose(purpose))).
+		Order(ent.Desc(usertoken.FieldCreatedAt)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t.CreatedAt, nil
+}
+
+// ConsumeUserToken marks an unexpired, unused token used and returns it. It returns nil
+// if the token is unknown, expired or already used.
+func (r *repository) ConsumeUserToken(ctx context.Context, tokenHash, purpose string) (*ent.UserToken, error) {
+	now := time.Now()
+	n, err := r.db.UserToken.Update().
+		Where(
+			usertoken.TokenHash(tokenHash),
+			usertoken.PurposeEQ(usertoken.Purpose(purpose)),
+			usertoken.UsedAtIsNil(),
+			usertoken.ExpiresAtGT(now),
+		).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		logger.Get().Error().Err(err).Str("purpose", purpose).Msg("Failed to consume user token")
+		return nil, err
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return r.db.UserToken.Query().Where(usertoken.TokenHash(tokenHash)).Only(ctx)
+}
+
+func (r *repository) SetUserPassword(ctx context.Context, userID, passwordHash string) error {
+	return r.db.User.UpdateOneID(userID).SetPassword(passwordHash).Exec(ctx)
+}
+
+// MarkUserEmailVerified marks the user's email verified if it is still the given address.
+func (r *repository) MarkUserEmailVerified(ctx context.Context, userID, email string) (bool, error) {
+	n, err := r.db.User.Update().
+		Where(user.ID(userID), user.Email(email)).
+		SetIsEmailVerified(true).
+		Save(ctx)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
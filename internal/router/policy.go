@@ -0,0 +1,98 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/internal/middleware"
+	"github.com/gorilla/mux"
+)
+
+// Policy is the access rule shared by a group of routes.
+type Policy struct {
+	Name       string
+	Middleware mux.MiddlewareFunc // nil for public routes
+}
+
+var (
+	PolicyPublic        = Policy{Name: "public"}
+	PolicyAuthenticated = Policy{Name: "authenticated", Middleware: middleware.Auth}
+	PolicyBuyer         = Policy{Name: "buyer", Middleware: middleware.RequireBuyer}
+)
+
+// PolicyPermission requires a signed-in user whose role grants the permission.
+func PolicyPermission(permission string) Policy {
+	return Policy{Name: permission, Middleware: middleware.Require(permission)}
+}
+
+// routePolicies records the policy every route was registered with, for CheckRoutePolicies.
+var routePolicies = make(map[*mux.Route]Policy)
+
+// routeGroup is a subrouter whose routes all share one policy.
+type routeGroup struct {
+	router *mux.Router
+	policy Policy
+}
+
+func newRouteGroup(parent *mux.Router, policy Policy) *routeGroup {
+	sub := parent.NewRoute().Subrouter()
+	if policy.Middleware != nil {
+		sub.Use(policy.Middleware)
+	}
+	return &routeGroup{router: sub, policy: policy}
+}
+
+func newPermissionGroup(parent *mux.Router, permission string) *routeGroup {
+	return newRouteGroup(parent, PolicyPermission(permission))
+}
+
+func (g *routeGroup) handle(path string, handler http.Handler, methods ...string) {
+	route := g.router.Handle(path, handler).Methods(methods...)
+	routePolicies[route] = g.policy
+}
+
+func (g *routeGroup) handlePrefix(prefix string, handler http.HandlerFunc) {
+	route := g.router.PathPrefix(prefix).HandlerFunc(handler)
+	routePolicies[route] = g.policy
+}
+
+// internalPathPrefix is the prefix of routes for signed-in staff and partners.
+const internalPathPrefix = "/v1/api/internal"
+
+// CheckRoutePolicies verifies that every internal or mutating route was registered with a
+// policy and that no internal route is public.
+func CheckRoutePolicies(router *mux.Router) error {
+	var problems []error
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil // a subrouter, its routes are walked separately
+		}
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		mutating := err != nil // a route without methods accepts all of them
+		for _, method := range methods {
+			if method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions {
+				mutating = true
+			}
+		}
+		internal := strings.HasPrefix(path, internalPathPrefix)
+
+		policy, ok := routePolicies[route]
+		switch {
+		case !ok && (internal || mutating):
+			problems = append(problems, fmt.Errorf("%s %s has no policy", strings.Join(methods, ","), path))
+		case ok && internal && policy.Middleware == nil:
+			problems = append(problems, fmt.Errorf("%s %s is internal but %s", strings.Join(methods, ","), path, policy.Name))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errors.Join(problems...)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/VI-IM/im_backend_go/internal/application"
 	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/internal/handlers"
 	"github.com/VI-IM/im_backend_go/internal/middleware"
 	"github.com/VI-IM/im_backend_go/internal/static"
@@ -38,6 +39,14 @@ func corsMiddleware(_ *mux.Router) mux.MiddlewareFunc {
 // serveStaticFiles serves the React application static files with priority: proxy > memory > filesystem
 func serveStaticFiles(w http.ResponseWriter, r *http.Request) {
 	cfg := config.GetConfig()
+
+	// Retired slugs and purged pages are redirected; asset requests skip the lookup
+	if filepath.Ext(r.URL.Path) == "" {
+		if rd, err := app.GetRedirect(r.Context(), strings.TrimSuffix(r.URL.Path, "/")); err == nil && rd != nil {
+			http.Redirect(w, r, rd.ToPath, rd.StatusCode)
+			return
+		}
+	}
 	
 	// Priority 1: Proxy to frontend development server if configured
 	if cfg.FrontendProxyURL != "" {
@@ -60,7 +69,7 @@ func serveStaticFiles(w http.ResponseWriter, r *http.Request) {
 		// Check if this is a bot request and serve SEO-optimized content
 		if isBotUserAgent(r.UserAgent()) {
 			// Handle SEO routes for bots with pre-rendered HTML content
-			// Supports: /<project_slug>, /blogs/<blog_slug>, /propertyforsale/<property_slug>
+			// Supports: /<project_slug>, /blogs/<blog_slug>, /propertyforsale/<property_slug>, /propertyforrent/<property_slug>
 			if handleSEORoute(w, r, app) {
 				return
 			}
@@ -149,7 +158,7 @@ func serveReactApp(w http.ResponseWriter, r *http.Request) {
 	// create a proxy for the frontend
 	if isBotUserAgent(r.UserAgent()) {
 		// Handle SEO routes for bots with pre-rendered HTML content
-		// Supports: /<project_slug>, /blogs/<blog_slug>, /propertyforsale/<property_slug>
+		// Supports: /<project_slug>, /blogs/<blog_slug>, /propertyforsale/<property_slug>, /propertyforrent/<property_slug>
 		if handleSEORoute(w, r, app) {
 			return
 		}
@@ -173,6 +182,11 @@ func Init(appInstance application.ApplicationInterface) {
 		panic(err)
 	}
 
+	// Permission checks and API keys resolve through the application
+	middleware.SetPermissionResolver(app)
+	middleware.SetAPIKeyAuthenticator(app)
+	middleware.SetUserValidator(app)
+
 	// Initialize handlers with controller
 	handler := handlers.NewHandler(app)
 
@@ -181,109 +195,267 @@ func Init(appInstance application.ApplicationInterface) {
 	Router.Use(corsMiddleware(Router))
 	//Router.Use(middleware.SecurityHeadersMiddleware(config.GetConfig()))
 
+	// Every API route is registered through a group that declares its policy. Groups are
+	// matched in order, so a specific path in a later group must not be shadowed by a
+	// wildcard in an earlier one.
+	api := Router.PathPrefix("/v1/api").Subrouter()
+	authenticated := newRouteGroup(api, PolicyAuthenticated)
+	mediaUpload := newPermissionGroup(api, domain.PermissionMediaUpload)
+	developerWrite := newPermissionGroup(api, domain.PermissionDeveloperWrite)
+	locationWrite := newPermissionGroup(api, domain.PermissionLocationWrite)
+	leadRead := newPermissionGroup(api, domain.PermissionLeadRead)
+	buyer := newRouteGroup(api, PolicyBuyer)
+	public := newRouteGroup(api, PolicyPublic)
+
+	internal := api.PathPrefix("/internal").Subrouter()
+	internalAuthenticated := newRouteGroup(internal, PolicyAuthenticated)
+	projectWrite := newPermissionGroup(internal, domain.PermissionProjectWrite)
+	projectDelete := newPermissionGroup(internal, domain.PermissionProjectDelete)
+	propertyWrite := newPermissionGroup(internal, domain.PermissionPropertyWrite)
+	propertyModerate := newPermissionGroup(internal, domain.PermissionPropertyModerate)
+	blogPublish := newPermissionGroup(internal, domain.PermissionBlogPublish)
+	contentWrite := newPermissionGroup(internal, domain.PermissionContentWrite)
+	internalLocationWrite := newPermissionGroup(internal, domain.PermissionLocationWrite)
+	analyticsRead := newPermissionGroup(internal, domain.PermissionAnalyticsRead)
+	notificationRead := newPermissionGroup(internal, domain.PermissionNotificationRead)
+	partnerPortal := newPermissionGroup(internal, domain.PermissionPartnerPortal)
+	partnerTeamManage := newPermissionGroup(internal, domain.PermissionPartnerTeamManage)
+	systemMaintain := newPermissionGroup(internal, domain.PermissionSystemMaintain)
+	roleManage := newPermissionGroup(internal, domain.PermissionRoleManage)
+	userManage := newPermissionGroup(internal, domain.PermissionUserManage)
+	apiKeyManage := newPermissionGroup(internal, domain.PermissionAPIKeyManage)
+	auditRead := newPermissionGroup(internal, domain.PermissionAuditRead)
+
 	// Public routes
-	Router.HandleFunc("/v1/api/health", handlers.HealthCheck).Methods(http.MethodGet)
+	public.handle("/health", http.HandlerFunc(handlers.HealthCheck), http.MethodGet)
 
 	// auth routes
-	Router.Handle("/v1/api/auth/generate-token", imhttp.AppHandler(handler.GenerateToken)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/auth/refresh-token", imhttp.AppHandler(handler.RefreshToken)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/auth/signup", imhttp.AppHandler(handler.Signup)).Methods(http.MethodPost)
-
-	// project routes - specific routes must come before wildcard routes
-	Router.Handle("/v1/api/projects/compare", imhttp.AppHandler(handler.CompareProjects)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/projects/names", middleware.Auth(imhttp.AppHandler(handler.GetProjectNames))).Methods(http.MethodGet)
-	Router.Handle("/v1/api/projects/{project_id}", imhttp.AppHandler(handler.GetProject)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/s/projects/{slug}", imhttp.AppHandler(handler.GetProjectBySlug)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/projects", imhttp.AppHandler(handler.ListProjects)).Methods(http.MethodGet)
-
-	Router.Handle("/v1/api/projects/compare", imhttp.AppHandler(handler.CompareProjects)).Methods(http.MethodPost)
+	public.handle("/auth/generate-token", imhttp.AppHandler(handler.GenerateToken), http.MethodPost)
+	public.handle("/auth/refresh-token", imhttp.AppHandler(handler.RefreshToken), http.MethodPost)
+	public.handle("/auth/logout", imhttp.AppHandler(handler.Logout), http.MethodPost)
+	authenticated.handle("/auth/logout-all", imhttp.AppHandler(handler.LogoutAll), http.MethodPost)
+	public.handle("/auth/password/forgot", imhttp.AppHandler(handler.ForgotPassword), http.MethodPost)
+	public.handle("/auth/password/reset", imhttp.AppHandler(handler.ResetPassword), http.MethodPost)
+	authenticated.handle("/auth/email/verification", imhttp.AppHandler(handler.RequestEmailVerification), http.MethodPost)
+	public.handle("/auth/email/verify", imhttp.AppHandler(handler.VerifyEmail), http.MethodPost)
+	public.handle("/auth/2fa/challenge/setup", imhttp.AppHandler(handler.SetupTwoFactorChallenge), http.MethodPost)
+	public.handle("/auth/2fa/challenge/verify", imhttp.AppHandler(handler.VerifyTwoFactorChallenge), http.MethodPost)
+	authenticated.handle("/auth/2fa", imhttp.AppHandler(handler.GetTwoFactorStatus), http.MethodGet)
+	authenticated.handle("/auth/2fa/setup", imhttp.AppHandler(handler.SetupTwoFactor), http.MethodPost)
+	authenticated.handle("/auth/2fa/enable", imhttp.AppHandler(handler.EnableTwoFactor), http.MethodPost)
+	authenticated.handle("/auth/2fa/disable", imhttp.AppHandler(handler.DisableTwoFactor), http.MethodPost)
+	authenticated.handle("/auth/2fa/recovery-codes", imhttp.AppHandler(handler.RegenerateRecoveryCodes), http.MethodPost)
+	public.handle("/auth/signup", imhttp.AppHandler(handler.Signup), http.MethodPost)
+	public.handle("/auth/invitations/accept", imhttp.AppHandler(handler.AcceptInvitation), http.MethodPost)
+
+	// project routes - /projects/names is in an earlier group than /projects/{project_id}
+	authenticated.handle("/projects/names", imhttp.AppHandler(handler.GetProjectNames), http.MethodGet)
+	public.handle("/projects/compare", imhttp.AppHandler(handler.CompareProjects), http.MethodPost)
+	public.handle("/projects/trending", imhttp.AppHandler(handler.ListTrendingProjects), http.MethodGet)
+	public.handle("/projects/{project_id}", imhttp.AppHandler(handler.GetProject), http.MethodGet)
+	public.handle("/projects/{project_id}/similar", imhttp.AppHandler(handler.GetSimilarProjects), http.MethodGet)
+	public.handle("/projects/{project_id}/brochure", imhttp.AppHandler(handler.GetProjectBrochure), http.MethodGet)
+	public.handle("/projects/{project_id}/price-history", imhttp.AppHandler(handler.GetProjectPriceHistory), http.MethodGet)
+	public.handle("/s/projects/{slug}", imhttp.AppHandler(handler.GetProjectBySlug), http.MethodGet)
+	public.handle("/projects", imhttp.AppHandler(handler.ListProjects), http.MethodGet)
 
 	// project internal routes
-	Router.Handle("/v1/api/internal/projects", imhttp.AppHandler(handler.AddProject)).Methods(http.MethodPost)                                         // internal
-	Router.Handle("/v1/api/internal/projects/{project_id}", imhttp.AppHandler(handler.UpdateProject)).Methods(http.MethodPatch)                        // internal
-	Router.Handle("/v1/api/internal/projects/{project_id}", middleware.RequireDM(imhttp.AppHandler(handler.DeleteProject))).Methods(http.MethodDelete) // internal
-	Router.Handle("/v1/api/internal/projects/filters", imhttp.AppHandler(handler.GetProjectFilters)).Methods(http.MethodGet)                           // internal
+	internalAuthenticated.handle("/projects/filters", imhttp.AppHandler(handler.GetProjectFilters), http.MethodGet)
+	projectWrite.handle("/projects", imhttp.AppHandler(handler.AddProject), http.MethodPost)
+	projectWrite.handle("/projects/import", imhttp.AppHandler(handler.ImportProjects), http.MethodPost)
+	projectWrite.handle("/projects/export", http.HandlerFunc(handler.ExportProjects), http.MethodGet)
+	projectWrite.handle("/projects/{project_id}", imhttp.AppHandler(handler.UpdateProject), http.MethodPatch)
+	projectDelete.handle("/projects/{project_id}", imhttp.AppHandler(handler.DeleteProject), http.MethodDelete)
+	projectDelete.handle("/projects/{project_id}/deletion-plan", imhttp.AppHandler(handler.GetProjectDeletionPlan), http.MethodGet)
+	projectWrite.handle("/projects/{project_id}/restore", imhttp.AppHandler(handler.RestoreProject), http.MethodPost)
+
+	// rera routes
+	public.handle("/projects/{project_id}/rera", imhttp.AppHandler(handler.GetProjectReraRegistrations), http.MethodGet)
+	projectWrite.handle("/projects/{project_id}/rera", imhttp.AppHandler(handler.AddReraRegistration), http.MethodPost)
+	projectWrite.handle("/rera/expiring", imhttp.AppHandler(handler.ListExpiringReraRegistrations), http.MethodGet)
+	projectWrite.handle("/rera/refresh-status", imhttp.AppHandler(handler.RefreshReraStatuses), http.MethodPost)
+	projectWrite.handle("/rera/{rera_id}", imhttp.AppHandler(handler.UpdateReraRegistration), http.MethodPatch)
+	projectWrite.handle("/rera/{rera_id}", imhttp.AppHandler(handler.DeleteReraRegistration), http.MethodDelete)
+
+	// deletion routes
+	systemMaintain.handle("/deletions/purge", imhttp.AppHandler(handler.PurgeDeletions), http.MethodPost)
+
+	// redirect and slug routes
+	contentWrite.handle("/redirects", imhttp.AppHandler(handler.ListRedirects), http.MethodGet)
+	contentWrite.handle("/redirects", imhttp.AppHandler(handler.CreateRedirect), http.MethodPost)
+	contentWrite.handle("/redirects/{redirect_id}", imhttp.AppHandler(handler.UpdateRedirect), http.MethodPatch)
+	contentWrite.handle("/redirects/{redirect_id}", imhttp.AppHandler(handler.DeleteRedirect), http.MethodDelete)
+	contentWrite.handle("/slugs", imhttp.AppHandler(handler.GetSlugHistory), http.MethodGet)
+	systemMaintain.handle("/slugs/sync", imhttp.AppHandler(handler.SyncSlugRegistry), http.MethodPost)
+	contentWrite.handle("/slugs/{slug}", imhttp.AppHandler(handler.ReleaseSlug), http.MethodDelete)
+
+	// upload file routes - used by both data managers and business partners
+	mediaUpload.handle("/upload", imhttp.AppHandler(handler.UploadFile), http.MethodPost)
 
-	// upload file routes
-	Router.Handle("/v1/api/upload", imhttp.AppHandler(handler.UploadFile)).Methods(http.MethodPost)
 	// property routes
-	Router.Handle("/v1/api/projects/{project_id}/properties", imhttp.AppHandler(handler.GetPropertiesOfProject)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/s/properties/{slug}", imhttp.AppHandler(handler.GetPropertyBySlug)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/properties/{property_id}", imhttp.AppHandler(handler.GetProperty)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/properties/slug/{slug}", imhttp.AppHandler(handler.GetPropertyBySlug)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/properties", imhttp.AppHandler(handler.ListProperties)).Methods(http.MethodGet)
-
-	//internal routes
-	// Protected property internal routes (require business_partner or superadmin role)
-	Router.Handle("/v1/api/internal/properties", middleware.RequireBusinessPartner(imhttp.AppHandler(handler.AddProperty))).Methods(http.MethodPost)
-	Router.Handle("/v1/api/internal/properties/{property_id}", middleware.RequireBusinessPartner(imhttp.AppHandler(handler.UpdateProperty))).Methods(http.MethodPatch)
-	Router.Handle("/v1/api/internal/properties/{property_id}", middleware.RequireBusinessPartner(imhttp.AppHandler(handler.DeleteProperty))).Methods(http.MethodDelete)
-
-	// internal routes
-	// Admin property route - business partners see only their properties, superadmins see all properties
-	Router.Handle("/v1/api/internal/admin/dashboard/properties", middleware.RequireBusinessPartner(imhttp.AppHandler(handler.AdminListProperties))).Methods(http.MethodGet)
+	public.handle("/projects/{project_id}/properties", imhttp.AppHandler(handler.GetPropertiesOfProject), http.MethodGet)
+	public.handle("/s/properties/{slug}", imhttp.AppHandler(handler.GetPropertyBySlug), http.MethodGet)
+	public.handle("/properties/{property_id}", imhttp.AppHandler(handler.GetProperty), http.MethodGet)
+	public.handle("/properties/slug/{slug}", imhttp.AppHandler(handler.GetPropertyBySlug), http.MethodGet)
+	public.handle("/properties", imhttp.AppHandler(handler.ListProperties), http.MethodGet)
+
+	// Protected property internal routes - owners edit their own listings, property.write.all edits any
+	propertyWrite.handle("/properties", imhttp.AppHandler(handler.AddProperty), http.MethodPost)
+	propertyWrite.handle("/properties/{property_id}", imhttp.AppHandler(handler.UpdateProperty), http.MethodPatch)
+	propertyWrite.handle("/properties/{property_id}", imhttp.AppHandler(handler.DeleteProperty), http.MethodDelete)
+	propertyWrite.handle("/properties/{property_id}/deletion-plan", imhttp.AppHandler(handler.GetPropertyDeletionPlan), http.MethodGet)
+	propertyWrite.handle("/properties/{property_id}/restore", imhttp.AppHandler(handler.RestoreProperty), http.MethodPost)
+
+	// Admin property route - users see their own properties unless they have property.read.all
+	propertyWrite.handle("/admin/dashboard/properties", imhttp.AppHandler(handler.AdminListProperties), http.MethodGet)
+
+	// Property moderation: submissions without property.publish are reviewed before going live
+	propertyModerate.handle("/moderation/properties", imhttp.AppHandler(handler.ListPropertyRevisions), http.MethodGet)
+	propertyModerate.handle("/moderation/properties/{revision_id}", imhttp.AppHandler(handler.GetPropertyRevision), http.MethodGet)
+	propertyModerate.handle("/moderation/properties/{revision_id}/approve", imhttp.AppHandler(handler.ApprovePropertyRevision), http.MethodPost)
+	propertyModerate.handle("/moderation/properties/{revision_id}/reject", imhttp.AppHandler(handler.RejectPropertyRevision), http.MethodPost)
+
+	// Saved searches - unsubscribe is linked from alert messages, so it accepts GET
+	public.handle("/saved-searches", imhttp.AppHandler(handler.CreateSavedSearch), http.MethodPost)
+	public.handle("/saved-searches/unsubscribe", imhttp.AppHandler(handler.UnsubscribeSavedSearch), http.MethodGet, http.MethodPost)
+	public.handle("/saved-searches/{saved_search_id}/verify", imhttp.AppHandler(handler.VerifySavedSearch), http.MethodPost)
+
+	// Home loan and purchase cost calculators
+	public.handle("/finance/emi", imhttp.AppHandler(handler.CalculateEMI), http.MethodPost)
+	public.handle("/finance/affordability", imhttp.AppHandler(handler.CalculateAffordability), http.MethodPost)
+	public.handle("/finance/purchase-charges", imhttp.AppHandler(handler.CalculatePurchaseCharges), http.MethodPost)
+	public.handle("/finance/stamp-duty-rates", imhttp.AppHandler(handler.ListStampDutyRates), http.MethodGet)
+
+	// Buyer accounts - buyer tokens are only accepted by RequireBuyer, never on internal routes
+	public.handle("/buyer/auth/otp", imhttp.AppHandler(handler.RequestBuyerOTP), http.MethodPost)
+	public.handle("/buyer/auth/verify", imhttp.AppHandler(handler.VerifyBuyerOTP), http.MethodPost)
+	buyer.handle("/buyer/me", imhttp.AppHandler(handler.GetBuyerProfile), http.MethodGet)
+	buyer.handle("/buyer/me", imhttp.AppHandler(handler.UpdateBuyerProfile), http.MethodPatch)
+	buyer.handle("/buyer/shortlist", imhttp.AppHandler(handler.GetBuyerShortlist), http.MethodGet)
+	buyer.handle("/buyer/shortlist", imhttp.AppHandler(handler.AddToShortlist), http.MethodPost)
+	buyer.handle("/buyer/shortlist/{entity_type}/{entity_id}", imhttp.AppHandler(handler.RemoveFromShortlist), http.MethodDelete)
+	buyer.handle("/buyer/enquiries", imhttp.AppHandler(handler.ListBuyerEnquiries), http.MethodGet)
+	buyer.handle("/buyer/comparisons", imhttp.AppHandler(handler.ListBuyerComparisons), http.MethodGet)
+	buyer.handle("/buyer/comparisons", imhttp.AppHandler(handler.CreateBuyerComparison), http.MethodPost)
+	buyer.handle("/buyer/comparisons/{comparison_id}", imhttp.AppHandler(handler.GetBuyerComparison), http.MethodGet)
+	buyer.handle("/buyer/comparisons/{comparison_id}", imhttp.AppHandler(handler.UpdateBuyerComparison), http.MethodPatch)
+	buyer.handle("/buyer/comparisons/{comparison_id}", imhttp.AppHandler(handler.DeleteBuyerComparison), http.MethodDelete)
+
+	// Listing engagement - events are public, daily rollups are for admins
+	public.handle("/events", imhttp.AppHandler(handler.IngestListingEvents), http.MethodPost)
+	analyticsRead.handle("/analytics/{entity_type}/{entity_id}", imhttp.AppHandler(handler.GetListingAnalytics), http.MethodGet)
+
+	// Notifications of the signed-in user
+	notificationRead.handle("/notifications", imhttp.AppHandler(handler.ListNotifications), http.MethodGet)
+	notificationRead.handle("/notifications/{notification_id}/read", imhttp.AppHandler(handler.MarkNotificationRead), http.MethodPost)
+
+	// Partner portal - team members get read access to their partner's listings and leads
+	partnerPortal.handle("/partner/profile", imhttp.AppHandler(handler.GetPartnerProfile), http.MethodGet)
+	partnerPortal.handle("/partner/profile", imhttp.AppHandler(handler.UpdatePartnerProfile), http.MethodPatch)
+	partnerPortal.handle("/partner/performance", imhttp.AppHandler(handler.GetListingPerformance), http.MethodGet)
+	partnerPortal.handle("/partner/leads", imhttp.AppHandler(handler.ListPartnerLeads), http.MethodGet)
+	partnerPortal.handle("/partner/properties/{property_id}/analytics", imhttp.AppHandler(handler.GetPartnerPropertyAnalytics), http.MethodGet)
+	partnerTeamManage.handle("/partner/team", imhttp.AppHandler(handler.ListTeamMembers), http.MethodGet)
+	partnerTeamManage.handle("/partner/team/invitations", imhttp.AppHandler(handler.InviteTeamMember), http.MethodPost)
+	partnerTeamManage.handle("/partner/team/{user_id}", imhttp.AppHandler(handler.RemoveTeamMember), http.MethodDelete)
 
 	// developer routes
-	Router.Handle("/v1/api/developers", imhttp.AppHandler(handler.ListDevelopers)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/developers/{developer_id}", imhttp.AppHandler(handler.GetDeveloper)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/developers/{developer_id}", imhttp.AppHandler(handler.DeleteDeveloper)).Methods(http.MethodDelete)
+	public.handle("/developers", imhttp.AppHandler(handler.ListDevelopers), http.MethodGet)
+	public.handle("/developers/{developer_id}", imhttp.AppHandler(handler.GetDeveloper), http.MethodGet)
+	developerWrite.handle("/developers/{developer_id}", imhttp.AppHandler(handler.DeleteDeveloper), http.MethodDelete)
 
 	// location routes
-	Router.Handle("/v1/api/locations", imhttp.AppHandler(handler.ListLocations)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/locations/{location_id}", imhttp.AppHandler(handler.GetLocation)).Methods(http.MethodGet)
-
-	// location internal routes
-	Router.Handle("/v1/api/internal/location", imhttp.AppHandler(handler.AddLocation)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/locations/{location_id}", imhttp.AppHandler(handler.DeleteLocation)).Methods(http.MethodDelete)
+	public.handle("/locations", imhttp.AppHandler(handler.ListLocations), http.MethodGet)
+	public.handle("/locations/{location_id}", imhttp.AppHandler(handler.GetLocation), http.MethodGet)
+	locationWrite.handle("/locations/{location_id}", imhttp.AppHandler(handler.DeleteLocation), http.MethodDelete)
+	internalLocationWrite.handle("/location", imhttp.AppHandler(handler.AddLocation), http.MethodPost)
 
 	// internal amenity routes
-	Router.Handle("/v1/api/internal/amenities", imhttp.AppHandler(handler.GetAllCategoriesWithAmenities)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/internal/category", imhttp.AppHandler(handler.AddCategory)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/internal/category/{category_name}/amenities", imhttp.AppHandler(handler.AddAmenityToCategory)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/internal/category/{category_name}/amenities/{amenity_name}", imhttp.AppHandler(handler.DeleteAmenityFromCategory)).Methods(http.MethodDelete)
-	Router.Handle("/v1/api/internal/category/{category_name}", imhttp.AppHandler(handler.DeleteCategoryWithAmenities)).Methods(http.MethodDelete)
+	internalAuthenticated.handle("/amenities", imhttp.AppHandler(handler.GetAllCategoriesWithAmenities), http.MethodGet)
+	contentWrite.handle("/category", imhttp.AppHandler(handler.AddCategory), http.MethodPost)
+	contentWrite.handle("/category/{category_name}/amenities", imhttp.AppHandler(handler.AddAmenityToCategory), http.MethodPost)
+	contentWrite.handle("/category/{category_name}/amenities/{amenity_name}", imhttp.AppHandler(handler.DeleteAmenityFromCategory), http.MethodDelete)
+	contentWrite.handle("/category/{category_name}", imhttp.AppHandler(handler.DeleteCategoryWithAmenities), http.MethodDelete)
 
-	Router.Handle("/v1/api/internal/static-site-data", imhttp.AppHandler(handler.UpdateStaticSiteData)).Methods(http.MethodPatch)
+	contentWrite.handle("/static-site-data", imhttp.AppHandler(handler.UpdateStaticSiteData), http.MethodPatch)
 
 	// blog routes
-	Router.Handle("/v1/api/blogs", imhttp.AppHandler(handler.ListBlogs)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/blogs/{blog_id}", imhttp.AppHandler(handler.GetBlog)).Methods(http.MethodGet)
+	public.handle("/blogs", imhttp.AppHandler(handler.ListBlogs), http.MethodGet)
+	public.handle("/blogs/{blog_id}", imhttp.AppHandler(handler.GetBlog), http.MethodGet)
 
 	// internal blog routes
-	Router.Handle("/v1/api/internal/blogs", imhttp.AppHandler(handler.CreateBlog)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/internal/blogs/{blog_id}", imhttp.AppHandler(handler.DeleteBlog)).Methods(http.MethodDelete)
-	Router.Handle("/v1/api/internal/blogs/{blog_id}", imhttp.AppHandler(handler.UpdateBlog)).Methods(http.MethodPatch)
+	blogPublish.handle("/blogs", imhttp.AppHandler(handler.CreateBlog), http.MethodPost)
+	blogPublish.handle("/blogs/{blog_id}", imhttp.AppHandler(handler.DeleteBlog), http.MethodDelete)
+	blogPublish.handle("/blogs/{blog_id}", imhttp.AppHandler(handler.UpdateBlog), http.MethodPatch)
 
 	// URL availability checking route
-	Router.Handle("/v1/api/internal/check-avialable-url", imhttp.AppHandler(handler.CheckURLExists)).Methods(http.MethodGet)
+	internalAuthenticated.handle("/check-avialable-url", imhttp.AppHandler(handler.CheckURLExists), http.MethodGet)
 
 	// lead routes - public endpoints for lead creation and OTP operations
-	Router.Handle("/v1/api/leads/send-otp", imhttp.AppHandler(handler.CreateLeadWithOTP)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/leads", imhttp.AppHandler(handler.CreateLead)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/leads/validate-otp", imhttp.AppHandler(handler.ValidateOTP)).Methods(http.MethodPatch)
-	Router.Handle("/v1/api/leads/resend-otp", imhttp.AppHandler(handler.ResendOTP)).Methods(http.MethodPatch)
+	public.handle("/leads/send-otp", imhttp.AppHandler(handler.CreateLeadWithOTP), http.MethodPost)
+	public.handle("/leads", imhttp.AppHandler(handler.CreateLead), http.MethodPost)
+	public.handle("/leads/validate-otp", imhttp.AppHandler(handler.ValidateOTP), http.MethodPatch)
+	public.handle("/leads/resend-otp", imhttp.AppHandler(handler.ResendOTP), http.MethodPatch)
 
-	// Protected lead routes - business partners, dm, and superadmin can access lead data
-	Router.Handle("/v1/api/leads/get/by/{id}", middleware.RequireLeadAccess(imhttp.AppHandler(handler.GetLeadByID))).Methods(http.MethodGet)
-	Router.Handle("/v1/api/leads", middleware.RequireLeadAccess(imhttp.AppHandler(handler.GetAllLeads))).Methods(http.MethodGet)
+	// Protected lead routes - lead.read is scoped to own listings unless the user has lead.read.all
+	leadRead.handle("/leads/get/by/{id}", imhttp.AppHandler(handler.GetLeadByID), http.MethodGet)
+	leadRead.handle("/leads", imhttp.AppHandler(handler.GetAllLeads), http.MethodGet)
 
 	//content routes
-	Router.Handle("/v1/api/content/test/{url}", imhttp.AppHandler(handler.GetProjectSEOContent)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/content/text", imhttp.AppHandler(handler.GetPropertySEOContent)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/content/blog/{slug}", imhttp.AppHandler(handler.GetBlogSEOContent)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/content/text/html", imhttp.AppHandler(handler.GetHTMLContent)).Methods(http.MethodGet)
+	public.handle("/content/test/{url}", imhttp.AppHandler(handler.GetProjectSEOContent), http.MethodGet)
+	public.handle("/content/text", imhttp.AppHandler(handler.GetPropertySEOContent), http.MethodGet)
+	public.handle("/content/blog/{slug}", imhttp.AppHandler(handler.GetBlogSEOContent), http.MethodGet)
+	public.handle("/content/text/html", imhttp.AppHandler(handler.GetHTMLContent), http.MethodGet)
 
 	// generic search routes
-	Router.Handle("/v1/api/s/{slug}", imhttp.AppHandler(handler.GetCustomSearchPage)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/links", imhttp.AppHandler(handler.GetLinks)).Methods(http.MethodGet)
+	public.handle("/s/{slug}", imhttp.AppHandler(handler.GetCustomSearchPage), http.MethodGet)
+	public.handle("/links", imhttp.AppHandler(handler.GetLinks), http.MethodGet)
 
 	// internal route for generic search page
-	Router.Handle("/v1/api/internal/custom-search-page", imhttp.AppHandler(handler.GetAllCustomSearchPages)).Methods(http.MethodGet)
-	Router.Handle("/v1/api/internal/custom-search-page", imhttp.AppHandler(handler.AddCustomSearchPage)).Methods(http.MethodPost)
-	Router.Handle("/v1/api/internal/custom-search-page/{id}", imhttp.AppHandler(handler.UpdateCustomSearchPage)).Methods(http.MethodPatch)
-	Router.Handle("/v1/api/internal/custom-search-page/{id}", imhttp.AppHandler(handler.DeleteCustomSearchPage)).Methods(http.MethodDelete)
+	contentWrite.handle("/custom-search-page", imhttp.AppHandler(handler.GetAllCustomSearchPages), http.MethodGet)
+	contentWrite.handle("/custom-search-page", imhttp.AppHandler(handler.AddCustomSearchPage), http.MethodPost)
+	contentWrite.handle("/custom-search-page/{id}", imhttp.AppHandler(handler.UpdateCustomSearchPage), http.MethodPatch)
+	contentWrite.handle("/custom-search-page/{id}", imhttp.AppHandler(handler.DeleteCustomSearchPage), http.MethodDelete)
+
+	// Role definitions - edit which permissions each role grants
+	roleManage.handle("/permissions", imhttp.AppHandler(handler.ListPermissions), http.MethodGet)
+	roleManage.handle("/roles", imhttp.AppHandler(handler.ListRoles), http.MethodGet)
+	roleManage.handle("/roles/{role}", imhttp.AppHandler(handler.UpdateRole), http.MethodPut)
+
+	// API keys
+	apiKeyManage.handle("/api-keys", imhttp.AppHandler(handler.ListAPIKeys), http.MethodGet)
+	apiKeyManage.handle("/api-keys", imhttp.AppHandler(handler.CreateAPIKey), http.MethodPost)
+	apiKeyManage.handle("/api-keys/{api_key_id}", imhttp.AppHandler(handler.RevokeAPIKey), http.MethodDelete)
+
+	// Audit log - filter by actor_user_id, entity_type, entity_id, action, from and to
+	auditRead.handle("/audit-logs", imhttp.AppHandler(handler.ListAuditLogs), http.MethodGet)
+	auditRead.handle("/audit-logs/export", http.HandlerFunc(handler.ExportAuditLogs), http.MethodGet)
+
+	// User management
+	userManage.handle("/users", imhttp.AppHandler(handler.ListUsers), http.MethodGet)
+	userManage.handle("/users", imhttp.AppHandler(handler.CreateUser), http.MethodPost)
+	userManage.handle("/users/{user_id}", imhttp.AppHandler(handler.GetUser), http.MethodGet)
+	userManage.handle("/users/{user_id}", imhttp.AppHandler(handler.UpdateUser), http.MethodPatch)
+	userManage.handle("/users/{user_id}/role", imhttp.AppHandler(handler.ChangeUserRole), http.MethodPut)
+	userManage.handle("/users/{user_id}/deactivate", imhttp.AppHandler(handler.DeactivateUser), http.MethodPost)
+	userManage.handle("/users/{user_id}/reactivate", imhttp.AppHandler(handler.ReactivateUser), http.MethodPost)
+	userManage.handle("/users/{user_id}/password", imhttp.AppHandler(handler.ResetUserPassword), http.MethodPost)
+	userManage.handle("/users/{user_id}/2fa", imhttp.AppHandler(handler.ResetUserTwoFactor), http.MethodDelete)
+	userManage.handle("/users/{user_id}/unlock", imhttp.AppHandler(handler.UnlockUser), http.MethodPost)
+	userManage.handle("/users/{user_id}/logins", imhttp.AppHandler(handler.ListLoginHistory), http.MethodGet)
+
+	// Sessions of a user, one per refresh token family
+	userManage.handle("/users/{user_id}/sessions", imhttp.AppHandler(handler.ListUserSessions), http.MethodGet)
+	userManage.handle("/users/{user_id}/sessions/{session_id}", imhttp.AppHandler(handler.RevokeUserSession), http.MethodDelete)
 
 	// Catch-all route for React app - must be last to handle all non-API routes
-	//Router.PathPrefix("/").HandlerFunc(serveReactApp) // Proxy to local dev server
-	Router.PathPrefix("/").HandlerFunc(serveStaticFiles) // Serve static files from memory or build directory
+	site := newRouteGroup(Router, PolicyPublic)
+	site.handle("/.well-known/jwks.json", http.HandlerFunc(handlers.JWKS), http.MethodGet)
+	//site.handlePrefix("/", serveReactApp) // Proxy to local dev server
+	site.handlePrefix("/", serveStaticFiles) // Serve static files from memory or build directory
+
+	// Refuse to start with an internal or mutating route that is open by accident
+	if err := CheckRoutePolicies(Router); err != nil {
+		panic(err)
+	}
 }
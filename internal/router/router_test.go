@@ -0,0 +1,364 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/VI-IM/im_backend_go/internal/application"
+	"github.com/VI-IM/im_backend_go/internal/auth"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/handlers"
+	"github.com/VI-IM/im_backend_go/request"
+	"github.com/VI-IM/im_backend_go/response"
+	imhttp "github.com/VI-IM/im_backend_go/shared"
+	"github.com/gorilla/mux"
+)
+
+// expectedPolicies is the access policy of every route, keyed by "METHOD path".
+// Adding a route means adding it here, so every new endpoint gets a conscious decision.
+var expectedPolicies = map[string]string{
+	"GET /.well-known/jwks.json":                                                "public",
+	"POST /v1/api/auth/email/verification":                                      "authenticated",
+	"POST /v1/api/auth/email/verify":                                            "public",
+	"GET /v1/api/auth/2fa":                                                      "authenticated",
+	"POST /v1/api/auth/2fa/challenge/setup":                                     "public",
+	"POST /v1/api/auth/2fa/challenge/verify":                                    "public",
+	"POST /v1/api/auth/2fa/disable":                                             "authenticated",
+	"POST /v1/api/auth/2fa/enable":                                              "authenticated",
+	"POST /v1/api/auth/2fa/recovery-codes":                                      "authenticated",
+	"POST /v1/api/auth/2fa/setup":                                               "authenticated",
+	"POST /v1/api/auth/generate-token":                                          "public",
+	"POST /v1/api/auth/invitations/accept":                                      "public",
+	"POST /v1/api/auth/logout":                                                  "public",
+	"POST /v1/api/auth/logout-all":                                              "authenticated",
+	"POST /v1/api/auth/password/forgot":                                         "public",
+	"POST /v1/api/auth/password/reset":                                          "public",
+	"POST /v1/api/auth/refresh-token":                                           "public",
+	"POST /v1/api/auth/signup":                                                  "public",
+	"GET /v1/api/blogs":                                                         "public",
+	"GET /v1/api/blogs/{blog_id}":                                               "public",
+	"POST /v1/api/buyer/auth/otp":                                               "public",
+	"POST /v1/api/buyer/auth/verify":                                            "public",
+	"GET /v1/api/buyer/comparisons":                                             "buyer",
+	"POST /v1/api/buyer/comparisons":                                            "buyer",
+	"GET /v1/api/buyer/comparisons/{comparison_id}":                             "buyer",
+	"PATCH /v1/api/buyer/comparisons/{comparison_id}":                           "buyer",
+	"DELETE /v1/api/buyer/comparisons/{comparison_id}":                          "buyer",
+	"GET /v1/api/buyer/enquiries":                                               "buyer",
+	"GET /v1/api/buyer/me":                                                      "buyer",
+	"PATCH /v1/api/buyer/me":                                                    "buyer",
+	"GET /v1/api/buyer/shortlist":                                               "buyer",
+	"POST /v1/api/buyer/shortlist":                                              "buyer",
+	"DELETE /v1/api/buyer/shortlist/{entity_type}/{entity_id}":                  "buyer",
+	"GET /v1/api/content/blog/{slug}":                                           "public",
+	"GET /v1/api/content/test/{url}":                                            "public",
+	"GET /v1/api/content/text":                                                  "public",
+	"GET /v1/api/content/text/html":                                             "public",
+	"GET /v1/api/developers":                                                    "public",
+	"GET /v1/api/developers/{developer_id}":                                     "public",
+	"DELETE /v1/api/developers/{developer_id}":                                  "developer.write",
+	"POST /v1/api/events":                                                       "public",
+	"POST /v1/api/finance/affordability":                                        "public",
+	"POST /v1/api/finance/emi":                                                  "public",
+	"POST /v1/api/finance/purchase-charges":                                     "public",
+	"GET /v1/api/finance/stamp-duty-rates":                                      "public",
+	"GET /v1/api/health":                                                        "public",
+	"GET /v1/api/internal/admin/dashboard/properties":                           "property.write",
+	"GET /v1/api/internal/amenities":                                            "authenticated",
+	"GET /v1/api/internal/analytics/{entity_type}/{entity_id}":                  "analytics.read",
+	"POST /v1/api/internal/blogs":                                               "blog.publish",
+	"PATCH /v1/api/internal/blogs/{blog_id}":                                    "blog.publish",
+	"DELETE /v1/api/internal/blogs/{blog_id}":                                   "blog.publish",
+	"POST /v1/api/internal/category":                                            "content.write",
+	"DELETE /v1/api/internal/category/{category_name}":                          "content.write",
+	"POST /v1/api/internal/category/{category_name}/amenities":                  "content.write",
+	"DELETE /v1/api/internal/category/{category_name}/amenities/{amenity_name}": "content.write",
+	"GET /v1/api/internal/check-avialable-url":                                  "authenticated",
+	"GET /v1/api/internal/custom-search-page":                                   "content.write",
+	"POST /v1/api/internal/custom-search-page":                                  "content.write",
+	"PATCH /v1/api/internal/custom-search-page/{id}":                            "content.write",
+	"DELETE /v1/api/internal/custom-search-page/{id}":                           "content.write",
+	"POST /v1/api/internal/deletions/purge":                                     "system.maintain",
+	"POST /v1/api/internal/location":                                            "location.write",
+	"GET /v1/api/internal/moderation/properties":                                "property.moderate",
+	"GET /v1/api/internal/moderation/properties/{revision_id}":                  "property.moderate",
+	"POST /v1/api/internal/moderation/properties/{revision_id}/approve":         "property.moderate",
+	"POST /v1/api/internal/moderation/properties/{revision_id}/reject":          "property.moderate",
+	"GET /v1/api/internal/notifications":                                        "notification.read",
+	"POST /v1/api/internal/notifications/{notification_id}/read":                "notification.read",
+	"GET /v1/api/internal/partner/leads":                                        "partner.portal",
+	"GET /v1/api/internal/partner/performance":                                  "partner.portal",
+	"GET /v1/api/internal/partner/profile":                                      "partner.portal",
+	"PATCH /v1/api/internal/partner/profile":                                    "partner.portal",
+	"GET /v1/api/internal/partner/properties/{property_id}/analytics":           "partner.portal",
+	"GET /v1/api/internal/partner/team":                                         "partner.team.manage",
+	"POST /v1/api/internal/partner/team/invitations":                            "partner.team.manage",
+	"DELETE /v1/api/internal/partner/team/{user_id}":                            "partner.team.manage",
+	"GET /v1/api/internal/permissions":                                          "role.manage",
+	"POST /v1/api/internal/projects":                                            "project.write",
+	"GET /v1/api/internal/projects/export":                                      "project.write",
+	"GET /v1/api/internal/projects/filters":                                     "authenticated",
+	"POST /v1/api/internal/projects/import":                                     "project.write",
+	"PATCH /v1/api/internal/projects/{project_id}":                              "project.write",
+	"DELETE /v1/api/internal/projects/{project_id}":                             "project.delete",
+	"GET /v1/api/internal/projects/{project_id}/deletion-plan":                  "project.delete",
+	"POST /v1/api/internal/projects/{project_id}/rera":                          "project.write",
+	"POST /v1/api/internal/projects/{project_id}/restore":                       "project.write",
+	"POST /v1/api/internal/properties":                                          "property.write",
+	"PATCH /v1/api/internal/properties/{property_id}":                           "property.write",
+	"DELETE /v1/api/internal/properties/{property_id}":                          "property.write",
+	"GET /v1/api/internal/properties/{property_id}/deletion-plan":               "property.write",
+	"POST /v1/api/internal/properties/{property_id}/restore":                    "property.write",
+	"GET /v1/api/internal/redirects":                                            "content.write",
+	"POST /v1/api/internal/redirects":                                           "content.write",
+	"PATCH /v1/api/internal/redirects/{redirect_id}":                            "content.write",
+	"DELETE /v1/api/internal/redirects/{redirect_id}":                           "content.write",
+	"GET /v1/api/internal/rera/expiring":                                        "project.write",
+	"POST /v1/api/internal/rera/refresh-status":                                 "project.write",
+	"PATCH /v1/api/internal/rera/{rera_id}":                                     "project.write",
+	"DELETE /v1/api/internal/rera/{rera_id}":                                    "project.write",
+	"GET /v1/api/internal/roles":                                                "role.manage",
+	"PUT /v1/api/internal/roles/{role}":                                         "role.manage",
+	"GET /v1/api/internal/slugs":                                                "content.write",
+	"POST /v1/api/internal/slugs/sync":                                          "system.maintain",
+	"DELETE /v1/api/internal/slugs/{slug}":                                      "content.write",
+	"PATCH /v1/api/internal/static-site-data":                                   "content.write",
+	"GET /v1/api/internal/users":                                                "user.manage",
+	"POST /v1/api/internal/users":                                               "user.manage",
+	"GET /v1/api/internal/users/{user_id}":                                      "user.manage",
+	"PATCH /v1/api/internal/users/{user_id}":                                    "user.manage",
+	"POST /v1/api/internal/users/{user_id}/deactivate":                          "user.manage",
+	"POST /v1/api/internal/users/{user_id}/password":                            "user.manage",
+	"DELETE /v1/api/internal/users/{user_id}/2fa":                               "user.manage",
+	"GET /v1/api/internal/api-keys":                                             "api_key.manage",
+	"POST /v1/api/internal/api-keys":                                            "api_key.manage",
+	"DELETE /v1/api/internal/api-keys/{api_key_id}":                             "api_key.manage",
+	"GET /v1/api/internal/audit-logs":                                           "audit.read",
+	"GET /v1/api/internal/audit-logs/export":                                    "audit.read",
+	"POST /v1/api/internal/users/{user_id}/unlock":                              "user.manage",
+	"GET /v1/api/internal/users/{user_id}/logins":                               "user.manage",
+	"POST /v1/api/internal/users/{user_id}/reactivate":                          "user.manage",
+	"PUT /v1/api/internal/users/{user_id}/role":                                 "user.manage",
+	"GET /v1/api/internal/users/{user_id}/sessions":                             "user.manage",
+	"DELETE /v1/api/internal/users/{user_id}/sessions/{session_id}":             "user.manage",
+	"GET /v1/api/leads":                                                         "lead.read",
+	"POST /v1/api/leads":                                                        "public",
+	"GET /v1/api/leads/get/by/{id}":                                             "lead.read",
+	"PATCH /v1/api/leads/resend-otp":                                            "public",
+	"POST /v1/api/leads/send-otp":                                               "public",
+	"PATCH /v1/api/leads/validate-otp":                                          "public",
+	"GET /v1/api/links":                                                         "public",
+	"GET /v1/api/locations":                                                     "public",
+	"GET /v1/api/locations/{location_id}":                                       "public",
+	"DELETE /v1/api/locations/{location_id}":                                    "location.write",
+	"GET /v1/api/projects":                                                      "public",
+	"POST /v1/api/projects/compare":                                             "public",
+	"GET /v1/api/projects/names":                                                "authenticated",
+	"GET /v1/api/projects/trending":                                             "public",
+	"GET /v1/api/projects/{project_id}":                                         "public",
+	"GET /v1/api/projects/{project_id}/brochure":                                "public",
+	"GET /v1/api/projects/{project_id}/price-history":                           "public",
+	"GET /v1/api/projects/{project_id}/properties":                              "public",
+	"GET /v1/api/projects/{project_id}/rera":                                    "public",
+	"GET /v1/api/projects/{project_id}/similar":                                 "public",
+	"GET /v1/api/properties":                                                    "public",
+	"GET /v1/api/properties/slug/{slug}":                                        "public",
+	"GET /v1/api/properties/{property_id}":                                      "public",
+	"GET /v1/api/s/projects/{slug}":                                             "public",
+	"GET /v1/api/s/properties/{slug}":                                           "public",
+	"GET /v1/api/s/{slug}":                                                      "public",
+	"POST /v1/api/saved-searches":                                               "public",
+	"GET /v1/api/saved-searches/unsubscribe":                                    "public",
+	"POST /v1/api/saved-searches/unsubscribe":                                   "public",
+	"POST /v1/api/saved-searches/{saved_search_id}/verify":                      "public",
+	"POST /v1/api/upload":                                                       "media.upload",
+}
+
+var initOnce sync.Once
+
+// initRouter registers the routes on the shared Router once for all tests.
+func initRouter() {
+	initOnce.Do(func() { Init(nil) })
+}
+
+func routeKeys(t *testing.T) map[string]*mux.Route {
+	t.Helper()
+	routes := make(map[string]*mux.Route)
+	err := Router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil
+		}
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil // the static site catch-all
+		}
+		for _, method := range methods {
+			routes[method+" "+path] = route
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk routes: %v", err)
+	}
+	return routes
+}
+
+func TestRoutePolicies(t *testing.T) {
+	initRouter()
+	routes := routeKeys(t)
+
+	keys := make([]string, 0, len(routes))
+	for key := range routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		expected, ok := expectedPolicies[key]
+		if !ok {
+			t.Errorf("%s is not listed in expectedPolicies", key)
+			continue
+		}
+		if actual := routePolicies[routes[key]].Name; actual != expected {
+			t.Errorf("%s has policy %q, want %q", key, actual, expected)
+		}
+	}
+	for key := range expectedPolicies {
+		if _, ok := routes[key]; !ok {
+			t.Errorf("%s is listed in expectedPolicies but not registered", key)
+		}
+	}
+
+	if err := CheckRoutePolicies(Router); err != nil {
+		t.Errorf("CheckRoutePolicies: %v", err)
+	}
+}
+
+var pathVariable = regexp.MustCompile(`\{[^}]+\}`)
+
+func TestProtectedRoutesRequireCredentials(t *testing.T) {
+	initRouter()
+
+	for key, route := range routeKeys(t) {
+		if routePolicies[route].Middleware == nil {
+			continue
+		}
+		methods, _ := route.GetMethods()
+		path, _ := route.GetPathTemplate()
+		for _, method := range methods {
+			req := httptest.NewRequest(method, pathVariable.ReplaceAllString(path, "test"), nil)
+			rec := httptest.NewRecorder()
+			Router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("%s without credentials returned %d, want %d", key, rec.Code, http.StatusUnauthorized)
+			}
+		}
+	}
+}
+
+func TestCheckRoutePoliciesRejectsUnguardedRoutes(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/api/internal/projects", ok).Methods(http.MethodGet)
+	if err := CheckRoutePolicies(router); err == nil {
+		t.Error("internal route without a policy was accepted")
+	}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/v1/api/leads", ok).Methods(http.MethodPost)
+	if err := CheckRoutePolicies(router); err == nil {
+		t.Error("mutating route without a policy was accepted")
+	}
+
+	router = mux.NewRouter()
+	newRouteGroup(router, PolicyPublic).handle("/v1/api/internal/projects", http.HandlerFunc(ok), http.MethodGet)
+	if err := CheckRoutePolicies(router); err == nil {
+		t.Error("public internal route was accepted")
+	}
+
+	router = mux.NewRouter()
+	router.HandleFunc("/v1/api/projects", ok).Methods(http.MethodGet)
+	newRouteGroup(router, PolicyPermission("project.write")).handle("/v1/api/internal/projects", http.HandlerFunc(ok), http.MethodPost)
+	if err := CheckRoutePolicies(router); err != nil {
+		t.Errorf("guarded routes were rejected: %v", err)
+	}
+}
+
+// leadsApp records the lead query the handler makes. Calling anything else panics.
+type leadsApp struct {
+	application.ApplicationInterface
+	req *request.GetLeadsRequest
+}
+
+func (a *leadsApp) GetAllLeads(_ context.Context, req *request.GetLeadsRequest) (*response.DateLeadsData, *imhttp.CustomError) {
+	a.req = req
+	return &response.DateLeadsData{}, nil
+}
+
+func TestGetAllLeadsIsScopedWithoutReadAll(t *testing.T) {
+	tests := []struct {
+		name        string
+		claims      *auth.Claims
+		permissions map[string]bool
+		wantStatus  int
+		wantOwner   string
+	}{
+		{
+			name:        "partner sees only their own leads",
+			claims:      &auth.Claims{UserID: "partner-1", Role: domain.RoleBusinessPartner},
+			permissions: map[string]bool{domain.PermissionLeadRead: true},
+			wantStatus:  http.StatusOK,
+			wantOwner:   "partner-1",
+		},
+		{
+			name:        "lead.read.all sees every lead",
+			claims:      &auth.Claims{UserID: "admin-1", Role: domain.RoleSuperAdmin},
+			permissions: map[string]bool{domain.PermissionLeadRead: true, domain.PermissionLeadReadAll: true},
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "API key without lead.read.all is refused",
+			claims:      &auth.Claims{Role: domain.RoleAPIKey, APIKeyID: "key-1"},
+			permissions: map[string]bool{domain.PermissionLeadRead: true},
+			wantStatus:  http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &leadsApp{}
+			h := handlers.NewHandler(app)
+
+			ctx := context.WithValue(context.Background(), "user_claims", tt.claims)
+			ctx = context.WithValue(ctx, "user_permissions", tt.permissions)
+			req := httptest.NewRequest(http.MethodGet, "/v1/api/leads", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+			imhttp.AppHandler(h.GetAllLeads).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("returned %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if app.req != nil {
+					t.Error("leads were queried for a refused request")
+				}
+				return
+			}
+			if app.req == nil {
+				t.Fatal("leads were not queried")
+			}
+			if app.req.OwnerUserID != tt.wantOwner {
+				t.Errorf("leads scoped to %q, want %q", app.req.OwnerUserID, tt.wantOwner)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/VI-IM/im_backend_go/internal/application"
 	"github.com/VI-IM/im_backend_go/internal/config"
+	"github.com/VI-IM/im_backend_go/internal/domain"
 	"github.com/VI-IM/im_backend_go/response"
 	"github.com/VI-IM/im_backend_go/templates"
 )
@@ -30,6 +31,7 @@ type PropertySEOData struct {
 	Property      *response.Property
 	MetaInfo      PropertyMetaInfo
 	PropertyImage string
+	PropertyURL   string
 	BaseURL       string
 }
 
@@ -88,8 +90,8 @@ func getBlogURL(slug string) string {
 	return fmt.Sprintf("%s/blogs/%s", getBaseURL(), slug)
 }
 
-func getPropertyURL(slug string) string {
-	return fmt.Sprintf("%s/propertyforsale/%s", getBaseURL(), slug)
+func getPropertyURL(listingType, slug string) string {
+	return getBaseURL() + domain.PropertyListingPath(listingType, slug)
 }
 
 func getDefaultImage(imageType string) string {
@@ -152,15 +154,17 @@ func handleSEORoute(w http.ResponseWriter, r *http.Request, app application.Appl
 		}
 	}
 
-	// Handle property routes: /propertyforsale/<property_slug>
-	if strings.HasPrefix(path, "/propertyforsale/") {
-		propertySlug := strings.TrimPrefix(path, "/propertyforsale/")
-		if propertySlug != "" && app != nil {
-			property, err := app.GetPropertyBySlug(r.Context(), propertySlug)
-			if err == nil && property != nil {
-				generatePropertySEOHTML(w, property)
+	// Handle property routes: /propertyforsale/<property_slug> and /propertyforrent/<property_slug>
+	if propertySlug, ok := domain.SplitPropertyPath(path); ok && propertySlug != "" && app != nil {
+		property, err := app.GetPropertyBySlug(r.Context(), propertySlug)
+		if err == nil && property != nil {
+			// Each listing type is served from one route family only
+			if canonicalPath := domain.PropertyListingPath(property.ListingType, property.Slug); canonicalPath != path {
+				http.Redirect(w, r, canonicalPath, http.StatusMovedPermanently)
 				return true
 			}
+			generatePropertySEOHTML(w, property)
+			return true
 		}
 	}
 
@@ -266,18 +270,23 @@ func generatePropertySEOHTML(w http.ResponseWriter, property *response.Property)
 			}
 			return getDefaultImage("property")
 		}(),
-		BaseURL: getBaseURL(),
+		PropertyURL: getPropertyURL(property.ListingType, property.Slug),
+		BaseURL:     getBaseURL(),
 	}
 
 	// Provide defaults if SEO meta is empty
+	purpose := "Sale"
+	if domain.IsRentalListing(property.ListingType) {
+		purpose = "Rent"
+	}
 	if templateData.MetaInfo.Title == "" {
-		templateData.MetaInfo.Title = fmt.Sprintf("%s - Property for Sale", property.Name)
+		templateData.MetaInfo.Title = fmt.Sprintf("%s - Property for %s", property.Name, purpose)
 	}
 	if templateData.MetaInfo.Description == "" {
-		templateData.MetaInfo.Description = fmt.Sprintf("Property for sale - %s", property.Name)
+		templateData.MetaInfo.Description = fmt.Sprintf("Property for %s - %s", strings.ToLower(purpose), property.Name)
 	}
 	if templateData.MetaInfo.Keywords == "" {
-		templateData.MetaInfo.Keywords = fmt.Sprintf("%s, property for sale, real estate", property.Name)
+		templateData.MetaInfo.Keywords = fmt.Sprintf("%s, property for %s, real estate", property.Name, strings.ToLower(purpose))
 	}
 
 	// Set content type and render template
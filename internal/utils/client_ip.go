@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/internal/config"
+)
+
+// ClientIP returns the address of the client. X-Forwarded-For is set by whoever sends
+// the request, so it is only read when the connection comes from a trusted proxy, and
+// then the right-most hop that is not a trusted proxy is the client.
+func ClientIP(r *http.Request) string {
+	remote := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		remote = host
+	}
+
+	trusted := trustedProxies()
+	if !isTrustedProxy(remote, trusted) {
+		return remote
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if net.ParseIP(hop) == nil {
+			// Anything left of a malformed hop cannot be attributed to a trusted proxy
+			return client
+		}
+		client = hop
+		if !isTrustedProxy(hop, trusted) {
+			return hop
+		}
+	}
+	return client
+}
+
+// trustedProxies parses TRUSTED_PROXIES. Entries that are neither an address nor a CIDR
+// range are ignored.
+func trustedProxies() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, entry := range config.GetConfig().Server.TrustedProxies {
+		entry = strings.TrimSpace(entry)
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			networks = append(networks, network)
+			continue
+		}
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		}
+	}
+	return networks
+}
+
+func isTrustedProxy(address string, trusted []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,13 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 func HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -13,3 +20,40 @@ func HashPassword(password string) (string, error) {
 func ComparePassword(hashedPassword, password string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) == nil
 }
+
+// maxPasswordBytes is the longest password bcrypt hashes in full.
+const maxPasswordBytes = 72
+
+var commonPasswords = map[string]bool{
+	"password": true, "password1": true, "password123": true, "12345678": true,
+	"123456789": true, "1234567890": true, "qwerty123": true, "admin123": true,
+	"welcome1": true, "iloveyou1": true, "abc12345": true, "letmein1": true,
+}
+
+// ValidatePasswordStrength rejects passwords that are too short, too long for bcrypt,
+// commonly used, or missing either letters or digits.
+func ValidatePasswordStrength(password string, minLength int) error {
+	if len([]rune(password)) < minLength {
+		return fmt.Errorf("password must be at least %d characters", minLength)
+	}
+	if len(password) > maxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes", maxPasswordBytes)
+	}
+	if commonPasswords[strings.ToLower(password)] {
+		return errors.New("password is too common")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return errors.New("password must contain both letters and digits")
+	}
+	return nil
+}
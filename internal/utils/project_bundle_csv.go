@@ -0,0 +1,387 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+	"github.com/VI-IM/im_backend_go/internal/domain/enums"
+)
+
+// Files that make up a CSV project bundle.
+const (
+	BundleDevelopersFile     = "developers.csv"
+	BundleProjectsFile       = "projects.csv"
+	BundleConfigurationsFile = "configurations.csv"
+	BundleFloorPlansFile     = "floor_plans.csv"
+	BundleFaqsFile           = "faqs.csv"
+)
+
+// listSeparator joins multi-valued cells such as image URLs.
+const listSeparator = "|"
+
+var (
+	developerColumns = []string{"identifier", "name", "legal_name", "established_year", "phone", "address", "logo", "alt_logo", "about", "overview", "disclaimer"}
+	projectColumns   = []string{
+		"slug", "name", "project_type", "status", "description", "min_price", "max_price", "developer",
+		"locality", "city", "state", "pincode", "phone_number",
+		"short_address", "latitude", "longitude", "google_map_link", "location_para",
+		"launch_date", "possession_date",
+		"meta_title", "meta_description", "meta_keywords", "meta_project_schema",
+		"is_featured", "is_premium", "is_priority", "images", "web_cards_json",
+	}
+	configurationColumns = []string{"project_slug", "configuration_name", "size", "price"}
+	floorPlanColumns     = []string{"project_slug", "title", "flat_type", "price", "is_sold_out", "building_area", "image"}
+	faqColumns           = []string{"project_slug", "question", "answer"}
+)
+
+// EncodeProjectBundleCSV writes a bundle as a zip archive of CSV files. Configurations,
+// floor plans and FAQs get their own files; the rest of the web cards is kept as JSON
+// in projects.csv so that an export can be imported back without losing data.
+func EncodeProjectBundleCSV(bundle *domain.ProjectBundle) ([]byte, error) {
+	developers := [][]string{developerColumns}
+	for _, d := range bundle.Developers {
+		developers = append(developers, []string{
+			d.Identifier, d.Name, d.LegalName, strconv.Itoa(d.EstablishedYear),
+			d.MediaContent.Phone, d.MediaContent.DeveloperAddress, d.MediaContent.DeveloperLogo, d.MediaContent.AltDeveloperLogo,
+			d.MediaContent.About, d.MediaContent.Overview, d.MediaContent.Disclaimer,
+		})
+	}
+
+	projects := [][]string{projectColumns}
+	configurations := [][]string{configurationColumns}
+	floorPlans := [][]string{floorPlanColumns}
+	faqs := [][]string{faqColumns}
+
+	for _, p := range bundle.Projects {
+		webCards := p.WebCards
+		webCards.Images = nil
+		webCards.PriceList.BHKOptionsWithPrices = nil
+		webCards.FloorPlan.Products = nil
+		webCards.Faqs = nil
+		webCardsJSON, err := json.Marshal(webCards)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", p.Slug, err)
+		}
+
+		projectSchema := ""
+		if len(p.MetaInfo.ProjectSchema) > 0 {
+			data, err := json.Marshal(p.MetaInfo.ProjectSchema)
+			if err != nil {
+				return nil, fmt.Errorf("project %s: %w", p.Slug, err)
+			}
+			projectSchema = string(data)
+		}
+
+		projects = append(projects, []string{
+			p.Slug, p.Name, p.ProjectType, string(p.Status), p.Description, p.MinPrice, p.MaxPrice, p.Developer,
+			p.Location.LocalityName, p.Location.City, p.Location.State, p.Location.Pincode, p.Location.PhoneNumber,
+			p.LocationInfo.ShortAddress, p.LocationInfo.Latitude, p.LocationInfo.Longitude, p.LocationInfo.GoogleMapLink, p.LocationInfo.LocationPara,
+			p.TimelineInfo.ProjectLaunchDate, p.TimelineInfo.ProjectPossessionDate,
+			p.MetaInfo.Title, p.MetaInfo.Description, p.MetaInfo.Keywords, projectSchema,
+			strconv.FormatBool(p.IsFeatured), strconv.FormatBool(p.IsPremium), strconv.FormatBool(p.IsPriority),
+			strings.Join(p.WebCards.Images, listSeparator), string(webCardsJSON),
+		})
+
+		for _, c := range p.WebCards.PriceList.BHKOptionsWithPrices {
+			configurations = append(configurations, []string{p.Slug, c.ConfigurationName, c.Size, c.Price})
+		}
+		for _, f := range p.WebCards.FloorPlan.Products {
+			floorPlans = append(floorPlans, []string{p.Slug, f.Title, f.FlatType, f.Price, strconv.FormatBool(f.IsSoldOut), f.BuildingArea, f.Image})
+		}
+		for _, f := range p.WebCards.Faqs {
+			faqs = append(faqs, []string{p.Slug, f.Question, f.Answer})
+		}
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		rows [][]string
+	}{
+		{BundleDevelopersFile, developers},
+		{BundleProjectsFile, projects},
+		{BundleConfigurationsFile, configurations},
+		{BundleFloorPlansFile, floorPlans},
+		{BundleFaqsFile, faqs},
+	}
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(file.rows); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeProjectBundleCSV reads a zip archive produced by EncodeProjectBundleCSV.
+// Only projects.csv is required. Malformed cells are reported per row instead of
+// failing the whole archive; the returned error is for archives that can't be read.
+func DecodeProjectBundleCSV(data []byte) (*domain.ProjectBundle, []domain.BundleRowError, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	files := make(map[string][]map[string]string)
+	for _, f := range archive.File {
+		name := f.Name[strings.LastIndex(f.Name, "/")+1:]
+		switch name {
+		case BundleDevelopersFile, BundleProjectsFile, BundleConfigurationsFile, BundleFloorPlansFile, BundleFaqsFile:
+		default:
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		rows, err := readCSVRows(rc)
+		rc.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		files[name] = rows
+	}
+	if _, ok := files[BundleProjectsFile]; !ok {
+		return nil, nil, fmt.Errorf("%s is missing from the archive", BundleProjectsFile)
+	}
+
+	bundle := &domain.ProjectBundle{}
+	var rowErrors []domain.BundleRowError
+	addError := func(source, key, field, message string) {
+		rowErrors = append(rowErrors, domain.BundleRowError{Source: source, Key: key, Field: field, Message: message})
+	}
+
+	for i, row := range files[BundleDevelopersFile] {
+		source := rowSource(BundleDevelopersFile, i)
+		developer := domain.BundleDeveloper{
+			Identifier: row["identifier"],
+			Name:       row["name"],
+			LegalName:  row["legal_name"],
+			MediaContent: schema.DeveloperMediaContent{
+				Phone:            row["phone"],
+				DeveloperAddress: row["address"],
+				DeveloperLogo:    row["logo"],
+				AltDeveloperLogo: row["alt_logo"],
+				About:            row["about"],
+				Overview:         row["overview"],
+				Disclaimer:       row["disclaimer"],
+			},
+			Source: source,
+		}
+		if value := row["established_year"]; value != "" {
+			year, err := strconv.Atoi(value)
+			if err != nil {
+				addError(source, developer.Key(), "established_year", "must be a number")
+			}
+			developer.EstablishedYear = year
+		}
+		bundle.Developers = append(bundle.Developers, developer)
+	}
+
+	projectIndex := make(map[string]int)
+	for i, row := range files[BundleProjectsFile] {
+		source := rowSource(BundleProjectsFile, i)
+		project := domain.BundleProject{
+			Slug:        row["slug"],
+			Name:        row["name"],
+			ProjectType: row["project_type"],
+			Status:      enums.ProjectStatus(row["status"]),
+			Description: row["description"],
+			MinPrice:    row["min_price"],
+			MaxPrice:    row["max_price"],
+			Developer:   row["developer"],
+			Location: domain.BundleLocation{
+				LocalityName: row["locality"],
+				City:         row["city"],
+				State:        row["state"],
+				Pincode:      row["pincode"],
+				PhoneNumber:  row["phone_number"],
+			},
+			TimelineInfo: schema.TimelineInfo{
+				ProjectLaunchDate:     row["launch_date"],
+				ProjectPossessionDate: row["possession_date"],
+			},
+			MetaInfo: schema.SEOMeta{
+				Title:       row["meta_title"],
+				Description: row["meta_description"],
+				Keywords:    row["meta_keywords"],
+			},
+			LocationInfo: schema.LocationInfo{
+				ShortAddress:  row["short_address"],
+				Latitude:      row["latitude"],
+				Longitude:     row["longitude"],
+				GoogleMapLink: row["google_map_link"],
+				LocationPara:  row["location_para"],
+			},
+			Source: source,
+		}
+
+		if value := row["web_cards_json"]; value != "" {
+			if err := json.Unmarshal([]byte(value), &project.WebCards); err != nil {
+				addError(source, project.Slug, "web_cards_json", "invalid JSON: "+err.Error())
+			}
+		}
+		if value := row["meta_project_schema"]; value != "" {
+			if err := json.Unmarshal([]byte(value), &project.MetaInfo.ProjectSchema); err != nil {
+				addError(source, project.Slug, "meta_project_schema", "must be a JSON array of strings")
+			}
+		}
+		if value := row["images"]; value != "" {
+			project.WebCards.Images = splitList(value)
+		}
+
+		for field, target := range map[string]*bool{"is_featured": &project.IsFeatured, "is_premium": &project.IsPremium, "is_priority": &project.IsPriority} {
+			if value := row[field]; value != "" {
+				parsed, err := strconv.ParseBool(value)
+				if err != nil {
+					addError(source, project.Slug, field, "must be true or false")
+				}
+				*target = parsed
+			}
+		}
+
+		if project.Slug != "" {
+			projectIndex[project.Slug] = len(bundle.Projects)
+		}
+		bundle.Projects = append(bundle.Projects, project)
+	}
+
+	// Child files replace the matching section of the project's web cards.
+	lookup := func(file string, i int, row map[string]string) *domain.BundleProject {
+		idx, ok := projectIndex[row["project_slug"]]
+		if !ok {
+			addError(rowSource(file, i), row["project_slug"], "project_slug", "no project with this slug in "+BundleProjectsFile)
+			return nil
+		}
+		return &bundle.Projects[idx]
+	}
+
+	seen := make(map[string]bool)
+	for i, row := range files[BundleConfigurationsFile] {
+		project := lookup(BundleConfigurationsFile, i, row)
+		if project == nil {
+			continue
+		}
+		if !seen[project.Slug] {
+			project.WebCards.PriceList.BHKOptionsWithPrices = nil
+			seen[project.Slug] = true
+		}
+		project.WebCards.PriceList.BHKOptionsWithPrices = append(project.WebCards.PriceList.BHKOptionsWithPrices, schema.ProductConfiguration{
+			ConfigurationName: row["configuration_name"],
+			Size:              row["size"],
+			Price:             row["price"],
+		})
+	}
+
+	seen = make(map[string]bool)
+	for i, row := range files[BundleFloorPlansFile] {
+		project := lookup(BundleFloorPlansFile, i, row)
+		if project == nil {
+			continue
+		}
+		if !seen[project.Slug] {
+			project.WebCards.FloorPlan.Products = nil
+			seen[project.Slug] = true
+		}
+		item := schema.FloorPlanItem{
+			Title:        row["title"],
+			FlatType:     row["flat_type"],
+			Price:        row["price"],
+			BuildingArea: row["building_area"],
+			Image:        row["image"],
+		}
+		if value := row["is_sold_out"]; value != "" {
+			soldOut, err := strconv.ParseBool(value)
+			if err != nil {
+				addError(rowSource(BundleFloorPlansFile, i), project.Slug, "is_sold_out", "must be true or false")
+			}
+			item.IsSoldOut = soldOut
+		}
+		project.WebCards.FloorPlan.Products = append(project.WebCards.FloorPlan.Products, item)
+	}
+
+	seen = make(map[string]bool)
+	for i, row := range files[BundleFaqsFile] {
+		project := lookup(BundleFaqsFile, i, row)
+		if project == nil {
+			continue
+		}
+		if !seen[project.Slug] {
+			project.WebCards.Faqs = nil
+			seen[project.Slug] = true
+		}
+		project.WebCards.Faqs = append(project.WebCards.Faqs, schema.FAQ{
+			Question: row["question"],
+			Answer:   row["answer"],
+		})
+	}
+
+	return bundle, rowErrors, nil
+}
+
+// readCSVRows reads a CSV file with a header row into one map per data row.
+func readCSVRows(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+
+	var rows []map[string]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = strings.TrimSpace(record[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// rowSource names a data row the way a spreadsheet would, counting the header as line 1.
+func rowSource(file string, index int) string {
+	return fmt.Sprintf("%s:%d", file, index+2)
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, listSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,13 @@
+package request
+
+// ListAuditLogsRequest filters the audit log. From and To are dates (2006-01-02) or
+// RFC 3339 times; a date in To includes that whole day.
+type ListAuditLogsRequest struct {
+	GetAllAPIRequest
+	ActorUserID string
+	EntityType  string
+	EntityID    string
+	Action      string
+	From        string
+	To          string
+}
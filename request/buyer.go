@@ -0,0 +1,30 @@
+package request
+
+type BuyerOTPRequest struct {
+	Phone string `json:"phone" validate:"required,len=10,numeric"`
+}
+
+type VerifyBuyerOTPRequest struct {
+	Phone string `json:"phone" validate:"required,len=10,numeric"`
+	Code  string `json:"code" validate:"required,len=6"`
+}
+
+type UpdateBuyerProfileRequest struct {
+	Name  *string `json:"name"`
+	Email *string `json:"email" validate:"omitempty,email"`
+}
+
+type ShortlistRequest struct {
+	EntityType string `json:"entity_type" validate:"required,oneof=project property"`
+	EntityID   string `json:"entity_id" validate:"required"`
+}
+
+type BuyerComparisonRequest struct {
+	Name       string   `json:"name"`
+	ProjectIDs []string `json:"project_ids" validate:"required,min=2"`
+}
+
+type UpdateBuyerComparisonRequest struct {
+	Name       *string  `json:"name"`
+	ProjectIDs []string `json:"project_ids" validate:"omitempty,min=2"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type DeleteRequest struct {
+	// Cascade also deletes the live properties of a project.
+	Cascade bool `json:"cascade"`
+	// RedirectTo is where the removed page should redirect once it is purged.
+	RedirectTo string `json:"redirect_to"`
+}
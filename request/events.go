@@ -0,0 +1,18 @@
+package request
+
+type ListingEventRequest struct {
+	EntityType string `json:"entity_type" validate:"required"`
+	EntityID   string `json:"entity_id" validate:"required"`
+	EventType  string `json:"event_type" validate:"required"`
+	SessionID  string `json:"session_id"`
+	Source     string `json:"source"`
+}
+
+type IngestEventsRequest struct {
+	Events []ListingEventRequest `json:"events" validate:"required,min=1,max=50"`
+}
+
+type ListingAnalyticsRequest struct {
+	StartDate string
+	EndDate   string
+}
@@ -0,0 +1,33 @@
+package request
+
+// Finance requests take the price either directly or from a project or property. An
+// explicit price wins over the listing's.
+
+type EMIRequest struct {
+	ProjectID   string  `json:"project_id"`
+	PropertyID  string  `json:"property_id"`
+	Price       float64 `json:"price" validate:"gte=0"`
+	DownPayment float64 `json:"down_payment" validate:"gte=0"`
+	LoanAmount  float64 `json:"loan_amount" validate:"gte=0"`
+	AnnualRate  float64 `json:"annual_rate" validate:"gte=0,lte=30"`
+	TenureYears int     `json:"tenure_years" validate:"gte=0"`
+}
+
+type AffordabilityRequest struct {
+	MonthlyIncome      float64 `json:"monthly_income" validate:"required,gt=0"`
+	MonthlyObligations float64 `json:"monthly_obligations" validate:"gte=0"`
+	DownPayment        float64 `json:"down_payment" validate:"gte=0"`
+	AnnualRate         float64 `json:"annual_rate" validate:"gte=0,lte=30"`
+	TenureYears        int     `json:"tenure_years" validate:"gte=0"`
+	ProjectID          string  `json:"project_id"`
+	PropertyID         string  `json:"property_id"`
+	Price              float64 `json:"price" validate:"gte=0"`
+}
+
+type PurchaseChargesRequest struct {
+	State      string  `json:"state"`
+	ProjectID  string  `json:"project_id"`
+	PropertyID string  `json:"property_id"`
+	Price      float64 `json:"price" validate:"gte=0"`
+	Woman      bool    `json:"woman"` // some states charge women buyers a lower stamp duty
+}
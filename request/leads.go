@@ -27,4 +27,7 @@ type GetLeadsRequest struct {
 	EndDate     string   `json:"end_date"`
 	Date        string   `json:"date"`
 	Source      string   `json:"source"`
+	// OwnerUserID limits the leads to properties created by this user. It is set from
+	// the caller, never from the query.
+	OwnerUserID string `json:"-"`
 }
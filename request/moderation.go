@@ -0,0 +1,16 @@
+package request
+
+type ListPropertyRevisionsRequest struct {
+	GetAllAPIRequest
+	Status     string
+	PropertyID string
+}
+
+type ReviewPropertyRevisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ListNotificationsRequest struct {
+	GetAllAPIRequest
+	UnreadOnly bool
+}
@@ -0,0 +1,34 @@
+package request
+
+type UpdateProfileRequest struct {
+	Name             string `json:"name"`
+	PhoneNumber      string `json:"phone_number"`
+	Gender           string `json:"gender"`
+	CurrentAddress   string `json:"current_address"`
+	PermanentAddress string `json:"permanent_address"`
+	DateOfBirth      string `json:"date_of_birth"` // 2006-01-02
+}
+
+type InviteTeamMemberRequest struct {
+	Username    string `json:"username" validate:"required"`
+	Email       string `json:"email" validate:"required,email"`
+	Name        string `json:"name" validate:"required"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=6"`
+}
+
+type PartnerPerformanceRequest struct {
+	StartDate string
+	EndDate   string
+}
+
+type ListPartnerLeadsRequest struct {
+	GetAllAPIRequest
+	PropertyID string
+	StartDate  string
+	EndDate    string
+}
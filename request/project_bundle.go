@@ -0,0 +1,19 @@
+package request
+
+// Project bundle formats.
+const (
+	ProjectBundleFormatJSON = "json"
+	ProjectBundleFormatCSV  = "csv"
+)
+
+type ImportProjectsRequest struct {
+	Format string
+	Data   []byte
+	DryRun bool
+}
+
+type ExportProjectsRequest struct {
+	Format      string
+	Slugs       []string
+	DeveloperID string
+}
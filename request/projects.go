@@ -17,6 +17,7 @@ type AddProjectRequest struct {
 type UpdateProjectRequest struct {
 	ProjectID     string                 `json:"project_id" validate:"required"`
 	ProjectName   string                 `json:"project_name,omitempty"`
+	Slug          string                 `json:"slug,omitempty"`
 	Description   string                 `json:"description,omitempty"`
 	MinPrice      string                 `json:"min_price,omitempty"`
 	MaxPrice      string                 `json:"max_price,omitempty"`
@@ -41,18 +42,27 @@ type UpdatePropertyRequest struct {
 	PropertyReraInfo schema.PropertyReraInfo    `json:"property_rera_info"`
 	MetaInfo         schema.PropertyMetaInfo    `json:"meta_info"`
 	Slug             string                     `json:"slug"`
+	ListingType      string                     `json:"listing_type"`
+	AvailableFrom    string                     `json:"available_from"` // YYYY-MM-DD
 	IsFeatured       bool                       `json:"is_featured"`
 	IsDeleted        bool                       `json:"is_deleted"`
 	DeveloperID      string                     `json:"developer_id"`
 	LocationID       string                     `json:"location_id"`
 	ProjectID        string                     `json:"project_id"`
+
+	// Set by the handler for business partners, whose edits are held for review
+	Moderated         bool   `json:"-"`
+	SubmittedByUserID string `json:"-"`
 }
 
 type AddPropertyRequest struct {
 	ProjectID       string  `json:"project_id"`
 	Name            string  `json:"name"`
 	PropertyType    string  `json:"property_type"`
+	ListingType     string  `json:"listing_type"`   // defaults to primary_sale
+	AvailableFrom   string  `json:"available_from"` // YYYY-MM-DD
 	CreatedByUserID *string `json:"created_by_user_id,omitempty"`
+	Moderated       bool    `json:"-"` // set by the handler for business partners
 }
 
 type ProjectFilterRequest struct {
@@ -104,3 +114,10 @@ func (r *ProjectFilterRequest) ToMap() map[string]interface{} {
 type CompareProjectsRequest struct {
 	ProjectIDs []string `json:"project_ids" validate:"required,min=2"`
 }
+
+type ListPropertiesRequest struct {
+	GetAllAPIRequest
+	Sort          string
+	IncludeFacets bool
+	ApprovedOnly  bool // public listings hide properties awaiting moderation
+}
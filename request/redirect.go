@@ -0,0 +1,12 @@
+package request
+
+type RedirectRequest struct {
+	FromPath   string `json:"from_path" validate:"required"`
+	ToPath     string `json:"to_path" validate:"required"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+type ListRedirectsRequest struct {
+	GetAllAPIRequest
+	Search string
+}
@@ -0,0 +1,12 @@
+package request
+
+type ReraRegistrationRequest struct {
+	Phase              string `json:"phase"`
+	State              string `json:"state" validate:"required"`
+	RegistrationNumber string `json:"registration_number" validate:"required"`
+	ValidFrom          string `json:"valid_from" validate:"omitempty,datetime=2006-01-02"`
+	ValidUntil         string `json:"valid_until" validate:"omitempty,datetime=2006-01-02"`
+	Revoked            bool   `json:"revoked"`
+	QRImage            string `json:"qr_image"`
+	WebsiteLink        string `json:"website_link" validate:"omitempty,url"`
+}
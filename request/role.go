@@ -0,0 +1,20 @@
+package request
+
+import "time"
+
+type UpdateRoleRequest struct {
+	Description *string `json:"description"`
+	// Permissions is required for every role except superadmin, which always has
+	// every permission.
+	Permissions []string `json:"permissions" validate:"omitempty,dive,required"`
+	// TwoFactorRequired is left unchanged when omitted.
+	TwoFactorRequired *bool `json:"two_factor_required"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string   `json:"name" validate:"required"`
+	Permissions []string `json:"permissions" validate:"required,min=1,dive,required"`
+	// AllowedIPs holds addresses and CIDR ranges; empty allows any address.
+	AllowedIPs []string   `json:"allowed_ips"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
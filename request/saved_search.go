@@ -0,0 +1,14 @@
+package request
+
+type CreateSavedSearchRequest struct {
+	Name      string                 `json:"name"`
+	Phone     string                 `json:"phone" validate:"omitempty,len=10"`
+	Email     string                 `json:"email" validate:"omitempty,email"`
+	Channel   string                 `json:"channel" validate:"omitempty,oneof=sms email"`
+	Frequency string                 `json:"frequency" validate:"omitempty,oneof=daily weekly"`
+	Filters   map[string]interface{} `json:"filters"`
+}
+
+type VerifySavedSearchRequest struct {
+	Code string `json:"code" validate:"required,len=6"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type SimilarProjectsRequest struct {
+	Limit                int
+	ExcludeSameDeveloper *bool // nil uses the configured default
+}
@@ -0,0 +1,8 @@
+package request
+
+type TrendingProjectsRequest struct {
+	City        string
+	Locality    string
+	ProjectType string
+	Limit       int
+}
@@ -0,0 +1,38 @@
+package request
+
+type ListUsersRequest struct {
+	GetAllAPIRequest
+	Role     string
+	IsActive *bool
+	Search   string
+}
+
+type CreateUserRequest struct {
+	Username    string `json:"username" validate:"required"`
+	Email       string `json:"email" validate:"required,email"`
+	Name        string `json:"name" validate:"required"`
+	PhoneNumber string `json:"phone_number"`
+	Role        string `json:"role" validate:"required"`
+	// Password is generated and returned once when empty.
+	Password   string `json:"password" validate:"omitempty,min=6"`
+	IsVerified bool   `json:"is_verified"`
+	ParentID   *int   `json:"parent_id"`
+}
+
+type UpdateUserRequest struct {
+	Username    *string `json:"username" validate:"omitempty,min=1"`
+	Email       *string `json:"email" validate:"omitempty,email"`
+	Name        *string `json:"name" validate:"omitempty,min=1"`
+	PhoneNumber *string `json:"phone_number"`
+	IsVerified  *bool   `json:"is_verified"`
+	ParentID    *int    `json:"parent_id"`
+}
+
+type ChangeUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+type ResetUserPasswordRequest struct {
+	// Password is generated and returned once when empty.
+	Password string `json:"password" validate:"omitempty,min=6"`
+}
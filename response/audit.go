@@ -0,0 +1,45 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+)
+
+// AuditLog is one recorded change.
+type AuditLog struct {
+	ID          string                 `json:"id"`
+	ActorUserID string                 `json:"actor_user_id,omitempty"`
+	ActorRole   string                 `json:"actor_role,omitempty"`
+	APIKeyID    string                 `json:"api_key_id,omitempty"`
+	IPAddress   string                 `json:"ip_address,omitempty"`
+	Action      string                 `json:"action"`
+	EntityType  string                 `json:"entity_type"`
+	EntityID    string                 `json:"entity_id"`
+	Before      map[string]interface{} `json:"before,omitempty"`
+	After       map[string]interface{} `json:"after,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+func GetAuditLogFromEnt(l *ent.AuditLog) *AuditLog {
+	return &AuditLog{
+		ID:          l.ID,
+		ActorUserID: l.ActorUserID,
+		ActorRole:   l.ActorRole,
+		APIKeyID:    l.APIKeyID,
+		IPAddress:   l.IPAddress,
+		Action:      l.Action,
+		EntityType:  l.EntityType,
+		EntityID:    l.EntityID,
+		Before:      l.Before,
+		After:       l.After,
+		CreatedAt:   l.CreatedAt,
+	}
+}
+
+// AuditLogExport is the audit log written out as a file.
+type AuditLogExport struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
@@ -1,10 +1,25 @@
 package response
 
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+)
+
+// GenerateTokenResponse is the result of a sign-in step. When a second factor is needed
+// the tokens are empty and ChallengeToken must be answered before it expires.
 type GenerateTokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	Role         string `json:"role"`
 	Name         string `json:"name"`
 	RefreshToken string `json:"refresh_token"`
+
+	TwoFactorRequired           bool       `json:"two_factor_required,omitempty"`
+	TwoFactorEnrollmentRequired bool       `json:"two_factor_enrollment_required,omitempty"`
+	ChallengeToken              string     `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt          *time.Time `json:"challenge_expires_at,omitempty"`
+	// RecoveryCodes are returned once, when enrollment completes during sign-in.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
 
 type RefreshTokenResponse struct {
@@ -19,3 +34,65 @@ type SignupResponse struct {
 	Phone     string `json:"phone"`
 	CreatedAt string `json:"created_at"`
 }
+
+// Session is a signed-in device, identified by its refresh token family.
+type Session struct {
+	ID              string    `json:"id"`
+	UserAgent       string    `json:"user_agent,omitempty"`
+	IPAddress       string    `json:"ip_address,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+	LastRefreshedAt time.Time `json:"last_refreshed_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+func GetSessionFromEnt(t *ent.RefreshToken) *Session {
+	return &Session{
+		ID:              t.FamilyID,
+		UserAgent:       t.UserAgent,
+		IPAddress:       t.IPAddress,
+		StartedAt:       t.SessionStartedAt,
+		LastRefreshedAt: t.CreatedAt,
+		ExpiresAt:       t.ExpiresAt,
+	}
+}
+
+type TwoFactorStatus struct {
+	Enabled   bool       `json:"enabled"`
+	EnabledAt *time.Time `json:"enabled_at,omitempty"`
+	// Required is true when the user's role does not allow turning 2FA off.
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorSetup is a new TOTP secret. ProvisioningURI is meant to be shown as a QR code;
+// Secret is for entering by hand.
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodes are shown once; only their hashes are stored.
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// LoginAttempt is one entry of a user's login history.
+type LoginAttempt struct {
+	ID            string    `json:"id"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	IPAddress     string    `json:"ip_address,omitempty"`
+	UserAgent     string    `json:"user_agent,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func GetLoginAttemptFromEnt(a *ent.LoginAttempt) *LoginAttempt {
+	return &LoginAttempt{
+		ID:            a.ID,
+		Success:       a.Success,
+		FailureReason: a.FailureReason,
+		IPAddress:     a.IPAddress,
+		UserAgent:     a.UserAgent,
+		CreatedAt:     a.CreatedAt,
+	}
+}
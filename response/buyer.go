@@ -0,0 +1,111 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+type Buyer struct {
+	ID          string     `json:"id"`
+	Phone       string     `json:"phone"`
+	Name        string     `json:"name,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type BuyerLoginResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Buyer       *Buyer    `json:"buyer"`
+}
+
+type ShortlistItem struct {
+	EntityType    string    `json:"entity_type"`
+	EntityID      string    `json:"entity_id"`
+	Name          string    `json:"name,omitempty"`
+	Slug          string    `json:"slug,omitempty"`
+	Available     bool      `json:"available"`
+	ShortlistedAt time.Time `json:"shortlisted_at"`
+}
+
+// BuyerEnquiry is a lead as its submitter sees it, without internal CRM fields.
+type BuyerEnquiry struct {
+	ID           int       `json:"id"`
+	Message      string    `json:"message,omitempty"`
+	Verified     bool      `json:"verified"`
+	ProjectID    string    `json:"project_id,omitempty"`
+	ProjectName  string    `json:"project_name,omitempty"`
+	PropertyID   string    `json:"property_id,omitempty"`
+	PropertyName string    `json:"property_name,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type BuyerComparison struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name,omitempty"`
+	ProjectIDs []string  `json:"project_ids"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type BuyerComparisonDetail struct {
+	*BuyerComparison
+	Comparison *ProjectComparisonResponse `json:"comparison"`
+}
+
+func GetBuyerFromEnt(b *ent.Buyer) *Buyer {
+	return &Buyer{
+		ID:          b.ID,
+		Phone:       b.Phone,
+		Name:        b.Name,
+		Email:       b.Email,
+		LastLoginAt: b.LastLoginAt,
+		CreatedAt:   b.CreatedAt,
+	}
+}
+
+func GetShortlistItem(item domain.ShortlistItem) *ShortlistItem {
+	return &ShortlistItem{
+		EntityType:    item.EntityType,
+		EntityID:      item.EntityID,
+		Name:          item.Name,
+		Slug:          item.Slug,
+		Available:     item.Available,
+		ShortlistedAt: item.ShortlistedAt,
+	}
+}
+
+func GetBuyerEnquiryFromEnt(lead *ent.Leads) *BuyerEnquiry {
+	enquiry := &BuyerEnquiry{
+		ID:        lead.ID,
+		Message:   lead.Message,
+		Verified:  lead.OtpVerified,
+		CreatedAt: lead.CreatedAt,
+	}
+	if lead.Edges.Property != nil {
+		enquiry.PropertyID = lead.Edges.Property.ID
+		enquiry.PropertyName = lead.Edges.Property.Name
+		if lead.Edges.Property.Edges.Project != nil {
+			enquiry.ProjectID = lead.Edges.Property.Edges.Project.ID
+			enquiry.ProjectName = lead.Edges.Property.Edges.Project.Name
+		}
+	}
+	if lead.Edges.Project != nil {
+		enquiry.ProjectID = lead.Edges.Project.ID
+		enquiry.ProjectName = lead.Edges.Project.Name
+	}
+	return enquiry
+}
+
+func GetBuyerComparisonFromEnt(comparison *ent.BuyerComparison) *BuyerComparison {
+	return &BuyerComparison{
+		ID:         comparison.ID,
+		Name:       comparison.Name,
+		ProjectIDs: comparison.ProjectIds,
+		CreatedAt:  comparison.CreatedAt,
+		UpdatedAt:  comparison.UpdatedAt,
+	}
+}
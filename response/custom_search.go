@@ -2,12 +2,14 @@ package response
 
 type CheckURLExistsResponse struct {
 	Exists     bool   `json:"exists"`
-	EntityType string `json:"entity_type,omitempty"` // "blog", "project", "custom_search_page"
+	EntityType string `json:"entity_type,omitempty"` // "blog", "project", "property", "custom_search_page"
 	EntityID   string `json:"entity_id,omitempty"`
+	Retired    bool   `json:"retired,omitempty"` // a former slug that now redirects
 }
 
 type URLExistsResult struct {
 	Exists     bool
 	EntityType string
 	EntityID   string
+	Retired    bool
 }
\ No newline at end of file
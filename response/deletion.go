@@ -0,0 +1,49 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/schema"
+)
+
+type DeletionPlan struct {
+	*schema.DeletionReport
+	RequiresCascade bool      `json:"requires_cascade"`
+	Warnings        []string  `json:"warnings"`
+	RestoreUntil    time.Time `json:"restore_until"`
+}
+
+type DeletionResult struct {
+	DeletionID          string                 `json:"deletion_id"`
+	EntityType          string                 `json:"entity_type"`
+	EntityID            string                 `json:"entity_id"`
+	CascadedPropertyIDs []string               `json:"cascaded_property_ids"`
+	RedirectTo          string                 `json:"redirect_to"`
+	RestoreUntil        time.Time              `json:"restore_until"`
+	Report              *schema.DeletionReport `json:"report"`
+}
+
+type PurgeResult struct {
+	Purged    int `json:"purged"`
+	Redirects int `json:"redirects"`
+	Failed    int `json:"failed"`
+}
+
+func GetDeletionResultFromEnt(record *ent.DeletionRecord) *DeletionResult {
+	result := &DeletionResult{
+		DeletionID:          record.ID,
+		EntityType:          string(record.EntityType),
+		EntityID:            record.EntityID,
+		CascadedPropertyIDs: record.CascadedPropertyIds,
+		RedirectTo:          record.RedirectTo,
+		RestoreUntil:        record.RestoreUntil,
+	}
+	if result.CascadedPropertyIDs == nil {
+		result.CascadedPropertyIDs = []string{}
+	}
+	if record.Report.EntityID != "" {
+		result.Report = &record.Report
+	}
+	return result
+}
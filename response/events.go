@@ -0,0 +1,50 @@
+package response
+
+import "github.com/VI-IM/im_backend_go/ent"
+
+type IngestEventsResult struct {
+	Accepted int `json:"accepted"`
+	Rejected int `json:"rejected"`
+}
+
+type ListingAnalytics struct {
+	EntityType string              `json:"entity_type"`
+	EntityID   string              `json:"entity_id"`
+	StartDate  string              `json:"start_date"`
+	EndDate    string              `json:"end_date"`
+	Totals     map[string]int      `json:"totals"`
+	Daily      []*DailyEventCounts `json:"daily"`
+}
+
+type DailyEventCounts struct {
+	Date   string         `json:"date"`
+	Counts map[string]int `json:"counts"`
+}
+
+type ProjectBrochure struct {
+	ProjectID    string `json:"project_id"`
+	DownloadLink string `json:"download_link"`
+}
+
+// GetListingAnalytics groups daily rollups, ordered by date, into one entry per day.
+func GetListingAnalytics(entityType, entityID, startDate, endDate string, rollups []*ent.ListingEventRollup) *ListingAnalytics {
+	analytics := &ListingAnalytics{
+		EntityType: entityType,
+		EntityID:   entityID,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Totals:     make(map[string]int),
+		Daily:      make([]*DailyEventCounts, 0),
+	}
+
+	var day *DailyEventCounts
+	for _, rollup := range rollups {
+		if day == nil || day.Date != rollup.Date {
+			day = &DailyEventCounts{Date: rollup.Date, Counts: make(map[string]int)}
+			analytics.Daily = append(analytics.Daily, day)
+		}
+		day.Counts[rollup.EventType.String()] += rollup.Count
+		analytics.Totals[rollup.EventType.String()] += rollup.Count
+	}
+	return analytics
+}
@@ -0,0 +1,91 @@
+package response
+
+import (
+	"sort"
+
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+type LoanYear struct {
+	Year             int     `json:"year"`
+	PrincipalPaid    float64 `json:"principal_paid"`
+	InterestPaid     float64 `json:"interest_paid"`
+	ClosingPrincipal float64 `json:"closing_principal"`
+}
+
+type EMIResponse struct {
+	Price         float64    `json:"price,omitempty"`
+	DownPayment   float64    `json:"down_payment,omitempty"`
+	LoanAmount    float64    `json:"loan_amount"`
+	AnnualRate    float64    `json:"annual_rate"`
+	TenureMonths  int        `json:"tenure_months"`
+	EMI           float64    `json:"emi"`
+	TotalInterest float64    `json:"total_interest"`
+	TotalPayment  float64    `json:"total_payment"`
+	Schedule      []LoanYear `json:"schedule"`
+}
+
+type AffordabilityResponse struct {
+	MaxEMI          float64 `json:"max_emi"`
+	MaxLoan         float64 `json:"max_loan"`
+	MaxPropertyCost float64 `json:"max_property_cost"`
+	AnnualRate      float64 `json:"annual_rate"`
+	TenureMonths    int     `json:"tenure_months"`
+	// Set when a price was given or prefilled from a listing.
+	Price       float64 `json:"price,omitempty"`
+	RequiredEMI float64 `json:"required_emi,omitempty"`
+	Affordable  *bool   `json:"affordable,omitempty"`
+}
+
+type PurchaseChargesResponse struct {
+	State        string         `json:"state"`
+	Price        float64        `json:"price"`
+	StampDuty    float64        `json:"stamp_duty"`
+	Registration float64        `json:"registration"`
+	Total        float64        `json:"total"`
+	Rate         *StampDutyRate `json:"rate"`
+}
+
+type StampDutyRate struct {
+	State                 string  `json:"state"`
+	StampDutyPercent      float64 `json:"stamp_duty_percent"`
+	WomenStampDutyPercent float64 `json:"women_stamp_duty_percent,omitempty"`
+	RegistrationPercent   float64 `json:"registration_percent"`
+	RegistrationCap       float64 `json:"registration_cap,omitempty"`
+}
+
+func GetEMIResponse(summary domain.LoanSummary) *EMIResponse {
+	schedule := make([]LoanYear, 0, len(summary.Schedule))
+	for _, year := range summary.Schedule {
+		schedule = append(schedule, LoanYear(year))
+	}
+	return &EMIResponse{
+		LoanAmount:    summary.Principal,
+		AnnualRate:    summary.AnnualRate,
+		TenureMonths:  summary.TenureMonths,
+		EMI:           summary.EMI,
+		TotalInterest: summary.TotalInterest,
+		TotalPayment:  summary.TotalPayment,
+		Schedule:      schedule,
+	}
+}
+
+func GetStampDutyRate(state string, rate domain.StampDutyRate) *StampDutyRate {
+	return &StampDutyRate{
+		State:                 state,
+		StampDutyPercent:      rate.StampDutyPercent,
+		WomenStampDutyPercent: rate.WomenStampDutyPercent,
+		RegistrationPercent:   rate.RegistrationPercent,
+		RegistrationCap:       rate.RegistrationCap,
+	}
+}
+
+// GetStampDutyRates lists a stamp duty table sorted by state.
+func GetStampDutyRates(table map[string]domain.StampDutyRate) []*StampDutyRate {
+	rates := make([]*StampDutyRate, 0, len(table))
+	for state, rate := range table {
+		rates = append(rates, GetStampDutyRate(state, rate))
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].State < rates[j].State })
+	return rates
+}
@@ -0,0 +1,78 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/ent/schema"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+type PropertyRevision struct {
+	ID                string                   `json:"id"`
+	PropertyID        string                   `json:"property_id"`
+	PropertyName      string                   `json:"property_name,omitempty"`
+	Action            string                   `json:"action"`
+	Status            string                   `json:"status"`
+	SubmittedByUserID string                   `json:"submitted_by_user_id"`
+	ReviewedByUserID  string                   `json:"reviewed_by_user_id,omitempty"`
+	ReviewReason      string                   `json:"review_reason,omitempty"`
+	ReviewedAt        *time.Time               `json:"reviewed_at,omitempty"`
+	CreatedAt         time.Time                `json:"created_at"`
+	Snapshot          *schema.PropertySnapshot `json:"snapshot,omitempty"`
+	Changes           []FieldChange            `json:"changes,omitempty"`
+}
+
+// FieldChange is one value that a revision changes on the live property.
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type Notification struct {
+	ID         string     `json:"id"`
+	Kind       string     `json:"kind"`
+	Title      string     `json:"title"`
+	Message    string     `json:"message,omitempty"`
+	EntityType string     `json:"entity_type,omitempty"`
+	EntityID   string     `json:"entity_id,omitempty"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func GetPropertyRevisionFromEnt(revision *ent.PropertyRevision) *PropertyRevision {
+	return &PropertyRevision{
+		ID:                revision.ID,
+		PropertyID:        revision.PropertyID,
+		PropertyName:      revision.Snapshot.Name,
+		Action:            revision.Action.String(),
+		Status:            revision.Status.String(),
+		SubmittedByUserID: revision.SubmittedByUserID,
+		ReviewedByUserID:  revision.ReviewedByUserID,
+		ReviewReason:      revision.ReviewReason,
+		ReviewedAt:        revision.ReviewedAt,
+		CreatedAt:         revision.CreatedAt,
+	}
+}
+
+func GetFieldChanges(changes []domain.FieldChange) []FieldChange {
+	result := make([]FieldChange, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, FieldChange{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return result
+}
+
+func GetNotificationFromEnt(n *ent.Notification) *Notification {
+	return &Notification{
+		ID:         n.ID,
+		Kind:       n.Kind,
+		Title:      n.Title,
+		Message:    n.Message,
+		EntityType: n.EntityType,
+		EntityID:   n.EntityID,
+		ReadAt:     n.ReadAt,
+		CreatedAt:  n.CreatedAt,
+	}
+}
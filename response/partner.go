@@ -0,0 +1,120 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+type UserProfile struct {
+	ID               string     `json:"id"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email"`
+	Name             string     `json:"name"`
+	PhoneNumber      string     `json:"phone_number,omitempty"`
+	Gender           string     `json:"gender,omitempty"`
+	CurrentAddress   string     `json:"current_address,omitempty"`
+	PermanentAddress string     `json:"permanent_address,omitempty"`
+	DateOfBirth      *time.Time `json:"date_of_birth,omitempty"`
+	Role             string     `json:"role"`
+	IsEmailVerified  bool       `json:"is_email_verified"`
+	IsVerified       bool       `json:"is_verified"`
+	AccountOwnerID   string     `json:"account_owner_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type ListingStats struct {
+	PropertyID       string     `json:"property_id"`
+	Name             string     `json:"name"`
+	Slug             string     `json:"slug"`
+	ModerationStatus string     `json:"moderation_status"`
+	Views            int        `json:"views"`
+	Leads            int        `json:"leads"`
+	VerifiedLeads    int        `json:"verified_leads"`
+	LastLeadAt       *time.Time `json:"last_lead_at,omitempty"`
+}
+
+type ListingPerformance struct {
+	TotalViews         int             `json:"total_views"`
+	TotalLeads         int             `json:"total_leads"`
+	TotalVerifiedLeads int             `json:"total_verified_leads"`
+	Properties         []*ListingStats `json:"properties"`
+}
+
+type TeamMember struct {
+	ID          string    `json:"id"`
+	Username    string    `json:"username"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	PhoneNumber string    `json:"phone_number,omitempty"`
+	Role        string    `json:"role"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Invitation is a sent invite. The token is only emailed to the member; only its hash is stored.
+type Invitation struct {
+	Member    *TeamMember `json:"member"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
+
+func GetUserProfileFromEnt(u *ent.User) *UserProfile {
+	profile := &UserProfile{
+		ID:               u.ID,
+		Username:         u.Username,
+		Email:            u.Email,
+		Name:             u.Name,
+		PhoneNumber:      u.PhoneNumber,
+		Gender:           u.Gender,
+		CurrentAddress:   u.CurrentAddress,
+		PermanentAddress: u.PermanentAddress,
+		Role:             u.Role.String(),
+		IsEmailVerified:  u.IsEmailVerified,
+		IsVerified:       u.IsVerified,
+		CreatedAt:        u.CreatedAt,
+	}
+	if !u.DateOfBirth.IsZero() {
+		dob := u.DateOfBirth
+		profile.DateOfBirth = &dob
+	}
+	if u.Role.String() == domain.RolePartnerMember && u.Edges.CreatedByUser != nil {
+		profile.AccountOwnerID = u.Edges.CreatedByUser.ID
+	}
+	return profile
+}
+
+func GetTeamMemberFromEnt(u *ent.User) *TeamMember {
+	return &TeamMember{
+		ID:          u.ID,
+		Username:    u.Username,
+		Email:       u.Email,
+		Name:        u.Name,
+		PhoneNumber: u.PhoneNumber,
+		Role:        u.Role.String(),
+		IsActive:    u.IsActive,
+		CreatedAt:   u.CreatedAt,
+	}
+}
+
+func GetListingPerformance(stats []domain.ListingStats) *ListingPerformance {
+	performance := &ListingPerformance{
+		Properties: make([]*ListingStats, 0, len(stats)),
+	}
+	for _, s := range stats {
+		performance.TotalViews += s.Views
+		performance.TotalLeads += s.Leads
+		performance.TotalVerifiedLeads += s.VerifiedLeads
+		performance.Properties = append(performance.Properties, &ListingStats{
+			PropertyID:       s.PropertyID,
+			Name:             s.Name,
+			Slug:             s.Slug,
+			ModerationStatus: s.ModerationStatus,
+			Views:            s.Views,
+			Leads:            s.Leads,
+			VerifiedLeads:    s.VerifiedLeads,
+			LastLeadAt:       s.LastLeadAt,
+		})
+	}
+	return performance
+}
@@ -0,0 +1,76 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+// PriceChange is the percentage change of a project's starting price over the listing card
+// periods. A period is omitted when the history does not reach back that far.
+type PriceChange struct {
+	ThreeMonths  *float64 `json:"three_months,omitempty"`
+	SixMonths    *float64 `json:"six_months,omitempty"`
+	TwelveMonths *float64 `json:"twelve_months,omitempty"`
+}
+
+type PriceHistoryPoint struct {
+	Date     time.Time `json:"date"`
+	MinPrice float64   `json:"min_price"`
+	MaxPrice float64   `json:"max_price"`
+}
+
+// PriceSeries is the history of one configuration, ready to plot as a line chart.
+// The overall project range has an empty configuration.
+type PriceSeries struct {
+	Configuration string              `json:"configuration"`
+	Size          string              `json:"size,omitempty"`
+	Points        []PriceHistoryPoint `json:"points"`
+}
+
+type ProjectPriceHistory struct {
+	ProjectID string         `json:"project_id"`
+	MinPrice  string         `json:"min_price"`
+	MaxPrice  string         `json:"max_price"`
+	Change    *PriceChange   `json:"change,omitempty"`
+	Series    []*PriceSeries `json:"series"`
+}
+
+// GetPriceChange computes the listing card price change from an overall price series.
+func GetPriceChange(series []domain.PricePoint, now time.Time) *PriceChange {
+	change := &PriceChange{
+		ThreeMonths:  domain.PriceChangePercent(series, now, 3),
+		SixMonths:    domain.PriceChangePercent(series, now, 6),
+		TwelveMonths: domain.PriceChangePercent(series, now, 12),
+	}
+	if change.ThreeMonths == nil && change.SixMonths == nil && change.TwelveMonths == nil {
+		return nil
+	}
+	return change
+}
+
+// GetPriceSeriesFromEnt groups price points, sorted by time, into one series per
+// configuration. The overall range comes first, the rest in order of first appearance.
+func GetPriceSeriesFromEnt(points []*ent.ProjectPricePoint) []*PriceSeries {
+	series := make([]*PriceSeries, 0)
+	byConfiguration := make(map[string]*PriceSeries)
+	for _, point := range points {
+		s, ok := byConfiguration[point.Configuration]
+		if !ok {
+			s = &PriceSeries{Configuration: point.Configuration, Size: point.Size}
+			byConfiguration[point.Configuration] = s
+			if point.Configuration == domain.PriceConfigurationOverall {
+				series = append([]*PriceSeries{s}, series...)
+			} else {
+				series = append(series, s)
+			}
+		}
+		s.Points = append(s.Points, PriceHistoryPoint{
+			Date:     point.RecordedAt,
+			MinPrice: point.MinPrice,
+			MaxPrice: point.MaxPrice,
+		})
+	}
+	return series
+}
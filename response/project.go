@@ -25,27 +25,30 @@ type ProjectComparison struct {
 	IsPriority    bool                   `json:"is_priority"`
 	WebCards      schema.ProjectWebCards `json:"web_cards"`
 	DeveloperName string                 `json:"developer_name,omitempty"`
+	ReraApproved  bool                   `json:"rera_approved"`
 }
 
 type Project struct {
-	ProjectID     string                 `json:"project_id"`
-	ProjectName   string                 `json:"project_name"`
-	Description   string                 `json:"description"`
-	ProjectType   string                 `json:"project_type"`
-	Slug          string                 `json:"slug"`
-	Status        enums.ProjectStatus    `json:"status"`
-	MinPrice      string                 `json:"min_price"`
-	MaxPrice      string                 `json:"max_price"`
-	PriceUnit     string                 `json:"price_unit"`
-	TimelineInfo  schema.TimelineInfo    `json:"timeline_info"`
-	MetaInfo      schema.SEOMeta         `json:"meta_info"`
-	WebCards      schema.ProjectWebCards `json:"web_cards"`
-	LocationInfo  schema.LocationInfo    `json:"location_info"`
-	City          string                 `json:"city"`
-	DeveloperInfo DeveloperInfo          `json:"developer_info"`
-	IsFeatured    bool                   `json:"is_featured"`
-	IsPremium     bool                   `json:"is_premium"`
-	IsPriority    bool                   `json:"is_priority"`
+	ProjectID         string                 `json:"project_id"`
+	ProjectName       string                 `json:"project_name"`
+	Description       string                 `json:"description"`
+	ProjectType       string                 `json:"project_type"`
+	Slug              string                 `json:"slug"`
+	Status            enums.ProjectStatus    `json:"status"`
+	MinPrice          string                 `json:"min_price"`
+	MaxPrice          string                 `json:"max_price"`
+	PriceUnit         string                 `json:"price_unit"`
+	TimelineInfo      schema.TimelineInfo    `json:"timeline_info"`
+	MetaInfo          schema.SEOMeta         `json:"meta_info"`
+	WebCards          schema.ProjectWebCards `json:"web_cards"`
+	LocationInfo      schema.LocationInfo    `json:"location_info"`
+	City              string                 `json:"city"`
+	DeveloperInfo     DeveloperInfo          `json:"developer_info"`
+	IsFeatured        bool                   `json:"is_featured"`
+	IsPremium         bool                   `json:"is_premium"`
+	IsPriority        bool                   `json:"is_priority"`
+	ReraApproved      bool                   `json:"rera_approved"`
+	ReraRegistrations []*ReraRegistration    `json:"rera_registrations"`
 }
 
 type DeveloperInfo struct {
@@ -73,18 +76,20 @@ type UpdateProjectResponse struct {
 }
 
 type ProjectListResponse struct {
-	ProjectID     string   `json:"project_id"`
-	ProjectName   string   `json:"project_name"`
-	ShortAddress  string   `json:"short_address"`
-	City          string   `json:"city"`
-	Slug          string   `json:"slug"`
-	Images        []string `json:"images"`
-	Configuration string   `json:"configuration"`
-	MinPrice      string   `json:"min_price"`
-	Sizes         string   `json:"sizes"`
-	IsPremium     bool     `json:"is_premium"`
-	VideoURLs     []string `json:"video_urls"`
-	FullDetails   *Project `json:"full_details,omitempty"`
+	ProjectID     string       `json:"project_id"`
+	ProjectName   string       `json:"project_name"`
+	ShortAddress  string       `json:"short_address"`
+	City          string       `json:"city"`
+	Slug          string       `json:"slug"`
+	Images        []string     `json:"images"`
+	Configuration string       `json:"configuration"`
+	MinPrice      string       `json:"min_price"`
+	Sizes         string       `json:"sizes"`
+	IsPremium     bool         `json:"is_premium"`
+	VideoURLs     []string     `json:"video_urls"`
+	ReraApproved  bool         `json:"rera_approved"`
+	PriceChange   *PriceChange `json:"price_change,omitempty"`
+	FullDetails   *Project     `json:"full_details,omitempty"`
 }
 
 func GetProjectFromEnt(project *ent.Project) *Project {
@@ -128,9 +133,11 @@ func GetProjectFromEnt(project *ent.Project) *Project {
 			Address:         project.Edges.Developer.MediaContent.DeveloperAddress,
 			EstablishedYear: project.Edges.Developer.EstablishedYear,
 		},
-		IsFeatured: project.IsFeatured,
-		IsPremium:  project.IsPremium,
-		IsPriority: project.IsPriority,
+		IsFeatured:        project.IsFeatured,
+		IsPremium:         project.IsPremium,
+		IsPriority:        project.IsPriority,
+		ReraApproved:      IsProjectReraApproved(project),
+		ReraRegistrations: getReraRegistrationsFromEnt(project.Edges.ReraRegistrations),
 	}
 }
 
@@ -152,5 +159,6 @@ func GetProjectListResponse(project *ent.Project) *ProjectListResponse {
 		VideoURLs:     project.WebCards.VideoPresentation.URLs,
 		MinPrice:      project.MinPrice,
 		Slug:          project.Slug,
+		ReraApproved:  IsProjectReraApproved(project),
 	}
 }
@@ -0,0 +1,18 @@
+package response
+
+import "github.com/VI-IM/im_backend_go/internal/domain"
+
+type ProjectImportReport struct {
+	DryRun  bool                     `json:"dry_run"`
+	Valid   bool                     `json:"valid"`
+	Created int                      `json:"created"`
+	Updated int                      `json:"updated"`
+	Errors  []domain.BundleRowError  `json:"errors"`
+	Results []domain.BundleRowResult `json:"results"`
+}
+
+type ProjectExport struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
@@ -1,6 +1,8 @@
 package response
 
 import (
+	"time"
+
 	"github.com/VI-IM/im_backend_go/ent"
 	"github.com/VI-IM/im_backend_go/ent/schema"
 )
@@ -14,19 +16,24 @@ type SimpleDeveloper struct {
 }
 
 type Property struct {
-	ID              string                     `json:"id"`
-	Name            string                     `json:"name"`
-	Slug            string                     `json:"slug"`
-	PropertyImages  []string                   `json:"property_images"`
-	WebCards        WebCards                   `json:"web_cards"`
-	PricingInfo     schema.PropertyPricingInfo `json:"pricing_info"`
-	PropertyRera    schema.PropertyReraInfo    `json:"property_rera_info"`
-	MetaInfo        schema.PropertyMetaInfo    `json:"meta_info"`
-	DeveloperID     string                     `json:"developer_id"`
-	LocationID      string                     `json:"location_id"`
-	ProjectID       string                     `json:"project_id,omitempty"`
-	CreatedByUserID string                     `json:"created_by_user_id,omitempty"`
-	Developer       *SimpleDeveloper           `json:"developer,omitempty"`
+	ID                string                     `json:"id"`
+	Name              string                     `json:"name"`
+	Slug              string                     `json:"slug"`
+	PropertyImages    []string                   `json:"property_images"`
+	WebCards          WebCards                   `json:"web_cards"`
+	PricingInfo       schema.PropertyPricingInfo `json:"pricing_info"`
+	ListingType       string                     `json:"listing_type"`
+	AvailableFrom     *time.Time                 `json:"available_from,omitempty"`
+	MonthlyRent       *float64                   `json:"monthly_rent,omitempty"`
+	ModerationStatus  string                     `json:"moderation_status"`
+	PendingRevisionID string                     `json:"pending_revision_id,omitempty"`
+	PropertyRera      schema.PropertyReraInfo    `json:"property_rera_info"`
+	MetaInfo          schema.PropertyMetaInfo    `json:"meta_info"`
+	DeveloperID       string                     `json:"developer_id"`
+	LocationID        string                     `json:"location_id"`
+	ProjectID         string                     `json:"project_id,omitempty"`
+	CreatedByUserID   string                     `json:"created_by_user_id,omitempty"`
+	Developer         *SimpleDeveloper           `json:"developer,omitempty"`
 }
 
 type WebCards struct {
@@ -77,25 +84,30 @@ func GetPropertyFromEnt(property *ent.Property) *Property {
 	}
 
 	return &Property{
-		ID:              property.ID,
-		Name:            property.Name,
-		Slug:            property.Slug,
-		PropertyImages:  property.PropertyImages,
-		WebCards:        webCard,
-		PricingInfo:     property.PricingInfo,
-		PropertyRera:    property.PropertyReraInfo,
-		MetaInfo:        property.MetaInfo,
-		DeveloperID:     property.DeveloperID,
-		LocationID:      property.LocationID,
-		ProjectID:       property.ProjectID,
-		CreatedByUserID: property.CreatedByUserID,
-		Developer:       developer,
+		ID:               property.ID,
+		Name:             property.Name,
+		Slug:             property.Slug,
+		PropertyImages:   property.PropertyImages,
+		WebCards:         webCard,
+		PricingInfo:      property.PricingInfo,
+		ListingType:      property.ListingType.String(),
+		AvailableFrom:    property.AvailableFrom,
+		MonthlyRent:      property.MonthlyRent,
+		ModerationStatus: property.ModerationStatus.String(),
+		PropertyRera:     property.PropertyReraInfo,
+		MetaInfo:         property.MetaInfo,
+		DeveloperID:      property.DeveloperID,
+		LocationID:       property.LocationID,
+		ProjectID:        property.ProjectID,
+		CreatedByUserID:  property.CreatedByUserID,
+		Developer:        developer,
 	}
 }
 
 type AddPropertyResponse struct {
-	PropertyID string `json:"property_id"`
-	Slug       string `json:"slug"`
+	PropertyID       string `json:"property_id"`
+	Slug             string `json:"slug"`
+	ModerationStatus string `json:"moderation_status"`
 }
 
 type PropertyListResponse struct {
@@ -110,6 +122,37 @@ type PropertyListResponse struct {
 	DeveloperName    string   `json:"developer_name"`
 	Configuration    string   `json:"configuration"`
 	Slug             string   `json:"slug"`
+	ListingType      string   `json:"listing_type"`
+	Price            *float64 `json:"price,omitempty"`
+	MonthlyRent      *float64 `json:"monthly_rent,omitempty"`
+	BuiltUpAreaSqft  *float64 `json:"built_up_area_sqft,omitempty"`
+	PricePerSqft     *float64 `json:"price_per_sqft,omitempty"`
+	Bedrooms         *int     `json:"bedrooms,omitempty"`
+}
+
+// FacetCount is the number of listings for one value of a filter.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+type PropertyFacets struct {
+	ListingType      []FacetCount `json:"listing_type"`
+	Budget           []FacetCount `json:"budget"`
+	Rent             []FacetCount `json:"rent"`
+	Bedrooms         []FacetCount `json:"bedrooms"`
+	Bathrooms        []FacetCount `json:"bathrooms"`
+	Furnishing       []FacetCount `json:"furnishing"`
+	Facing           []FacetCount `json:"facing"`
+	Floor            []FacetCount `json:"floor"`
+	PossessionStatus []FacetCount `json:"possession_status"`
+	Age              []FacetCount `json:"age"`
+}
+
+type PropertyListPage struct {
+	*PaginatedResponse
+	Facets *PropertyFacets `json:"facets,omitempty"`
 }
 
 func GetPropertyListResponse(property *ent.Property, developerName string, location string) *PropertyListResponse {
@@ -125,5 +168,11 @@ func GetPropertyListResponse(property *ent.Property, developerName string, locat
 		Location:         location,
 		DeveloperName:    developerName,
 		Slug:             property.Slug,
+		ListingType:      property.ListingType.String(),
+		Price:            property.Price,
+		MonthlyRent:      property.MonthlyRent,
+		BuiltUpAreaSqft:  property.BuiltUpAreaSqft,
+		PricePerSqft:     property.PricePerSqft,
+		Bedrooms:         property.Bedrooms,
 	}
 }
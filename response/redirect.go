@@ -0,0 +1,57 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+type Redirect struct {
+	ID         string    `json:"id"`
+	FromPath   string    `json:"from_path"`
+	ToPath     string    `json:"to_path"`
+	StatusCode int       `json:"status_code"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type SlugRecord struct {
+	Slug       string     `json:"slug"`
+	Path       string     `json:"path"`
+	EntityType string     `json:"entity_type"`
+	EntityID   string     `json:"entity_id"`
+	IsCurrent  bool       `json:"is_current"`
+	RetiredAt  *time.Time `json:"retired_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type SlugSyncResult struct {
+	Registered int      `json:"registered"`
+	Conflicts  []string `json:"conflicts"`
+}
+
+func GetRedirectFromEnt(rd *ent.Redirect) *Redirect {
+	return &Redirect{
+		ID:         rd.ID,
+		FromPath:   rd.FromPath,
+		ToPath:     rd.ToPath,
+		StatusCode: rd.StatusCode,
+		Reason:     rd.Reason,
+		CreatedAt:  rd.CreatedAt,
+		UpdatedAt:  rd.UpdatedAt,
+	}
+}
+
+func GetSlugRecordFromEnt(record *ent.SlugRecord) *SlugRecord {
+	return &SlugRecord{
+		Slug:       record.Slug,
+		Path:       domain.SlugPath(string(record.EntityType), record.Slug),
+		EntityType: string(record.EntityType),
+		EntityID:   record.EntityID,
+		IsCurrent:  record.IsCurrent,
+		RetiredAt:  record.RetiredAt,
+		CreatedAt:  record.CreatedAt,
+	}
+}
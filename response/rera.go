@@ -0,0 +1,76 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+type ReraRegistration struct {
+	ID                 string     `json:"id"`
+	ProjectID          string     `json:"project_id"`
+	ProjectName        string     `json:"project_name,omitempty"`
+	Phase              string     `json:"phase"`
+	State              string     `json:"state"`
+	Authority          string     `json:"authority"`
+	RegistrationNumber string     `json:"registration_number"`
+	ValidFrom          *time.Time `json:"valid_from,omitempty"`
+	ValidUntil         *time.Time `json:"valid_until,omitempty"`
+	Status             string     `json:"status"`
+	QRImage            string     `json:"qr_image"`
+	WebsiteLink        string     `json:"website_link"`
+	LastCheckedAt      *time.Time `json:"last_checked_at,omitempty"`
+}
+
+type ReraStatusRefreshResponse struct {
+	Checked  int `json:"checked"`
+	Updated  int `json:"updated"`
+	Expiring int `json:"expiring"`
+	Expired  int `json:"expired"`
+}
+
+type ReraBackfillResult struct {
+	Registered int      `json:"registered"`
+	Skipped    []string `json:"skipped"`
+}
+
+func GetReraRegistrationFromEnt(registration *ent.ReraRegistration) *ReraRegistration {
+	resp := &ReraRegistration{
+		ID:                 registration.ID,
+		ProjectID:          registration.ProjectID,
+		Phase:              registration.Phase,
+		State:              registration.State,
+		Authority:          registration.Authority,
+		RegistrationNumber: registration.RegistrationNumber,
+		ValidFrom:          registration.ValidFrom,
+		ValidUntil:         registration.ValidUntil,
+		Status:             string(registration.Status),
+		QRImage:            registration.QrImage,
+		WebsiteLink:        registration.WebsiteLink,
+		LastCheckedAt:      registration.LastCheckedAt,
+	}
+	if registration.Edges.Project != nil {
+		resp.ProjectName = registration.Edges.Project.Name
+	}
+	return resp
+}
+
+func getReraRegistrationsFromEnt(registrations []*ent.ReraRegistration) []*ReraRegistration {
+	resp := make([]*ReraRegistration, 0, len(registrations))
+	for _, registration := range registrations {
+		resp = append(resp, GetReraRegistrationFromEnt(registration))
+	}
+	return resp
+}
+
+// IsProjectReraApproved reports whether the project has at least one active RERA
+// registration. The rera_registrations edge must be loaded.
+func IsProjectReraApproved(project *ent.Project) bool {
+	for _, registration := range project.Edges.ReraRegistrations {
+		if domain.IsReraApprovedStatus(string(registration.Status)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package response
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+	"github.com/VI-IM/im_backend_go/internal/domain"
+)
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+	// TwoFactorRequired makes users of the role enroll in 2FA before they can sign in.
+	TwoFactorRequired bool `json:"two_factor_required"`
+	// Editable is false for superadmin, which always has every permission.
+	Editable        bool       `json:"editable"`
+	UpdatedByUserID string     `json:"updated_by_user_id,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+func GetPermissions() []*Permission {
+	permissions := make([]*Permission, 0, len(domain.AllPermissions))
+	for _, p := range domain.AllPermissions {
+		permissions = append(permissions, &Permission{Name: p.Name, Description: p.Description})
+	}
+	return permissions
+}
+
+func GetRoleFromEnt(ro *ent.Role) *Role {
+	set := domain.RolePermissionSet(ro.ID, ro.Permissions)
+	permissions := make([]string, 0, len(set))
+	for p := range set {
+		permissions = append(permissions, p)
+	}
+	sort.Strings(permissions)
+
+	updatedAt := ro.UpdatedAt
+	return &Role{
+		Name:              ro.ID,
+		Description:       ro.Description,
+		Permissions:       permissions,
+		TwoFactorRequired: ro.TwoFactorRequired,
+		Editable:          ro.ID != domain.RoleSuperAdmin,
+		UpdatedByUserID:   ro.UpdatedByUserID,
+		UpdatedAt:         &updatedAt,
+	}
+}
+
+// APIKey describes a key without its secret.
+type APIKey struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Prefix          string     `json:"prefix"`
+	Permissions     []string   `json:"permissions"`
+	AllowedIPs      []string   `json:"allowed_ips"`
+	CreatedByUserID string     `json:"created_by_user_id"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt      *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP      string     `json:"last_used_ip,omitempty"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty"`
+	RevokedByUserID string     `json:"revoked_by_user_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKey carries a new key once; only its hash is stored.
+type CreatedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+func GetAPIKeyFromEnt(k *ent.APIKey) *APIKey {
+	allowedIPs := k.AllowedIps
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+	return &APIKey{
+		ID:              k.ID,
+		Name:            k.Name,
+		Prefix:          k.Prefix,
+		Permissions:     k.Permissions,
+		AllowedIPs:      allowedIPs,
+		CreatedByUserID: k.CreatedByUserID,
+		ExpiresAt:       k.ExpiresAt,
+		LastUsedAt:      k.LastUsedAt,
+		LastUsedIP:      k.LastUsedIP,
+		RevokedAt:       k.RevokedAt,
+		RevokedByUserID: k.RevokedByUserID,
+		CreatedAt:       k.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+)
+
+type SavedSearch struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name,omitempty"`
+	Channel    string                 `json:"channel"`
+	Frequency  string                 `json:"frequency"`
+	Filters    map[string]interface{} `json:"filters"`
+	Verified   bool                   `json:"verified"`
+	VerifiedAt *time.Time             `json:"verified_at,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+type SavedSearchAlertResult struct {
+	Checked int `json:"checked"`
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+}
+
+func GetSavedSearchFromEnt(saved *ent.SavedSearch) *SavedSearch {
+	return &SavedSearch{
+		ID:         saved.ID,
+		Name:       saved.Name,
+		Channel:    saved.Channel.String(),
+		Frequency:  saved.Frequency.String(),
+		Filters:    saved.Filters,
+		Verified:   saved.VerifiedAt != nil,
+		VerifiedAt: saved.VerifiedAt,
+		CreatedAt:  saved.CreatedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package response
+
+import "github.com/VI-IM/im_backend_go/ent"
+
+type SimilarProject struct {
+	*ProjectListResponse
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+func GetSimilarProject(project *ent.Project, score float64, reasons []string) *SimilarProject {
+	if reasons == nil {
+		reasons = []string{}
+	}
+	return &SimilarProject{
+		ProjectListResponse: GetProjectListResponse(project),
+		Score:               score,
+		Reasons:             reasons,
+	}
+}
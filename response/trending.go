@@ -0,0 +1,25 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+)
+
+type TrendingProject struct {
+	*ProjectListResponse
+	Locality   string    `json:"locality,omitempty"`
+	Rank       int       `json:"rank"`
+	Score      float64   `json:"score"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+func GetTrendingProject(popularity *ent.ProjectPopularity, rank int) *TrendingProject {
+	return &TrendingProject{
+		ProjectListResponse: GetProjectListResponse(popularity.Edges.Project),
+		Locality:            popularity.Locality,
+		Rank:                rank,
+		Score:               popularity.Score,
+		ComputedAt:          popularity.ComputedAt,
+	}
+}
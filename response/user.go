@@ -0,0 +1,64 @@
+package response
+
+import (
+	"time"
+
+	"github.com/VI-IM/im_backend_go/ent"
+)
+
+// AdminUser is a user as superadmins manage it.
+type AdminUser struct {
+	ID              string     `json:"id"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	Name            string     `json:"name"`
+	PhoneNumber     string     `json:"phone_number,omitempty"`
+	Role            string     `json:"role"`
+	IsActive        bool       `json:"is_active"`
+	IsVerified      bool       `json:"is_verified"`
+	IsEmailVerified bool       `json:"is_email_verified"`
+	ParentID        int        `json:"parent_id,omitempty"`
+	LastLoginTime   *time.Time `json:"last_login_time,omitempty"`
+	FailedLogins    int        `json:"failed_logins"`
+	LockedUntil     *time.Time `json:"locked_until,omitempty"`
+	CreatedByUserID string     `json:"created_by_user_id,omitempty"`
+	UpdatedByUserID string     `json:"updated_by_user_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// UserCredentials carries a generated password once; only its hash is stored.
+type UserCredentials struct {
+	User              *AdminUser `json:"user"`
+	TemporaryPassword string     `json:"temporary_password,omitempty"`
+}
+
+func GetAdminUserFromEnt(u *ent.User) *AdminUser {
+	user := &AdminUser{
+		ID:              u.ID,
+		Username:        u.Username,
+		Email:           u.Email,
+		Name:            u.Name,
+		PhoneNumber:     u.PhoneNumber,
+		Role:            u.Role.String(),
+		IsActive:        u.IsActive,
+		IsVerified:      u.IsVerified,
+		IsEmailVerified: u.IsEmailVerified,
+		ParentID:        u.ParentID,
+		FailedLogins:    u.FailedLoginCount,
+		LockedUntil:     u.LockedUntil,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
+	}
+	if !u.LastLoginTime.IsZero() {
+		lastLogin := u.LastLoginTime
+		user.LastLoginTime = &lastLogin
+	}
+	if u.Edges.CreatedByUser != nil {
+		user.CreatedByUserID = u.Edges.CreatedByUser.ID
+	}
+	if u.Edges.UpdatedByUser != nil {
+		user.UpdatedByUserID = u.Edges.UpdatedByUser.ID
+	}
+	return user
+}